@@ -0,0 +1,449 @@
+// Command sentineldiff generates reflection-free Diff and Merge functions
+// for sentinel structs and registers them with diffutils, which then uses
+// them as its fast path.
+//
+// Typical use, next to the type declaration:
+//
+//	//go:generate go run github.com/zodimo/go-sentinel-helper/cmd/sentineldiff -type Settings
+//
+// Every field must have a sentinel convention the generator knows: int,
+// float32 and float64 (including local named float types), string,
+// intutils.IntValue, stringutils.StringValue, *wrapperspb.XValue,
+// boolutils.BooleanValue, enumutils.Enum[D] or a struct type declared in
+// the same package, which is generated as well. Anything else is an error;
+// such types can still use the reflective diffutils.Diff.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"log"
+	"maps"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	modulePath     = "github.com/zodimo/go-sentinel-helper"
+	diffutilsPath  = modulePath + "/sentinel/diffutils"
+	intutilsPath   = modulePath + "/sentinel/intutils"
+	floatutilsPath = modulePath + "/sentinel/floatutils"
+	stringsPath    = modulePath + "/sentinel/stringutils"
+	boolutilsPath  = modulePath + "/sentinel/boolutils"
+	enumutilsPath  = modulePath + "/sentinel/enumutils"
+	wrapperPath    = modulePath + "/sentinel/protobufwrapper"
+	wrapperspbPath = "google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var wrapperTypes = map[string]bool{
+	"BoolValue": true, "BytesValue": true, "DoubleValue": true,
+	"FloatValue": true, "Int32Value": true, "Int64Value": true,
+	"StringValue": true, "UInt32Value": true, "UInt64Value": true,
+}
+
+type fieldKind int
+
+const (
+	kindInt fieldKind = iota
+	kindFloat32
+	kindFloat64
+	kindString
+	kindWrapper
+	kindMethods
+	kindNested
+)
+
+type field struct {
+	name     string
+	kind     fieldKind
+	typeExpr string // Go source of the field type
+	wrapper  string // wrapperspb type name for kindWrapper
+}
+
+type structInfo struct {
+	name   string
+	fields []field
+}
+
+type generator struct {
+	fset    *token.FileSet
+	pkgName string
+	types   map[string]*ast.TypeSpec
+	imports map[*ast.TypeSpec]map[string]string // local name -> path
+	used    map[string]string                   // path -> local name
+	done    map[string]*structInfo
+	order   []string
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("sentineldiff: ")
+	typeNames := flag.String("type", "", "comma-separated list of struct type names; must be set")
+	output := flag.String("output", "", "output file name; default <type>_diff.go")
+	flag.Parse()
+	if *typeNames == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+
+	g, err := load(dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+	names := strings.Split(*typeNames, ",")
+	for _, name := range names {
+		if err := g.collect(strings.TrimSpace(name)); err != nil {
+			log.Fatal(err)
+		}
+	}
+	src, err := g.generate()
+	if err != nil {
+		log.Fatal(err)
+	}
+	out := *output
+	if out == "" {
+		out = strings.ToLower(strings.TrimSpace(names[0])) + "_diff.go"
+	}
+	if err := os.WriteFile(filepath.Join(dir, out), src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func load(dir string) (*generator, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("expected one package in %s, found %d", dir, len(pkgs))
+	}
+	g := &generator{
+		fset:    fset,
+		types:   map[string]*ast.TypeSpec{},
+		imports: map[*ast.TypeSpec]map[string]string{},
+		used:    map[string]string{},
+		done:    map[string]*structInfo{},
+	}
+	for name, pkg := range pkgs {
+		g.pkgName = name
+		for _, file := range pkg.Files {
+			imports := map[string]string{}
+			for _, imp := range file.Imports {
+				path, _ := strconv.Unquote(imp.Path.Value)
+				local := path[strings.LastIndex(path, "/")+1:]
+				if imp.Name != nil {
+					local = imp.Name.Name
+				}
+				imports[local] = path
+			}
+			for _, decl := range file.Decls {
+				gd, ok := decl.(*ast.GenDecl)
+				if !ok || gd.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range gd.Specs {
+					ts := spec.(*ast.TypeSpec)
+					g.types[ts.Name.Name] = ts
+					g.imports[ts] = imports
+				}
+			}
+		}
+	}
+	return g, nil
+}
+
+func (g *generator) source(expr ast.Expr) string {
+	var buf bytes.Buffer
+	printer.Fprint(&buf, g.fset, expr)
+	return buf.String()
+}
+
+func (g *generator) use(path string) string {
+	if local, ok := g.used[path]; ok {
+		return local
+	}
+	local := path[strings.LastIndex(path, "/")+1:]
+	g.used[path] = local
+	return local
+}
+
+func (g *generator) collect(name string) error {
+	if _, ok := g.done[name]; ok {
+		return nil
+	}
+	ts, ok := g.types[name]
+	if !ok {
+		return fmt.Errorf("type %s not found", name)
+	}
+	st, ok := ts.Type.(*ast.StructType)
+	if !ok || ts.TypeParams != nil {
+		return fmt.Errorf("%s is not a non-generic struct type", name)
+	}
+	info := &structInfo{name: name}
+	g.done[name] = info
+	for _, f := range st.Fields.List {
+		if len(f.Names) == 0 {
+			return fmt.Errorf("%s: embedded fields are not supported", name)
+		}
+		fd, err := g.classify(ts, f.Type)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", name, f.Names[0].Name, err)
+		}
+		for _, n := range f.Names {
+			if !n.IsExported() {
+				continue
+			}
+			fd.name = n.Name
+			info.fields = append(info.fields, fd)
+		}
+	}
+	g.order = append(g.order, name)
+	return nil
+}
+
+func (g *generator) classify(owner *ast.TypeSpec, expr ast.Expr) (field, error) {
+	fd := field{typeExpr: g.source(expr)}
+	imports := g.imports[owner]
+	switch t := expr.(type) {
+	case *ast.Ident:
+		switch t.Name {
+		case "int":
+			fd.kind = kindInt
+			return fd, nil
+		case "float32":
+			fd.kind = kindFloat32
+			return fd, nil
+		case "float64":
+			fd.kind = kindFloat64
+			return fd, nil
+		case "string":
+			fd.kind = kindString
+			return fd, nil
+		}
+		ts, ok := g.types[t.Name]
+		if !ok {
+			break
+		}
+		if ts.Assign.IsValid() {
+			// Alias such as `type Alignment = enumutils.Enum[alignmentDef]`.
+			if _, isStruct := ts.Type.(*ast.StructType); !isStruct {
+				// The generated code names the alias, not the
+				// aliased type, so its imports are not needed.
+				used := maps.Clone(g.used)
+				aliased, err := g.classify(ts, ts.Type)
+				g.used = used
+				aliased.typeExpr = t.Name
+				return aliased, err
+			}
+		}
+		switch under := ts.Type.(type) {
+		case *ast.StructType:
+			fd.kind = kindNested
+			return fd, g.collect(t.Name)
+		case *ast.Ident:
+			switch under.Name {
+			case "float32":
+				fd.kind = kindFloat32
+				return fd, nil
+			case "float64":
+				fd.kind = kindFloat64
+				return fd, nil
+			}
+		}
+	case *ast.SelectorExpr:
+		pkg, ok := t.X.(*ast.Ident)
+		if !ok {
+			break
+		}
+		switch path := imports[pkg.Name]; {
+		case path == intutilsPath && t.Sel.Name == "IntValue":
+			fd.kind = kindInt
+			return fd, nil
+		case path == stringsPath && t.Sel.Name == "StringValue":
+			fd.kind = kindString
+			return fd, nil
+		case path == boolutilsPath && t.Sel.Name == "BooleanValue":
+			// The type expression names the package, so the
+			// generated file must import it under the same name.
+			g.used[path] = pkg.Name
+			fd.kind = kindMethods
+			return fd, nil
+		}
+	case *ast.IndexExpr:
+		if sel, ok := t.X.(*ast.SelectorExpr); ok {
+			if pkg, ok := sel.X.(*ast.Ident); ok && imports[pkg.Name] == enumutilsPath && sel.Sel.Name == "Enum" {
+				g.used[enumutilsPath] = pkg.Name
+				fd.kind = kindMethods
+				return fd, nil
+			}
+		}
+	case *ast.StarExpr:
+		if sel, ok := t.X.(*ast.SelectorExpr); ok {
+			if pkg, ok := sel.X.(*ast.Ident); ok && imports[pkg.Name] == wrapperspbPath && wrapperTypes[sel.Sel.Name] {
+				fd.kind = kindWrapper
+				fd.wrapper = sel.Sel.Name
+				return fd, nil
+			}
+		}
+	}
+	return fd, fmt.Errorf("unsupported field type %s", fd.typeExpr)
+}
+
+func (g *generator) generate() ([]byte, error) {
+	diffutils := g.use(diffutilsPath)
+	var body bytes.Buffer
+	w := func(format string, args ...any) { fmt.Fprintf(&body, format, args...) }
+
+	w("func init() {\n")
+	for _, name := range g.order {
+		w("\t%s.Register(diff%s, merge%s)\n", diffutils, name, name)
+	}
+	w("}\n")
+
+	for _, name := range g.order {
+		info := g.done[name]
+		w(`
+// diff%[1]s is the generated fast path of diffutils.Diff for %[1]s.
+func diff%[1]s(base, updated *%[1]s) *%[1]s {
+	if base == nil {
+		base = %[2]s.Unspecified[%[1]s]()
+	}
+	if updated == nil {
+		updated = %[2]s.Unspecified[%[1]s]()
+	}
+	patch := new(%[1]s)
+	diff%[1]sInto(patch, base, updated)
+	return patch
+}
+
+// merge%[1]s is the generated fast path of diffutils.Merge for %[1]s.
+func merge%[1]s(base, patch *%[1]s) *%[1]s {
+	if base == nil {
+		base = %[2]s.Unspecified[%[1]s]()
+	}
+	if patch == nil {
+		patch = %[2]s.Unspecified[%[1]s]()
+	}
+	out := new(%[1]s)
+	merge%[1]sInto(out, base, patch)
+	return out
+}
+`, name, diffutils)
+
+		w("\nfunc diff%sInto(p, b, u *%s) {\n", name, name)
+		for _, f := range info.fields {
+			g.writeDiff(w, f)
+		}
+		w("}\n")
+
+		w("\nfunc merge%sInto(out, b, p *%s) {\n\t*out = *b\n", name, name)
+		for _, f := range info.fields {
+			g.writeMerge(w, f)
+		}
+		w("}\n")
+	}
+
+	var src bytes.Buffer
+	fmt.Fprintf(&src, "// Code generated by sentineldiff; DO NOT EDIT.\n\npackage %s\n\nimport (\n", g.pkgName)
+	paths := make([]string, 0, len(g.used))
+	for path := range g.used {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		local := g.used[path]
+		if local == path[strings.LastIndex(path, "/")+1:] {
+			fmt.Fprintf(&src, "\t%q\n", path)
+		} else {
+			fmt.Fprintf(&src, "\t%s %q\n", local, path)
+		}
+	}
+	src.WriteString(")\n\n")
+	src.Write(body.Bytes())
+	return format.Source(src.Bytes())
+}
+
+// leafFuncs returns the IsSpecified… and Equal… functions of f's type and
+// the expression of its Unspecified value.
+func (g *generator) leafFuncs(f field) (isSpecified, equal, unspecified string) {
+	switch f.kind {
+	case kindInt:
+		p := g.use(intutilsPath)
+		return p + ".IsSpecifiedIntValue", p + ".EqualIntValue", p + ".IntValueUnspecified"
+	case kindFloat32, kindFloat64:
+		p := g.use(floatutilsPath)
+		base, sentinel := "float64", p+".Float64Unspecified"
+		if f.kind == kindFloat32 {
+			base, sentinel = "float32", p+".Float32Unspecified"
+		}
+		if f.typeExpr != base {
+			sentinel = fmt.Sprintf("%s(%s)", f.typeExpr, sentinel)
+		}
+		return fmt.Sprintf("%s.IsSpecified[%s]", p, f.typeExpr),
+			fmt.Sprintf("%s.Equal[%s]", p, f.typeExpr),
+			sentinel
+	case kindString:
+		p := g.use(stringsPath)
+		return p + ".IsSpecifiedString", p + ".SameString", p + ".StringValueUnspecified"
+	case kindWrapper:
+		p := g.use(wrapperPath)
+		equal := p + ".Equal" + f.wrapper
+		if f.wrapper == "StringValue" {
+			equal = g.use(diffutilsPath) + ".SameStringValue"
+		}
+		return p + ".IsSpecified" + f.wrapper, equal, p + "." + f.wrapper + "Unspecified"
+	default: // kindMethods
+		return f.typeExpr + ".IsSpecified", f.typeExpr + ".Equal", f.typeExpr + "{}"
+	}
+}
+
+func (g *generator) writeDiff(w func(string, ...any), f field) {
+	if f.kind == kindNested {
+		w("\tdiff%sInto(&p.%s, &b.%s, &u.%s)\n", f.typeExpr, f.name, f.name, f.name)
+		return
+	}
+	isSpecified, equal, unspecified := g.leafFuncs(f)
+	value := "u." + f.name
+	if f.kind == kindWrapper {
+		value = fmt.Sprintf("%s.Copy%s(u.%s)", g.use(wrapperPath), f.wrapper, f.name)
+	}
+	w("\tif %s.Changed(b.%s, u.%s, %s, %s) {\n\t\tp.%s = %s\n\t} else {\n\t\tp.%s = %s\n\t}\n",
+		g.use(diffutilsPath), f.name, f.name, isSpecified, equal, f.name, value, f.name, unspecified)
+}
+
+func (g *generator) writeMerge(w func(string, ...any), f field) {
+	var expr string
+	switch f.kind {
+	case kindNested:
+		w("\tmerge%sInto(&out.%s, &b.%s, &p.%s)\n", f.typeExpr, f.name, f.name, f.name)
+		return
+	case kindInt:
+		expr = fmt.Sprintf("%s.MergeIntValue(b.%s, p.%s)", g.use(intutilsPath), f.name, f.name)
+	case kindFloat32, kindFloat64:
+		expr = fmt.Sprintf("%s.Merge(b.%s, p.%s)", g.use(floatutilsPath), f.name, f.name)
+	case kindString:
+		expr = fmt.Sprintf("%s.MergeString(b.%s, p.%s)", g.use(stringsPath), f.name, f.name)
+	case kindWrapper:
+		p := g.use(wrapperPath)
+		expr = fmt.Sprintf("%s.Copy%s(%s.Merge%s(b.%s, p.%s))", p, f.wrapper, p, f.wrapper, f.name, f.name)
+	case kindMethods:
+		expr = fmt.Sprintf("b.%s.Merge(p.%s)", f.name, f.name)
+	}
+	w("\tout.%s = %s\n", f.name, expr)
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const fixtureDir = "../../sentinel/diffutils/internal/difftest"
+
+// TestFixtureUpToDate fails when the committed fixture output differs from
+// what the generator produces now; run `go generate ./...` to refresh it.
+func TestFixtureUpToDate(t *testing.T) {
+	g, err := load(fixtureDir)
+	require.NoError(t, err)
+	require.NoError(t, g.collect("Settings"))
+	got, err := g.generate()
+	require.NoError(t, err)
+
+	want, err := os.ReadFile(filepath.Join(fixtureDir, "settings_diff.go"))
+	require.NoError(t, err)
+	require.Equal(t, string(want), string(got))
+}
+
+func TestUnsupportedField(t *testing.T) {
+	dir := t.TempDir()
+	src := "package p\n\ntype T struct {\n\tTags []string\n}\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "t.go"), []byte(src), 0o644))
+
+	g, err := load(dir)
+	require.NoError(t, err)
+	err = g.collect("T")
+	require.ErrorContains(t, err, "T.Tags: unsupported field type []string")
+
+	require.ErrorContains(t, g.collect("Missing"), "type Missing not found")
+}
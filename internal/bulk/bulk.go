@@ -0,0 +1,25 @@
+// Package bulk holds what the bulk operations of floatutils, intutils and
+// boolutils share.
+//
+// Each of those loops is a single pass with no calls and turns the
+// sentinel test into arithmetic or a conditional move rather than a
+// branch, so its cost does not depend on where the Unspecified values
+// fall. Their masks are little-endian bitmaps: bit i%64 of word i/64 is
+// set when values[i] is specified, the layout of column.Bitmap and of
+// Apache Arrow validity bitmaps.
+package bulk
+
+// B2U converts a bool to 0 or 1; the compiler inlines it and emits a flag
+// move, not a jump.
+func B2U(b bool) uint64 {
+	var u uint64
+	if b {
+		u = 1
+	}
+	return u
+}
+
+// MaskWords returns the number of mask words for n values.
+func MaskWords(n int) int {
+	return (n + 63) / 64
+}
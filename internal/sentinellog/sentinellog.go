@@ -0,0 +1,23 @@
+// Package sentinellog holds the slog value shared by the per-package Attr
+// constructors and slogutils.Handler, so the handler can recognise an
+// Unspecified value without importing every sentinel package.
+package sentinellog
+
+import "log/slog"
+
+// Text is how an Unspecified value reads in a log line.
+const Text = "<unspecified>"
+
+// Unspecified marks a logged value as Unspecified. It resolves to Text, so
+// handlers that know nothing about sentinels still print something readable.
+type Unspecified struct{}
+
+// LogValue implements slog.LogValuer.
+func (Unspecified) LogValue() slog.Value {
+	return slog.StringValue(Text)
+}
+
+// Value returns the marker as a slog.Value.
+func Value() slog.Value {
+	return slog.AnyValue(Unspecified{})
+}
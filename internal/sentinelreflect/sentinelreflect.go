@@ -0,0 +1,247 @@
+// Package sentinelreflect classifies reflected values by the sentinel
+// convention of their type, for packages that walk arbitrary structs.
+package sentinelreflect
+
+import (
+	"encoding"
+	"math"
+	"reflect"
+	"time"
+
+	"github.com/zodimo/go-sentinel-helper/sentinel/boolutils"
+	"github.com/zodimo/go-sentinel-helper/sentinel/floatutils"
+	"github.com/zodimo/go-sentinel-helper/sentinel/intutils"
+	"github.com/zodimo/go-sentinel-helper/sentinel/protobufwrapper"
+	"github.com/zodimo/go-sentinel-helper/sentinel/stringutils"
+	"github.com/zodimo/go-sentinel-helper/sentinel/timeutils"
+	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// BooleanValueType is the reflected boolutils.BooleanValue type.
+var BooleanValueType = reflect.TypeFor[boolutils.BooleanValue]()
+
+// DurationType and TimeType are the reflected time types, whose sentinels
+// are timeutils.DurationUnspecified and timeutils.TimeUnspecified.
+var (
+	DurationType = reflect.TypeFor[time.Duration]()
+	TimeType     = reflect.TypeFor[time.Time]()
+)
+
+// wrapperUnspecified maps each supported wrapperspb pointer type to its sentinel.
+var wrapperUnspecified = map[reflect.Type]reflect.Value{
+	reflect.TypeFor[*wrapperspb.BoolValue]():   reflect.ValueOf(protobufwrapper.BoolValueUnspecified),
+	reflect.TypeFor[*wrapperspb.BytesValue]():  reflect.ValueOf(protobufwrapper.BytesValueUnspecified),
+	reflect.TypeFor[*wrapperspb.DoubleValue](): reflect.ValueOf(protobufwrapper.DoubleValueUnspecified),
+	reflect.TypeFor[*wrapperspb.FloatValue]():  reflect.ValueOf(protobufwrapper.FloatValueUnspecified),
+	reflect.TypeFor[*wrapperspb.Int32Value]():  reflect.ValueOf(protobufwrapper.Int32ValueUnspecified),
+	reflect.TypeFor[*wrapperspb.Int64Value]():  reflect.ValueOf(protobufwrapper.Int64ValueUnspecified),
+	reflect.TypeFor[*wrapperspb.StringValue](): reflect.ValueOf(protobufwrapper.StringValueUnspecified),
+	reflect.TypeFor[*wrapperspb.UInt32Value](): reflect.ValueOf(protobufwrapper.UInt32ValueUnspecified),
+	reflect.TypeFor[*wrapperspb.UInt64Value](): reflect.ValueOf(protobufwrapper.UInt64ValueUnspecified),
+}
+
+// unspecifiedPredicate is the value-receiver half of Sentinel.
+type unspecifiedPredicate interface {
+	IsUnspecified() bool
+}
+
+// Sentinel is implemented through a pointer by this module's value types
+// (BooleanValue, enumutils.Enum, decimalutils.Decimal, ...), so that
+// reflection-based packages (yamlutils, diffutils, envutils, patchutils)
+// can recognise them and reset fields through SetUnspecified. IsUnspecified
+// alone is not enough: foreign types use the name for other things, such
+// as netip.Addr for 0.0.0.0, and their zero value need not be Unspecified.
+type Sentinel interface {
+	IsUnspecified() bool
+	// SetUnspecified stores the type's Unspecified sentinel in the receiver.
+	SetUnspecified()
+}
+
+var sentinelType = reflect.TypeFor[Sentinel]()
+
+// IsValueType reports whether *t implements Sentinel, i.e. t is one of this
+// module's value types.
+func IsValueType(t reflect.Type) bool {
+	return t.Kind() != reflect.Pointer && reflect.PointerTo(t).Implements(sentinelType)
+}
+
+// IsWrapper reports whether t is a supported *wrapperspb.XValue type.
+func IsWrapper(t reflect.Type) bool {
+	_, ok := wrapperUnspecified[t]
+	return ok
+}
+
+// IsLeaf reports whether t has a sentinel convention of its own: a 1-D value
+// type, a wrapperspb pointer, time.Duration, time.Time, or a type whose kind
+// is int, float32, float64 or string.
+func IsLeaf(t reflect.Type) bool {
+	if IsValueType(t) || IsWrapper(t) || t == DurationType || t == TimeType {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Float32, reflect.Float64, reflect.String:
+		return true
+	}
+	return false
+}
+
+var (
+	textMarshalerType   = reflect.TypeFor[encoding.TextMarshaler]()
+	binaryMarshalerType = reflect.TypeFor[encoding.BinaryMarshaler]()
+)
+
+// IsOpaque reports whether the struct type t must be handled as a whole
+// rather than field by field: it has no exported fields (time.Time,
+// netip.Addr), an Equal method, or a text or binary encoding of its own.
+func IsOpaque(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	if _, ok := EqualMethod(t); ok {
+		return true
+	}
+	pt := reflect.PointerTo(t)
+	if pt.Implements(textMarshalerType) || pt.Implements(binaryMarshalerType) {
+		return true
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() {
+			return false
+		}
+	}
+	return true
+}
+
+// EqualMethod returns t's `Equal(t) bool` method, if it has one.
+func EqualMethod(t reflect.Type) (reflect.Method, bool) {
+	m, ok := t.MethodByName("Equal")
+	if !ok || m.Type.NumIn() != 2 || m.Type.In(1) != t || m.Type.NumOut() != 1 || m.Type.Out(0).Kind() != reflect.Bool {
+		return reflect.Method{}, false
+	}
+	return m, true
+}
+
+// IsNested reports whether t is a struct that should be walked field by field.
+func IsNested(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && !IsLeaf(t) && !IsOpaque(t)
+}
+
+// SetUnspecified stores the Unspecified sentinel of v's type into v, which
+// must be settable. It reports false, leaving v untouched, when the type is
+// not a leaf.
+func SetUnspecified(v reflect.Value) bool {
+	t := v.Type()
+	if IsValueType(t) {
+		v.Addr().Interface().(Sentinel).SetUnspecified()
+		return true
+	}
+	if sentinel, ok := wrapperUnspecified[t]; ok {
+		v.Set(sentinel)
+		return true
+	}
+	switch t {
+	case DurationType:
+		v.SetInt(int64(timeutils.DurationUnspecified))
+		return true
+	case TimeType:
+		v.Set(reflect.ValueOf(timeutils.TimeUnspecified))
+		return true
+	}
+
+	switch t.Kind() {
+	case reflect.Int:
+		v.SetInt(int64(intutils.IntValueUnspecified))
+	case reflect.Float32:
+		v.SetFloat(float64(floatutils.Float32Unspecified))
+	case reflect.Float64:
+		v.SetFloat(floatutils.Float64Unspecified)
+	case reflect.String:
+		v.SetString(stringutils.StringValueUnspecified)
+	default:
+		return false
+	}
+	return true
+}
+
+// IsUnspecified reports whether the leaf value v holds its type's sentinel.
+// A nil wrapper pointer counts as Unspecified. Non-leaf values never do.
+func IsUnspecified(v reflect.Value) bool {
+	t := v.Type()
+	if IsValueType(t) {
+		return v.Interface().(unspecifiedPredicate).IsUnspecified()
+	}
+	if sentinel, ok := wrapperUnspecified[t]; ok {
+		return v.IsNil() || v.Pointer() == sentinel.Pointer()
+	}
+	switch t {
+	case DurationType:
+		return timeutils.IsUnspecifiedDuration(time.Duration(v.Int()))
+	case TimeType:
+		return timeutils.IsUnspecifiedTime(v.Interface().(time.Time))
+	}
+
+	switch t.Kind() {
+	case reflect.Int:
+		return v.Int() == int64(intutils.IntValueUnspecified)
+	case reflect.Float32, reflect.Float64:
+		return math.IsNaN(v.Float())
+	case reflect.String:
+		return v.String() == stringutils.StringValueUnspecified
+	}
+	return false
+}
+
+// FromUntrusted checks a decoded int, float or string leaf with the
+// FromUntrusted function of intutils, floatutils or stringutils, and a
+// Duration or Time against its timeutils sentinel, so that input equal to
+// the sentinel fails instead of reading as Unspecified. Other values pass.
+func FromUntrusted(v reflect.Value) error {
+	switch t := v.Type(); {
+	case IsValueType(t):
+		return nil
+	case t == DurationType && IsUnspecified(v):
+		return timeutils.ErrReservedDuration
+	case t == TimeType && IsUnspecified(v):
+		return timeutils.ErrReservedTime
+	}
+	var err error
+	switch v.Kind() {
+	case reflect.Int:
+		_, err = intutils.FromUntrusted(int(v.Int()))
+	case reflect.Float32, reflect.Float64:
+		_, err = floatutils.FromUntrusted(v.Float())
+	case reflect.String:
+		_, err = stringutils.FromUntrusted(v.String())
+	}
+	return err
+}
+
+// WrapperField returns the Value field of a non-nil wrapperspb pointer.
+func WrapperField(v reflect.Value) reflect.Value {
+	return v.Elem().FieldByName("Value")
+}
+
+// NewWrapper allocates a fresh wrapper of type t and returns it together with
+// its settable Value field.
+func NewWrapper(t reflect.Type) (ptr, field reflect.Value) {
+	ptr = reflect.New(t.Elem())
+	return ptr, WrapperField(ptr)
+}
+
+// IsUnspecifiedOrZero extends IsUnspecified to every type: values without
+// a sentinel convention of their own (bool, slices, maps, pointers, ...)
+// count as Unspecified when they hold their zero value.
+func IsUnspecifiedOrZero(v reflect.Value) bool {
+	if IsLeaf(v.Type()) {
+		return IsUnspecified(v)
+	}
+	return v.IsZero()
+}
+
+// SetUnspecifiedOrZero is SetUnspecified, falling back to the zero value
+// for types without a sentinel convention.
+func SetUnspecifiedOrZero(v reflect.Value) {
+	if !SetUnspecified(v) {
+		v.SetZero()
+	}
+}
@@ -0,0 +1,83 @@
+package bigutils
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// BigFloat
+
+// 1. Sentinel
+var BigFloatUnspecified = &big.Float{}
+
+// 2. IsSpecified
+func IsSpecifiedBigFloat(v *big.Float) bool {
+	return v != nil && v != BigFloatUnspecified
+}
+
+// 3. TakeOrElse
+func TakeOrElseBigFloat(v, def *big.Float) *big.Float {
+	if v == nil || v == BigFloatUnspecified {
+		return def
+	}
+	return v
+}
+
+// 4. Merge
+// The result is a copy: it never aliases a or b.
+func MergeBigFloat(a, b *big.Float) *big.Float {
+	if IsSpecifiedBigFloat(b) {
+		return CopyBigFloat(b)
+	}
+	return CopyBigFloat(a)
+}
+
+// 5. String
+func StringBigFloat(v *big.Float) string {
+	if !IsSpecifiedBigFloat(v) {
+		return "BigFloat{Unspecified}"
+	}
+	return fmt.Sprintf("BigFloat{%s}", v.Text('g', -1))
+}
+
+// 6. Coalesce
+func CoalesceBigFloat(ptr, def *big.Float) *big.Float {
+	if ptr == nil {
+		return def
+	}
+	return ptr
+}
+
+// 7. Same
+func SameBigFloat(a, b *big.Float) bool {
+	return CoalesceBigFloat(a, BigFloatUnspecified) == CoalesceBigFloat(b, BigFloatUnspecified)
+}
+
+// 8. SemanticEqual
+// Values are compared with Cmp, so precision and rounding mode are ignored
+// and +0 equals -0.
+func SemanticEqualBigFloat(a, b *big.Float) bool {
+	a = CoalesceBigFloat(a, BigFloatUnspecified)
+	b = CoalesceBigFloat(b, BigFloatUnspecified)
+	if a == BigFloatUnspecified || b == BigFloatUnspecified {
+		return a == b
+	}
+	return a.Cmp(b) == 0
+}
+
+// 9. Equal
+func EqualBigFloat(a, b *big.Float) bool {
+	if !SameBigFloat(a, b) {
+		return SemanticEqualBigFloat(a, b)
+	}
+	return true
+}
+
+// 10. Copy
+// Copy is deep: the result shares no memory with v.
+func CopyBigFloat(v *big.Float) *big.Float {
+	if !IsSpecifiedBigFloat(v) {
+		return BigFloatUnspecified
+	}
+	return new(big.Float).Copy(v)
+}
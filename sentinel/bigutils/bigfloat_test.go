@@ -0,0 +1,59 @@
+package bigutils
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsSpecifiedBigFloat(t *testing.T) {
+	require.False(t, IsSpecifiedBigFloat(nil))
+	require.False(t, IsSpecifiedBigFloat(BigFloatUnspecified))
+	require.True(t, IsSpecifiedBigFloat(new(big.Float)))
+}
+
+func TestTakeOrElseBigFloat(t *testing.T) {
+	def := big.NewFloat(1.5)
+	require.Same(t, def, TakeOrElseBigFloat(nil, def))
+	require.Same(t, def, TakeOrElseBigFloat(BigFloatUnspecified, def))
+}
+
+func TestMergeBigFloatNeverAliases(t *testing.T) {
+	a, b := big.NewFloat(1), new(big.Float).SetPrec(200).SetMode(big.ToZero).SetFloat64(2)
+	got := MergeBigFloat(a, b)
+	require.NotSame(t, b, got)
+	require.Equal(t, uint(200), got.Prec())
+	require.Equal(t, big.ToZero, got.Mode())
+	got.SetFloat64(9)
+	f, _ := b.Float64()
+	require.Equal(t, 2.0, f)
+
+	require.NotSame(t, a, MergeBigFloat(a, BigFloatUnspecified))
+}
+
+func TestStringBigFloat(t *testing.T) {
+	require.Equal(t, "BigFloat{Unspecified}", StringBigFloat(BigFloatUnspecified))
+	require.Equal(t, "BigFloat{0.1}", StringBigFloat(big.NewFloat(0.1)))
+	require.Equal(t, "BigFloat{+Inf}", StringBigFloat(big.NewFloat(math.Inf(1))))
+}
+
+func TestEqualBigFloat(t *testing.T) {
+	lo := new(big.Float).SetPrec(24).SetFloat64(0.5)
+	hi := new(big.Float).SetPrec(500).SetFloat64(0.5)
+	require.False(t, SameBigFloat(lo, hi))
+	require.True(t, SemanticEqualBigFloat(lo, hi), "precision does not matter")
+	require.True(t, EqualBigFloat(big.NewFloat(0), big.NewFloat(math.Copysign(0, -1))))
+	require.False(t, EqualBigFloat(big.NewFloat(0), nil))
+	require.True(t, EqualBigFloat(nil, nil))
+}
+
+func TestCopyBigFloat(t *testing.T) {
+	require.Same(t, BigFloatUnspecified, CopyBigFloat(nil))
+	v := big.NewFloat(3)
+	c := CopyBigFloat(v)
+	require.NotSame(t, v, c)
+	c.Neg(c)
+	require.Equal(t, 1, v.Sign())
+}
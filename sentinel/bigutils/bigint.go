@@ -0,0 +1,89 @@
+// Package bigutils provides Pattern 1-C sentinel helpers for the math/big
+// pointer types *big.Int, *big.Float and *big.Rat.
+//
+// Each type has a singleton XUnspecified; nil is coalesced to it. The
+// big types are mutable, so the helpers that build values (Merge, Copy)
+// always return fresh values and never alias an input. Never mutate the
+// singletons.
+package bigutils
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// BigInt
+
+// 1. Sentinel
+var BigIntUnspecified = &big.Int{}
+
+// 2. IsSpecified
+func IsSpecifiedBigInt(v *big.Int) bool {
+	return v != nil && v != BigIntUnspecified
+}
+
+// 3. TakeOrElse
+func TakeOrElseBigInt(v, def *big.Int) *big.Int {
+	if v == nil || v == BigIntUnspecified {
+		return def
+	}
+	return v
+}
+
+// 4. Merge
+// The result is a copy: it never aliases a or b.
+func MergeBigInt(a, b *big.Int) *big.Int {
+	if IsSpecifiedBigInt(b) {
+		return CopyBigInt(b)
+	}
+	return CopyBigInt(a)
+}
+
+// 5. String
+func StringBigInt(v *big.Int) string {
+	if !IsSpecifiedBigInt(v) {
+		return "BigInt{Unspecified}"
+	}
+	return fmt.Sprintf("BigInt{%s}", v.String())
+}
+
+// 6. Coalesce
+func CoalesceBigInt(ptr, def *big.Int) *big.Int {
+	if ptr == nil {
+		return def
+	}
+	return ptr
+}
+
+// 7. Same
+func SameBigInt(a, b *big.Int) bool {
+	return CoalesceBigInt(a, BigIntUnspecified) == CoalesceBigInt(b, BigIntUnspecified)
+}
+
+// 8. SemanticEqual
+// Values are compared with Cmp.
+func SemanticEqualBigInt(a, b *big.Int) bool {
+	a = CoalesceBigInt(a, BigIntUnspecified)
+	b = CoalesceBigInt(b, BigIntUnspecified)
+	if a == BigIntUnspecified || b == BigIntUnspecified {
+		return a == b
+	}
+	return a.Cmp(b) == 0
+}
+
+// 9. Equal
+func EqualBigInt(a, b *big.Int) bool {
+	if !SameBigInt(a, b) {
+		return SemanticEqualBigInt(a, b)
+	}
+	return true
+}
+
+// 10. Copy
+// Copy is deep: the result shares no memory with v.
+func CopyBigInt(v *big.Int) *big.Int {
+	if !IsSpecifiedBigInt(v) {
+		return BigIntUnspecified
+	}
+	return new(big.Int).Set(v)
+}
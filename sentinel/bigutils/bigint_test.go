@@ -0,0 +1,62 @@
+package bigutils
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsSpecifiedBigInt(t *testing.T) {
+	require.False(t, IsSpecifiedBigInt(nil))
+	require.False(t, IsSpecifiedBigInt(BigIntUnspecified))
+	require.True(t, IsSpecifiedBigInt(big.NewInt(0)))
+}
+
+func TestTakeOrElseBigInt(t *testing.T) {
+	def := big.NewInt(7)
+	require.Same(t, def, TakeOrElseBigInt(nil, def))
+	require.Same(t, def, TakeOrElseBigInt(BigIntUnspecified, def))
+	v := big.NewInt(0)
+	require.Same(t, v, TakeOrElseBigInt(v, def))
+}
+
+func TestMergeBigIntNeverAliases(t *testing.T) {
+	a, b := big.NewInt(1), big.NewInt(2)
+
+	got := MergeBigInt(a, b)
+	require.Equal(t, int64(2), got.Int64())
+	require.NotSame(t, b, got)
+	got.SetInt64(99)
+	require.Equal(t, int64(2), b.Int64())
+
+	got = MergeBigInt(a, nil)
+	require.Equal(t, int64(1), got.Int64())
+	require.NotSame(t, a, got)
+
+	require.Same(t, BigIntUnspecified, MergeBigInt(nil, BigIntUnspecified))
+}
+
+func TestStringBigInt(t *testing.T) {
+	require.Equal(t, "BigInt{Unspecified}", StringBigInt(nil))
+	n, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	require.Equal(t, "BigInt{123456789012345678901234567890}", StringBigInt(n))
+}
+
+func TestEqualBigInt(t *testing.T) {
+	require.True(t, SameBigInt(nil, BigIntUnspecified))
+	require.False(t, SameBigInt(big.NewInt(1), big.NewInt(1)))
+	require.True(t, SemanticEqualBigInt(big.NewInt(1), big.NewInt(1)))
+	require.True(t, EqualBigInt(big.NewInt(1), big.NewInt(1)))
+	require.True(t, EqualBigInt(nil, BigIntUnspecified))
+	require.False(t, EqualBigInt(big.NewInt(0), nil), "0 is not Unspecified")
+}
+
+func TestCopyBigInt(t *testing.T) {
+	require.Same(t, BigIntUnspecified, CopyBigInt(nil))
+	v := big.NewInt(5)
+	c := CopyBigInt(v)
+	require.NotSame(t, v, c)
+	c.Add(c, c)
+	require.Equal(t, int64(5), v.Int64())
+}
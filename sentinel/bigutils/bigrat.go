@@ -0,0 +1,83 @@
+package bigutils
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// BigRat
+
+// 1. Sentinel
+var BigRatUnspecified = &big.Rat{}
+
+// 2. IsSpecified
+func IsSpecifiedBigRat(v *big.Rat) bool {
+	return v != nil && v != BigRatUnspecified
+}
+
+// 3. TakeOrElse
+func TakeOrElseBigRat(v, def *big.Rat) *big.Rat {
+	if v == nil || v == BigRatUnspecified {
+		return def
+	}
+	return v
+}
+
+// 4. Merge
+// The result is a copy: it never aliases a or b.
+func MergeBigRat(a, b *big.Rat) *big.Rat {
+	if IsSpecifiedBigRat(b) {
+		return CopyBigRat(b)
+	}
+	return CopyBigRat(a)
+}
+
+// 5. String
+func StringBigRat(v *big.Rat) string {
+	if !IsSpecifiedBigRat(v) {
+		return "BigRat{Unspecified}"
+	}
+	return fmt.Sprintf("BigRat{%s}", v.RatString())
+}
+
+// 6. Coalesce
+func CoalesceBigRat(ptr, def *big.Rat) *big.Rat {
+	if ptr == nil {
+		return def
+	}
+	return ptr
+}
+
+// 7. Same
+func SameBigRat(a, b *big.Rat) bool {
+	return CoalesceBigRat(a, BigRatUnspecified) == CoalesceBigRat(b, BigRatUnspecified)
+}
+
+// 8. SemanticEqual
+// Values are compared with Cmp; rationals are always normalised, so 2/4
+// equals 1/2.
+func SemanticEqualBigRat(a, b *big.Rat) bool {
+	a = CoalesceBigRat(a, BigRatUnspecified)
+	b = CoalesceBigRat(b, BigRatUnspecified)
+	if a == BigRatUnspecified || b == BigRatUnspecified {
+		return a == b
+	}
+	return a.Cmp(b) == 0
+}
+
+// 9. Equal
+func EqualBigRat(a, b *big.Rat) bool {
+	if !SameBigRat(a, b) {
+		return SemanticEqualBigRat(a, b)
+	}
+	return true
+}
+
+// 10. Copy
+// Copy is deep: the result shares no memory with v.
+func CopyBigRat(v *big.Rat) *big.Rat {
+	if !IsSpecifiedBigRat(v) {
+		return BigRatUnspecified
+	}
+	return new(big.Rat).Set(v)
+}
@@ -0,0 +1,52 @@
+package bigutils
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsSpecifiedBigRat(t *testing.T) {
+	require.False(t, IsSpecifiedBigRat(nil))
+	require.False(t, IsSpecifiedBigRat(BigRatUnspecified))
+	require.True(t, IsSpecifiedBigRat(new(big.Rat)))
+}
+
+func TestTakeOrElseBigRat(t *testing.T) {
+	def := big.NewRat(1, 3)
+	require.Same(t, def, TakeOrElseBigRat(nil, def))
+	require.Same(t, def, TakeOrElseBigRat(BigRatUnspecified, def))
+}
+
+func TestMergeBigRatNeverAliases(t *testing.T) {
+	a, b := big.NewRat(1, 2), big.NewRat(1, 3)
+	got := MergeBigRat(a, b)
+	require.NotSame(t, b, got)
+	got.SetInt64(4)
+	require.Equal(t, "1/3", b.RatString())
+	require.Equal(t, "1/2", MergeBigRat(a, nil).RatString())
+}
+
+func TestStringBigRat(t *testing.T) {
+	require.Equal(t, "BigRat{Unspecified}", StringBigRat(nil))
+	require.Equal(t, "BigRat{1/3}", StringBigRat(big.NewRat(2, 6)))
+	require.Equal(t, "BigRat{4}", StringBigRat(big.NewRat(8, 2)))
+}
+
+func TestEqualBigRat(t *testing.T) {
+	require.True(t, SemanticEqualBigRat(big.NewRat(2, 4), big.NewRat(1, 2)))
+	require.True(t, EqualBigRat(big.NewRat(2, 4), big.NewRat(1, 2)))
+	require.False(t, EqualBigRat(big.NewRat(1, 2), big.NewRat(1, 3)))
+	require.False(t, EqualBigRat(new(big.Rat), BigRatUnspecified))
+	require.True(t, SameBigRat(nil, BigRatUnspecified))
+}
+
+func TestCopyBigRat(t *testing.T) {
+	require.Same(t, BigRatUnspecified, CopyBigRat(nil))
+	v := big.NewRat(1, 2)
+	c := CopyBigRat(v)
+	require.NotSame(t, v, c)
+	c.Inv(c)
+	require.Equal(t, "1/2", v.RatString())
+}
@@ -47,6 +47,11 @@ func (bv BooleanValue) IsUnspecified() bool {
 	return bv.value == booleanValueUnspecified
 }
 
+// SetUnspecified - stores BooleanValueUnspecified in the receiver
+func (bv *BooleanValue) SetUnspecified() {
+	*bv = BooleanValueUnspecified
+}
+
 // IsTrue - check if value is explicitly true
 func (bv BooleanValue) IsTrue() bool {
 	return bv.value == booleanValueTrue
@@ -0,0 +1,148 @@
+package boolutils
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestBulk(t *testing.T) {
+	tr, fa, un := BooleanValueTrue(), BooleanValueFalse(), BooleanValueUnspecified
+	values := []BooleanValue{tr, un, fa, un, tr}
+	if got := CountSpecified(values); got != 3 {
+		t.Errorf("CountSpecified = %d, want 3", got)
+	}
+
+	filled := slices.Clone(values)
+	FillUnspecified(filled, fa)
+	if !slices.Equal(filled, []BooleanValue{tr, fa, fa, fa, tr}) {
+		t.Errorf("FillUnspecified = %v", filled)
+	}
+
+	dst := []BooleanValue{tr, tr, un, un}
+	if n := MergeInto(dst, []BooleanValue{un, fa, tr}); n != 3 {
+		t.Errorf("MergeInto n = %d, want 3", n)
+	}
+	if !slices.Equal(dst, []BooleanValue{tr, fa, tr, un}) {
+		t.Errorf("MergeInto = %v", dst)
+	}
+
+	if mask := SpecifiedMask(values); !slices.Equal(mask, []uint64{0b10101}) {
+		t.Errorf("SpecifiedMask = %b", mask)
+	}
+
+	compacted := Compact(values)
+	if !slices.Equal(compacted, []BooleanValue{tr, fa, tr}) || !slices.Equal(values[3:], []BooleanValue{un, un}) {
+		t.Errorf("Compact = %v, backing %v", compacted, values)
+	}
+}
+
+var benchBooleanValues = func() []BooleanValue {
+	s := make([]BooleanValue, 1<<16)
+	x := uint32(1)
+	for i := range s {
+		x ^= x << 13
+		x ^= x >> 17
+		x ^= x << 5
+		switch x % 3 {
+		case 0:
+			s[i] = BooleanValueUnspecified
+		case 1:
+			s[i] = BooleanValueTrue()
+		default:
+			s[i] = BooleanValueFalse()
+		}
+	}
+	return s
+}()
+
+func BenchmarkCountSpecified(b *testing.B) {
+	b.Run("bulk", func(b *testing.B) {
+		for b.Loop() {
+			CountSpecified(benchBooleanValues)
+		}
+	})
+	b.Run("scalar", func(b *testing.B) {
+		for b.Loop() {
+			n := 0
+			for _, v := range benchBooleanValues {
+				if v.IsSpecified() {
+					n++
+				}
+			}
+			_ = n
+		}
+	})
+}
+
+func BenchmarkFillUnspecified(b *testing.B) {
+	dst := make([]BooleanValue, len(benchBooleanValues))
+	b.Run("bulk", func(b *testing.B) {
+		for b.Loop() {
+			copy(dst, benchBooleanValues)
+			FillUnspecified(dst, BooleanValueFalse())
+		}
+	})
+	b.Run("scalar", func(b *testing.B) {
+		for b.Loop() {
+			copy(dst, benchBooleanValues)
+			for i, v := range dst {
+				dst[i] = v.TakeOrElse(BooleanValueFalse())
+			}
+		}
+	})
+}
+
+func BenchmarkMergeInto(b *testing.B) {
+	dst := make([]BooleanValue, len(benchBooleanValues))
+	b.Run("bulk", func(b *testing.B) {
+		for b.Loop() {
+			MergeInto(dst, benchBooleanValues)
+		}
+	})
+	b.Run("scalar", func(b *testing.B) {
+		for b.Loop() {
+			for i, v := range benchBooleanValues {
+				dst[i] = MergeBooleanValue(dst[i], v)
+			}
+		}
+	})
+}
+
+func BenchmarkSpecifiedMask(b *testing.B) {
+	b.Run("bulk", func(b *testing.B) {
+		for b.Loop() {
+			SpecifiedMask(benchBooleanValues)
+		}
+	})
+	b.Run("scalar", func(b *testing.B) {
+		for b.Loop() {
+			mask := make([]uint64, (len(benchBooleanValues)+63)/64)
+			for i, v := range benchBooleanValues {
+				if v.IsSpecified() {
+					mask[i/64] |= 1 << (i % 64)
+				}
+			}
+		}
+	})
+}
+
+func BenchmarkCompact(b *testing.B) {
+	values := make([]BooleanValue, len(benchBooleanValues))
+	b.Run("bulk", func(b *testing.B) {
+		for b.Loop() {
+			copy(values, benchBooleanValues)
+			Compact(values)
+		}
+	})
+	b.Run("scalar", func(b *testing.B) {
+		for b.Loop() {
+			copy(values, benchBooleanValues)
+			out := values[:0]
+			for _, v := range values {
+				if v.IsSpecified() {
+					out = append(out, v)
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,59 @@
+package boolutils
+
+import "strconv"
+
+// IsZero reports whether bv is Unspecified, so that encoders honouring
+// `omitempty`/`omitzero` (yaml.v3, TOML, encoding/json) drop it.
+func (bv BooleanValue) IsZero() bool {
+	return bv.IsUnspecified()
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// Unspecified encodes as the empty string.
+func (bv BooleanValue) MarshalText() ([]byte, error) {
+	switch bv.value {
+	case booleanValueTrue:
+		return []byte("true"), nil
+	case booleanValueFalse:
+		return []byte("false"), nil
+	default:
+		return []byte{}, nil
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// The empty string, "null" and "~" decode to Unspecified.
+func (bv *BooleanValue) UnmarshalText(text []byte) error {
+	switch s := string(text); s {
+	case "", "null", "~":
+		*bv = BooleanValueUnspecified
+	default:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		*bv = BooleanValueFrom(b)
+	}
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler without importing a YAML package.
+// Unspecified encodes as null.
+func (bv BooleanValue) MarshalYAML() (any, error) {
+	if bv.IsUnspecified() {
+		return nil, nil
+	}
+	return bv.Bool(), nil
+}
+
+// UnmarshalYAML implements the yaml.v2/v3 obsolete unmarshaler interface.
+// Null nodes never reach it, so a null or absent key leaves the zero value,
+// which is BooleanValueUnspecified.
+func (bv *BooleanValue) UnmarshalYAML(unmarshal func(any) error) error {
+	var b bool
+	if err := unmarshal(&b); err != nil {
+		return err
+	}
+	*bv = BooleanValueFrom(b)
+	return nil
+}
@@ -0,0 +1,82 @@
+package boolutils
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestBooleanValue_Text(t *testing.T) {
+	tests := []struct {
+		val  BooleanValue
+		text string
+	}{
+		{BooleanValueTrue(), "true"},
+		{BooleanValueFalse(), "false"},
+		{BooleanValueUnspecified, ""},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.val.MarshalText()
+		if err != nil || string(got) != tt.text {
+			t.Errorf("MarshalText(%v) = %q, %v; want %q", tt.val, got, err, tt.text)
+		}
+
+		var back BooleanValue
+		if err := back.UnmarshalText(got); err != nil || !back.Equal(tt.val) {
+			t.Errorf("UnmarshalText(%q) = %v, %v; want %v", got, back, err, tt.val)
+		}
+	}
+
+	for _, null := range []string{"null", "~"} {
+		back := BooleanValueTrue()
+		if err := back.UnmarshalText([]byte(null)); err != nil || back.IsSpecified() {
+			t.Errorf("UnmarshalText(%q) = %v, %v; want Unspecified", null, back, err)
+		}
+	}
+
+	var bad BooleanValue
+	if err := bad.UnmarshalText([]byte("maybe")); err == nil {
+		t.Error("UnmarshalText(\"maybe\") should fail")
+	}
+}
+
+func TestBooleanValue_IsZero(t *testing.T) {
+	if !BooleanValueUnspecified.IsZero() {
+		t.Error("Unspecified should be zero")
+	}
+	if BooleanValueFalse().IsZero() {
+		t.Error("False should not be zero")
+	}
+}
+
+func TestBooleanValue_YAML(t *testing.T) {
+	type flags struct {
+		A BooleanValue `yaml:"a,omitempty"`
+		B BooleanValue `yaml:"b,omitempty"`
+		C BooleanValue `yaml:"c"`
+	}
+
+	out, err := yaml.Marshal(flags{A: BooleanValueFalse(), B: BooleanValueUnspecified})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "a: false\nc: null\n"; string(out) != want {
+		t.Errorf("yaml.Marshal = %q, want %q", out, want)
+	}
+
+	var in flags
+	if err := yaml.Unmarshal([]byte("a: true\nb: ~\n"), &in); err != nil {
+		t.Fatal(err)
+	}
+	if !in.A.IsTrue() {
+		t.Errorf("A = %v, want true", in.A)
+	}
+	if in.B.IsSpecified() || in.C.IsSpecified() {
+		t.Errorf("B = %v, C = %v; want Unspecified", in.B, in.C)
+	}
+
+	if err := yaml.Unmarshal([]byte("a: [1]\n"), &in); err == nil {
+		t.Error("decoding a sequence should fail")
+	}
+}
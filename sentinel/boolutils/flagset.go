@@ -0,0 +1,242 @@
+package boolutils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"strings"
+)
+
+// FlagSetCapacity is the maximum number of flags a FlagSet can hold.
+const FlagSetCapacity = 32
+
+const (
+	// flagLowBits selects the low bit of every 2-bit slot.
+	flagLowBits  uint64 = 0x5555555555555555
+	flagSlotMask uint64 = 0b11
+)
+
+// FlagSetDefinition names the flags of a FlagSet family. Implement it on an
+// empty struct and return a package-level slice of at most FlagSetCapacity names:
+//
+//	type textFlags struct{}
+//	var textFlagNames = []string{"bold", "italic", "underline"}
+//	func (textFlags) Names() []string { return textFlagNames }
+type FlagSetDefinition interface {
+	comparable
+	Names() []string
+}
+
+// FlagSet packs up to 32 BooleanValues into a uint64, two bits per flag,
+// using the booleanValue encoding (0 Unspecified, 1 true, 2 false).
+// Uses Pattern 1-D from sentinel_pattern.md; the zero value has every flag Unspecified.
+type FlagSet[D FlagSetDefinition] struct {
+	bits uint64
+}
+
+// 1. Sentinel - every flag Unspecified (the zero value)
+func FlagSetUnspecified[D FlagSetDefinition]() FlagSet[D] {
+	return FlagSet[D]{}
+}
+
+// Len returns the number of flags declared by D.
+func (fs FlagSet[D]) Len() int {
+	var def D
+	return len(def.Names())
+}
+
+func (fs FlagSet[D]) checkIndex(i int) {
+	if n := fs.Len(); i < 0 || i >= n || i >= FlagSetCapacity {
+		panic(fmt.Sprintf("boolutils: flag index %d out of range [0, %d)", i, min(n, FlagSetCapacity)))
+	}
+}
+
+// Get returns flag i.
+func (fs FlagSet[D]) Get(i int) BooleanValue {
+	fs.checkIndex(i)
+	return BooleanValue{value: booleanValue((fs.bits >> (2 * i)) & flagSlotMask)}
+}
+
+// Set returns a copy of fs with flag i replaced by v.
+func (fs FlagSet[D]) Set(i int, v BooleanValue) FlagSet[D] {
+	fs.checkIndex(i)
+	shift := 2 * i
+	fs.bits = fs.bits&^(flagSlotMask<<shift) | uint64(v.value)<<shift
+	return fs
+}
+
+// Flags iterates over every declared flag in order.
+func (fs FlagSet[D]) Flags() iter.Seq2[int, BooleanValue] {
+	return func(yield func(int, BooleanValue) bool) {
+		for i := range min(fs.Len(), FlagSetCapacity) {
+			if !yield(i, fs.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+// specifiedMask has both bits of every specified slot set.
+func (fs FlagSet[D]) specifiedMask() uint64 {
+	low := (fs.bits | fs.bits>>1) & flagLowBits
+	return low | low<<1
+}
+
+// 2. IsSpecified - predicate: at least one flag is specified
+func (fs FlagSet[D]) IsSpecified() bool {
+	return fs.bits != 0
+}
+
+// IsUnspecified - convenience predicate: every flag is Unspecified
+func (fs FlagSet[D]) IsUnspecified() bool {
+	return fs.bits == 0
+}
+
+// SetUnspecified - stores FlagSetUnspecified in the receiver
+func (fs *FlagSet[D]) SetUnspecified() {
+	*fs = FlagSetUnspecified[D]()
+}
+
+// IsFullySpecified reports whether every declared flag is specified.
+func (fs FlagSet[D]) IsFullySpecified() bool {
+	n := min(fs.Len(), FlagSetCapacity)
+	want := uint64(1)<<(2*n) - 1
+	if n == FlagSetCapacity {
+		want = ^uint64(0)
+	}
+	return fs.specifiedMask()&want == want
+}
+
+// 3. TakeOrElse - 2-param fallback, applied per flag
+func (fs FlagSet[D]) TakeOrElse(def FlagSet[D]) FlagSet[D] {
+	return def.Merge(fs)
+}
+
+// 4. Merge - per-flag composition merge: every specified flag of other wins.
+// All flags are merged at once with a handful of bitwise operations.
+func (fs FlagSet[D]) Merge(other FlagSet[D]) FlagSet[D] {
+	mask := other.specifiedMask()
+	return FlagSet[D]{bits: fs.bits&^mask | other.bits&mask}
+}
+
+// MergeFlagSet - package-level merge function
+func MergeFlagSet[D FlagSetDefinition](a, b FlagSet[D]) FlagSet[D] {
+	return a.Merge(b)
+}
+
+// 5. String - stringification, listing specified flags by name
+func (fs FlagSet[D]) String() string {
+	if fs.IsUnspecified() {
+		return "FlagSet{Unspecified}"
+	}
+	var def D
+	var sb strings.Builder
+	sb.WriteString("FlagSet{")
+	first := true
+	for i, v := range fs.Flags() {
+		if v.IsUnspecified() {
+			continue
+		}
+		if !first {
+			sb.WriteString(", ")
+		}
+		first = false
+		fmt.Fprintf(&sb, "%s: %t", def.Names()[i], v.Bool())
+	}
+	sb.WriteString("}")
+	return sb.String()
+}
+
+// StringFlagSet - package-level string function
+func StringFlagSet[D FlagSetDefinition](fs FlagSet[D]) string {
+	return fs.String()
+}
+
+// 6. Coalesce - N/A for value types (no nil possible)
+
+// 7. Same - identity (for value types, uses == operator)
+func (fs FlagSet[D]) Same(other FlagSet[D]) bool {
+	return fs.bits == other.bits
+}
+
+// 8. SemanticEqual - semantic equality (for value types, same as Same)
+func (fs FlagSet[D]) SemanticEqual(other FlagSet[D]) bool {
+	return fs.bits == other.bits
+}
+
+// 9. Equal - equality check
+func (fs FlagSet[D]) Equal(other FlagSet[D]) bool {
+	return fs.bits == other.bits
+}
+
+// 10. Copy - identity for immutable value types
+func (fs FlagSet[D]) Copy() FlagSet[D] {
+	return fs
+}
+
+// IsZero reports whether every flag is Unspecified, for `omitzero` encoders.
+func (fs FlagSet[D]) IsZero() bool {
+	return fs.IsUnspecified()
+}
+
+// MarshalJSON encodes the specified flags as an object keyed by flag name.
+// Unspecified flags are omitted.
+func (fs FlagSet[D]) MarshalJSON() ([]byte, error) {
+	var def D
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	first := true
+	for i, v := range fs.Flags() {
+		if v.IsUnspecified() {
+			continue
+		}
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+		name, err := json.Marshal(def.Names()[i])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		fmt.Fprintf(&buf, ":%t", v.Bool())
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON decodes an object keyed by flag name. Absent and null
+// flags are Unspecified; unknown names, and names a definition declares
+// beyond FlagSetCapacity, are an error.
+func (fs *FlagSet[D]) UnmarshalJSON(data []byte) error {
+	var raw map[string]*bool
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	var def D
+	var result FlagSet[D]
+	for name, b := range raw {
+		i := indexOf(def.Names(), name)
+		if i < 0 {
+			return fmt.Errorf("boolutils: unknown flag %q", name)
+		}
+		if i >= FlagSetCapacity {
+			return fmt.Errorf("boolutils: flag %q at index %d exceeds FlagSetCapacity", name, i)
+		}
+		if b != nil {
+			result = result.Set(i, BooleanValueFrom(*b))
+		}
+	}
+	*fs = result
+	return nil
+}
+
+func indexOf(names []string, name string) int {
+	for i, n := range names {
+		if n == name {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,213 @@
+package boolutils
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+type textFlags struct{}
+
+var textFlagNames = []string{"bold", "italic", "underline"}
+
+func (textFlags) Names() []string { return textFlagNames }
+
+type wideFlags struct{}
+
+var wideFlagNames = func() []string {
+	names := make([]string, FlagSetCapacity)
+	for i := range names {
+		names[i] = fmt.Sprintf("f%d", i)
+	}
+	return names
+}()
+
+func (wideFlags) Names() []string { return wideFlagNames }
+
+type oversizedFlags struct{}
+
+var oversizedFlagNames = append(append([]string(nil), wideFlagNames...), "extra")
+
+func (oversizedFlags) Names() []string { return oversizedFlagNames }
+
+func TestFlagSet_GetSet(t *testing.T) {
+	fs := FlagSetUnspecified[textFlags]()
+	if fs.IsSpecified() {
+		t.Error("zero FlagSet should be unspecified")
+	}
+	if fs.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", fs.Len())
+	}
+
+	fs = fs.Set(0, BooleanValueTrue()).Set(2, BooleanValueFalse())
+	if !fs.Get(0).IsTrue() || fs.Get(1).IsSpecified() || !fs.Get(2).IsFalse() {
+		t.Errorf("Get after Set = %v", fs)
+	}
+
+	fs = fs.Set(0, BooleanValueUnspecified)
+	if fs.Get(0).IsSpecified() {
+		t.Error("Set(Unspecified) should clear the flag")
+	}
+	if !fs.Get(2).IsFalse() {
+		t.Error("clearing flag 0 should not touch flag 2")
+	}
+}
+
+func TestFlagSet_OutOfRangePanics(t *testing.T) {
+	for _, i := range []int{-1, 3} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Get(%d) should panic", i)
+				}
+			}()
+			FlagSetUnspecified[textFlags]().Get(i)
+		}()
+	}
+}
+
+func TestFlagSet_IsFullySpecified(t *testing.T) {
+	fs := FlagSetUnspecified[textFlags]().Set(0, BooleanValueTrue()).Set(1, BooleanValueFalse())
+	if fs.IsFullySpecified() {
+		t.Error("two of three flags should not be fully specified")
+	}
+	if !fs.Set(2, BooleanValueFalse()).IsFullySpecified() {
+		t.Error("all three flags should be fully specified")
+	}
+
+	var wide FlagSet[wideFlags]
+	for i := range FlagSetCapacity {
+		wide = wide.Set(i, BooleanValueFrom(i%2 == 0))
+	}
+	if !wide.IsFullySpecified() {
+		t.Error("all 32 flags should be fully specified")
+	}
+	if wide.Set(31, BooleanValueUnspecified).IsFullySpecified() {
+		t.Error("clearing flag 31 should not be fully specified")
+	}
+}
+
+func TestFlagSet_MergeMatchesPerFlagMerge(t *testing.T) {
+	values := []BooleanValue{BooleanValueUnspecified, BooleanValueTrue(), BooleanValueFalse()}
+
+	// Every combination of three flags on each side.
+	for a := range 27 {
+		for b := range 27 {
+			var fa, fb FlagSet[textFlags]
+			for i, x, y := 0, a, b; i < 3; i, x, y = i+1, x/3, y/3 {
+				fa = fa.Set(i, values[x%3])
+				fb = fb.Set(i, values[y%3])
+			}
+
+			merged := fa.Merge(fb)
+			taken := fa.TakeOrElse(fb)
+			for i := range 3 {
+				if want := fa.Get(i).Merge(fb.Get(i)); !merged.Get(i).Equal(want) {
+					t.Fatalf("%v Merge %v: flag %d = %v, want %v", fa, fb, i, merged.Get(i), want)
+				}
+				if want := fa.Get(i).TakeOrElse(fb.Get(i)); !taken.Get(i).Equal(want) {
+					t.Fatalf("%v TakeOrElse %v: flag %d = %v, want %v", fa, fb, i, taken.Get(i), want)
+				}
+			}
+			if !MergeFlagSet(fa, fb).Equal(merged) {
+				t.Fatal("MergeFlagSet should match Merge")
+			}
+		}
+	}
+}
+
+func TestFlagSet_Flags(t *testing.T) {
+	fs := FlagSetUnspecified[textFlags]().Set(1, BooleanValueTrue())
+	var got []string
+	for i, v := range fs.Flags() {
+		got = append(got, fmt.Sprintf("%d=%v", i, v))
+	}
+	want := "[0=BooleanValue{Unspecified} 1=BooleanValue{true} 2=BooleanValue{Unspecified}]"
+	if fmt.Sprint(got) != want {
+		t.Errorf("Flags() = %v, want %v", got, want)
+	}
+
+	count := 0
+	for range fs.Flags() {
+		count++
+		break
+	}
+	if count != 1 {
+		t.Error("Flags() should stop when the loop breaks")
+	}
+}
+
+func TestFlagSet_String(t *testing.T) {
+	var fs FlagSet[textFlags]
+	if got := fs.String(); got != "FlagSet{Unspecified}" {
+		t.Errorf("String() = %q", got)
+	}
+	fs = fs.Set(0, BooleanValueTrue()).Set(2, BooleanValueFalse())
+	if got := StringFlagSet(fs); got != "FlagSet{bold: true, underline: false}" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestFlagSet_Equality(t *testing.T) {
+	a := FlagSetUnspecified[textFlags]().Set(1, BooleanValueTrue())
+	b := FlagSetUnspecified[textFlags]().Set(1, BooleanValueTrue())
+	c := a.Set(1, BooleanValueFalse())
+	if !a.Same(b) || !a.SemanticEqual(b) || !a.Equal(b) {
+		t.Error("identical flag sets should be equal")
+	}
+	if a.Equal(c) {
+		t.Error("different flag sets should not be equal")
+	}
+	if !a.Copy().Equal(a) {
+		t.Error("Copy should be equal")
+	}
+}
+
+func TestFlagSet_JSON(t *testing.T) {
+	fs := FlagSetUnspecified[textFlags]().Set(0, BooleanValueTrue()).Set(2, BooleanValueFalse())
+	out, err := json.Marshal(fs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"bold":true,"underline":false}`; string(out) != want {
+		t.Errorf("Marshal = %s, want %s", out, want)
+	}
+
+	var back FlagSet[textFlags]
+	if err := json.Unmarshal(out, &back); err != nil || !back.Equal(fs) {
+		t.Errorf("Unmarshal = %v, %v; want %v", back, err, fs)
+	}
+
+	if err := json.Unmarshal([]byte(`{"italic":null}`), &back); err != nil || back.IsSpecified() {
+		t.Errorf("Unmarshal null flag = %v, %v", back, err)
+	}
+	if err := json.Unmarshal([]byte(`{"strike":true}`), &back); err == nil {
+		t.Error("Unmarshal unknown flag should fail")
+	}
+	var oversized FlagSet[oversizedFlags]
+	if err := json.Unmarshal([]byte(`{"f0":true,"extra":true}`), &oversized); err == nil {
+		t.Error("Unmarshal flag beyond FlagSetCapacity should fail")
+	}
+
+	type style struct {
+		Flags FlagSet[textFlags] `json:"flags,omitzero"`
+	}
+	out, _ = json.Marshal(style{})
+	if string(out) != `{}` {
+		t.Errorf("omitzero Marshal = %s", out)
+	}
+}
+
+func BenchmarkFlagSet_Merge(b *testing.B) {
+	var x, y FlagSet[wideFlags]
+	for i := range FlagSetCapacity {
+		x = x.Set(i, BooleanValueTrue())
+		if i%3 == 0 {
+			y = y.Set(i, BooleanValueFalse())
+		}
+	}
+	b.ReportAllocs()
+	for b.Loop() {
+		x = x.Merge(y)
+	}
+}
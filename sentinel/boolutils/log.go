@@ -0,0 +1,21 @@
+package boolutils
+
+import (
+	"log/slog"
+
+	"github.com/zodimo/go-sentinel-helper/internal/sentinellog"
+)
+
+// LogValue implements slog.LogValuer: a bool when specified, otherwise
+// "<unspecified>".
+func (bv BooleanValue) LogValue() slog.Value {
+	if bv.IsUnspecified() {
+		return sentinellog.Value()
+	}
+	return slog.BoolValue(bv.Bool())
+}
+
+// BooleanValueAttr returns a slog.Attr for bv.
+func BooleanValueAttr(key string, bv BooleanValue) slog.Attr {
+	return slog.Attr{Key: key, Value: bv.LogValue()}
+}
@@ -0,0 +1,26 @@
+package boolutils
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestBooleanValue_LogValue(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	logger.Info("m", "t", BooleanValueTrue(), BooleanValueAttr("f", BooleanValueFalse()), "u", BooleanValue{})
+
+	want := "level=INFO msg=m t=true f=false u=<unspecified>"
+	if got := strings.TrimSpace(buf.String()); got != want {
+		t.Errorf("log line = %q, want %q", got, want)
+	}
+}
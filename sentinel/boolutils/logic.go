@@ -0,0 +1,92 @@
+package boolutils
+
+// Strong Kleene three-valued logic. Unspecified is read as "unknown":
+// an operator yields Unspecified only when the known operands do not
+// already decide the result.
+
+// Not returns the negation; Unspecified stays Unspecified.
+func (bv BooleanValue) Not() BooleanValue {
+	switch bv.value {
+	case booleanValueTrue:
+		return BooleanValueFalse()
+	case booleanValueFalse:
+		return BooleanValueTrue()
+	default:
+		return BooleanValueUnspecified
+	}
+}
+
+// And is false if either operand is false, true if both are true,
+// and Unspecified otherwise.
+func (bv BooleanValue) And(other BooleanValue) BooleanValue {
+	if bv.IsFalse() || other.IsFalse() {
+		return BooleanValueFalse()
+	}
+	if bv.IsTrue() && other.IsTrue() {
+		return BooleanValueTrue()
+	}
+	return BooleanValueUnspecified
+}
+
+// Or is true if either operand is true, false if both are false,
+// and Unspecified otherwise.
+func (bv BooleanValue) Or(other BooleanValue) BooleanValue {
+	if bv.IsTrue() || other.IsTrue() {
+		return BooleanValueTrue()
+	}
+	if bv.IsFalse() && other.IsFalse() {
+		return BooleanValueFalse()
+	}
+	return BooleanValueUnspecified
+}
+
+// Xor is Unspecified if either operand is Unspecified.
+func (bv BooleanValue) Xor(other BooleanValue) BooleanValue {
+	if bv.IsUnspecified() || other.IsUnspecified() {
+		return BooleanValueUnspecified
+	}
+	return BooleanValueFrom(bv.value != other.value)
+}
+
+// Implies is equivalent to bv.Not().Or(other).
+func (bv BooleanValue) Implies(other BooleanValue) BooleanValue {
+	return bv.Not().Or(other)
+}
+
+// All folds values with And. It returns true for no values.
+func All(values ...BooleanValue) BooleanValue {
+	result := BooleanValueTrue()
+	for _, v := range values {
+		if v.IsFalse() {
+			return BooleanValueFalse()
+		}
+		result = result.And(v)
+	}
+	return result
+}
+
+// Any folds values with Or. It returns false for no values.
+func Any(values ...BooleanValue) BooleanValue {
+	result := BooleanValueFalse()
+	for _, v := range values {
+		if v.IsTrue() {
+			return BooleanValueTrue()
+		}
+		result = result.Or(v)
+	}
+	return result
+}
+
+// Resolve returns bv if specified, otherwise the first specified default,
+// otherwise Unspecified. It chains TakeOrElse over the defaults.
+func (bv BooleanValue) Resolve(defaults ...BooleanValue) BooleanValue {
+	if bv.IsSpecified() {
+		return bv
+	}
+	for _, d := range defaults {
+		if d.IsSpecified() {
+			return d
+		}
+	}
+	return BooleanValueUnspecified
+}
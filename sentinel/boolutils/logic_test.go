@@ -0,0 +1,107 @@
+package boolutils
+
+import "testing"
+
+var (
+	lT = BooleanValueTrue()
+	lF = BooleanValueFalse()
+	lU = BooleanValueUnspecified
+)
+
+func TestBooleanValue_Not(t *testing.T) {
+	tests := []struct {
+		in, want BooleanValue
+	}{
+		{lT, lF},
+		{lF, lT},
+		{lU, lU},
+	}
+	for _, tt := range tests {
+		if got := tt.in.Not(); !got.Equal(tt.want) {
+			t.Errorf("Not(%v) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestBooleanValue_BinaryTruthTables(t *testing.T) {
+	tests := []struct {
+		a, b                  BooleanValue
+		and, or, xor, implies BooleanValue
+	}{
+		{lT, lT, lT, lT, lF, lT},
+		{lT, lF, lF, lT, lT, lF},
+		{lT, lU, lU, lT, lU, lU},
+		{lF, lT, lF, lT, lT, lT},
+		{lF, lF, lF, lF, lF, lT},
+		{lF, lU, lF, lU, lU, lT},
+		{lU, lT, lU, lT, lU, lT},
+		{lU, lF, lF, lU, lU, lU},
+		{lU, lU, lU, lU, lU, lU},
+	}
+	for _, tt := range tests {
+		if got := tt.a.And(tt.b); !got.Equal(tt.and) {
+			t.Errorf("%v And %v = %v, want %v", tt.a, tt.b, got, tt.and)
+		}
+		if got := tt.a.Or(tt.b); !got.Equal(tt.or) {
+			t.Errorf("%v Or %v = %v, want %v", tt.a, tt.b, got, tt.or)
+		}
+		if got := tt.a.Xor(tt.b); !got.Equal(tt.xor) {
+			t.Errorf("%v Xor %v = %v, want %v", tt.a, tt.b, got, tt.xor)
+		}
+		if got := tt.a.Implies(tt.b); !got.Equal(tt.implies) {
+			t.Errorf("%v Implies %v = %v, want %v", tt.a, tt.b, got, tt.implies)
+		}
+	}
+}
+
+func TestAllAny(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []BooleanValue
+		all, any BooleanValue
+	}{
+		{"empty", nil, lT, lF},
+		{"single true", []BooleanValue{lT}, lT, lT},
+		{"single false", []BooleanValue{lF}, lF, lF},
+		{"single unspecified", []BooleanValue{lU}, lU, lU},
+		{"true true", []BooleanValue{lT, lT}, lT, lT},
+		{"true false", []BooleanValue{lT, lF}, lF, lT},
+		{"true unspecified", []BooleanValue{lT, lU}, lU, lT},
+		{"false unspecified", []BooleanValue{lF, lU}, lF, lU},
+		{"false false", []BooleanValue{lF, lF}, lF, lF},
+		{"unspecified unspecified", []BooleanValue{lU, lU}, lU, lU},
+		{"mixed", []BooleanValue{lU, lT, lF}, lF, lT},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := All(tt.values...); !got.Equal(tt.all) {
+				t.Errorf("All = %v, want %v", got, tt.all)
+			}
+			if got := Any(tt.values...); !got.Equal(tt.any) {
+				t.Errorf("Any = %v, want %v", got, tt.any)
+			}
+		})
+	}
+}
+
+func TestBooleanValue_Resolve(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    BooleanValue
+		defaults []BooleanValue
+		want     BooleanValue
+	}{
+		{"specified wins", lF, []BooleanValue{lT}, lF},
+		{"no defaults", lU, nil, lU},
+		{"first default", lU, []BooleanValue{lT, lF}, lT},
+		{"skips unspecified defaults", lU, []BooleanValue{lU, lF, lT}, lF},
+		{"all unspecified", lU, []BooleanValue{lU, lU}, lU},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.value.Resolve(tt.defaults...); !got.Equal(tt.want) {
+				t.Errorf("Resolve = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,296 @@
+// Package color provides a uint64-packed RGBA color tagged with its color
+// space, using 0 as the Unspecified sentinel.
+package color
+
+import (
+	"math"
+
+	"github.com/zodimo/go-sentinel-helper/sentinel/color/colorspace"
+	"github.com/zodimo/go-sentinel-helper/sentinel/floatutils"
+)
+
+// Packed layout, most significant bits first:
+//
+//	63..48 red   (16-bit unorm)
+//	47..32 green (16-bit unorm)
+//	31..16 blue  (16-bit unorm)
+//	15..6  alpha (10-bit unorm)
+//	 5..0  colorspace.ID
+//
+// Every specified color has a non-zero space ID, so the all-zero word is
+// free to act as ColorUnspecified. 16 bits per channel keep 8-bit hex
+// colors exact through a round trip.
+const (
+	redShift   = 48
+	greenShift = 32
+	blueShift  = 16
+	alphaShift = 6
+
+	channelMax = 1<<16 - 1
+	alphaMax   = 1<<10 - 1
+	idMask     = uint64(colorspace.MaxID)
+)
+
+// ColorEqualityThreshold is the per-channel tolerance of SemanticEqual:
+// half an 8-bit step, so colors that format to the same hex are equal.
+const ColorEqualityThreshold float32 = 0.5 / 255
+
+// Color is a packed RGBA color in one of the colorspace spaces.
+// Uses Pattern 1-B from sentinel_pattern.md.
+type Color uint64
+
+// 1. Sentinel - ColorUnspecified
+const ColorUnspecified Color = 0
+
+func quantize(v float32, max float64) uint64 {
+	f := math.Min(math.Max(float64(v), 0), 1)
+	return uint64(math.Round(f * max))
+}
+
+// NewColor packs the channels, each clamped to [0, 1], in space.
+// A NaN channel or an Unspecified space yields ColorUnspecified.
+func NewColor(alpha, red, green, blue float32, space *colorspace.ColorSpace) Color {
+	if !colorspace.IsSpecifiedColorSpace(space) ||
+		floatutils.IsUnspecified(alpha) || floatutils.IsUnspecified(red) ||
+		floatutils.IsUnspecified(green) || floatutils.IsUnspecified(blue) {
+		return ColorUnspecified
+	}
+	return Color(quantize(red, channelMax)<<redShift |
+		quantize(green, channelMax)<<greenShift |
+		quantize(blue, channelMax)<<blueShift |
+		quantize(alpha, alphaMax)<<alphaShift |
+		uint64(space.ID()))
+}
+
+// FromARGB creates an sRGB color from a 0xAARRGGBB word.
+func FromARGB(argb uint32) Color {
+	return NewColor(
+		float32(argb>>24)/255,
+		float32(argb>>16&0xFF)/255,
+		float32(argb>>8&0xFF)/255,
+		float32(argb&0xFF)/255,
+		colorspace.SRGB,
+	)
+}
+
+// ColorSpaceId returns the ID of the space c is expressed in.
+func (c Color) ColorSpaceId() colorspace.ID {
+	return colorspace.ID(uint64(c) & idMask)
+}
+
+// ColorSpace returns the space c is expressed in.
+func (c Color) ColorSpace() *colorspace.ColorSpace {
+	return colorspace.Get(c.ColorSpaceId())
+}
+
+func (c Color) channel(shift uint, max float32) float32 {
+	if c.IsUnspecified() {
+		return floatutils.Float32Unspecified
+	}
+	return float32(uint64(c)>>shift&uint64(max)) / max
+}
+
+// Alpha returns the alpha channel in [0, 1], NaN if Unspecified.
+func (c Color) Alpha() float32 { return c.channel(alphaShift, alphaMax) }
+
+// Red returns the red channel in [0, 1], NaN if Unspecified.
+func (c Color) Red() float32 { return c.channel(redShift, channelMax) }
+
+// Green returns the green channel in [0, 1], NaN if Unspecified.
+func (c Color) Green() float32 { return c.channel(greenShift, channelMax) }
+
+// Blue returns the blue channel in [0, 1], NaN if Unspecified.
+func (c Color) Blue() float32 { return c.channel(blueShift, channelMax) }
+
+// ARGB returns c converted to sRGB as a 0xAARRGGBB word, 0 if Unspecified.
+func (c Color) ARGB() uint32 {
+	if c.IsUnspecified() {
+		return 0
+	}
+	s := c.Convert(colorspace.SRGB)
+	return uint32(quantize(s.Alpha(), 255))<<24 |
+		uint32(quantize(s.Red(), 255))<<16 |
+		uint32(quantize(s.Green(), 255))<<8 |
+		uint32(quantize(s.Blue(), 255))
+}
+
+// Convert returns c expressed in dst. Channels outside dst's gamut are
+// clipped; alpha is carried over unchanged. Unspecified stays Unspecified.
+func (c Color) Convert(dst *colorspace.ColorSpace) Color {
+	src := c.ColorSpace()
+	if c.IsUnspecified() || colorspace.SameColorSpace(src, dst) {
+		return c
+	}
+	x, y, z := src.ToXYZ(float64(c.Red()), float64(c.Green()), float64(c.Blue()))
+	r, g, b := dst.FromXYZ(x, y, z)
+	return NewColor(c.Alpha(), float32(r), float32(g), float32(b), dst)
+}
+
+// 2. IsSpecified - predicate (method on value receiver)
+func (c Color) IsSpecified() bool {
+	return c != ColorUnspecified
+}
+
+// IsUnspecified - convenience predicate
+func (c Color) IsUnspecified() bool {
+	return c == ColorUnspecified
+}
+
+// SetUnspecified - stores ColorUnspecified in the receiver
+func (c *Color) SetUnspecified() {
+	*c = ColorUnspecified
+}
+
+// IsSpecifiedColor - package-level predicate
+func IsSpecifiedColor(c Color) bool {
+	return c.IsSpecified()
+}
+
+// 3. TakeOrElse - 2-param fallback (method on value receiver)
+func (c Color) TakeOrElse(def Color) Color {
+	if c.IsSpecified() {
+		return c
+	}
+	return def
+}
+
+// TakeOrElseColor - package-level fallback
+func TakeOrElseColor(a, b Color) Color {
+	return a.TakeOrElse(b)
+}
+
+// 4. Merge - composition merge (package-level function)
+// Prefers incoming specified values over current values
+func MergeColor(a, b Color) Color {
+	if b.IsSpecified() {
+		return b
+	}
+	return a
+}
+
+// 5. String - stringification (method on value receiver)
+func (c Color) String() string {
+	if c.IsUnspecified() {
+		return "Color{Unspecified}"
+	}
+	return "Color{" + c.CSS() + "}"
+}
+
+// StringColor - package-level string function
+func StringColor(c Color) string {
+	return c.String()
+}
+
+// 6. Coalesce - N/A for value types (no nil possible)
+
+// 7. Same - identity (compares the packed bits)
+func SameColor(a, b Color) bool {
+	return a == b
+}
+
+// 8. SemanticEqual - every channel is compared within
+// ColorEqualityThreshold. Colors in different spaces are compared in
+// unclipped CIE XYZ, so a color outside one space's gamut is not equated
+// with its clipped conversion and the result does not depend on argument
+// order.
+func SemanticEqualColor(a, b Color) bool {
+	if a.IsUnspecified() || b.IsUnspecified() {
+		return a.IsUnspecified() && b.IsUnspecified()
+	}
+	if !floatutils.Float32Equals(a.Alpha(), b.Alpha(), ColorEqualityThreshold) {
+		return false
+	}
+	if colorspace.SameColorSpace(a.ColorSpace(), b.ColorSpace()) {
+		return floatutils.Float32Equals(a.Red(), b.Red(), ColorEqualityThreshold) &&
+			floatutils.Float32Equals(a.Green(), b.Green(), ColorEqualityThreshold) &&
+			floatutils.Float32Equals(a.Blue(), b.Blue(), ColorEqualityThreshold)
+	}
+	ax, ay, az := a.xyz()
+	bx, by, bz := b.xyz()
+	const eps = float64(ColorEqualityThreshold)
+	return floatutils.Float64Equals(ax, bx, eps) &&
+		floatutils.Float64Equals(ay, by, eps) &&
+		floatutils.Float64Equals(az, bz, eps)
+}
+
+func (c Color) xyz() (x, y, z float64) {
+	return c.ColorSpace().ToXYZ(float64(c.Red()), float64(c.Green()), float64(c.Blue()))
+}
+
+// 9. Equal - equality check (method on value receiver)
+func (c Color) Equal(other Color) bool {
+	return SameColor(c, other) || SemanticEqualColor(c, other)
+}
+
+// EqualColor - package-level equal function
+func EqualColor(a, b Color) bool {
+	return a.Equal(b)
+}
+
+// 10. Copy - per-channel overrides using float sentinels
+
+type ColorCopyOptions struct {
+	Alpha, Red, Green, Blue float32
+}
+type ColorCopyOption func(*ColorCopyOptions) ColorCopyOptions
+
+func CopyWithAlpha(alpha float32) ColorCopyOption {
+	return func(o *ColorCopyOptions) ColorCopyOptions {
+		o.Alpha = alpha
+		return *o
+	}
+}
+
+func CopyWithRed(red float32) ColorCopyOption {
+	return func(o *ColorCopyOptions) ColorCopyOptions {
+		o.Red = red
+		return *o
+	}
+}
+
+func CopyWithGreen(green float32) ColorCopyOption {
+	return func(o *ColorCopyOptions) ColorCopyOptions {
+		o.Green = green
+		return *o
+	}
+}
+
+func CopyWithBlue(blue float32) ColorCopyOption {
+	return func(o *ColorCopyOptions) ColorCopyOptions {
+		o.Blue = blue
+		return *o
+	}
+}
+
+// Copy creates a new color with modified components, kept in c's space.
+// Channels left at floatutils.Float32Unspecified are taken from c.
+// An Unspecified color has no space, so it copies to ColorUnspecified.
+func (c Color) Copy(opts ...ColorCopyOption) Color {
+	if c.IsUnspecified() {
+		return ColorUnspecified
+	}
+	var o ColorCopyOptions = ColorCopyOptions{
+		Alpha: floatutils.Float32Unspecified,
+		Red:   floatutils.Float32Unspecified,
+		Green: floatutils.Float32Unspecified,
+		Blue:  floatutils.Float32Unspecified,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	id := c.ColorSpaceId()
+	space := colorspace.Get(id)
+	return NewColor(
+		floatutils.TakeOrElse(o.Alpha, c.Alpha()),
+		floatutils.TakeOrElse(o.Red, c.Red()),
+		floatutils.TakeOrElse(o.Green, c.Green()),
+		floatutils.TakeOrElse(o.Blue, c.Blue()),
+		space,
+	)
+}
+
+// CopyColor - package-level copy function
+func CopyColor(c Color, opts ...ColorCopyOption) Color {
+	return c.Copy(opts...)
+}
@@ -0,0 +1,172 @@
+package color
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zodimo/go-sentinel-helper/sentinel/color/colorspace"
+)
+
+func TestNewColor(t *testing.T) {
+	c := NewColor(1, 1, 0.5, 0, colorspace.SRGB)
+	require.True(t, c.IsSpecified())
+	require.Equal(t, colorspace.IDSRGB, c.ColorSpaceId())
+	require.Same(t, colorspace.SRGB, c.ColorSpace())
+	require.Equal(t, float32(1), c.Alpha())
+	require.Equal(t, float32(1), c.Red())
+	require.InDelta(t, 0.5, c.Green(), 1e-4)
+	require.Equal(t, float32(0), c.Blue())
+
+	// Transparent black is a real color, distinct from the sentinel.
+	require.True(t, NewColor(0, 0, 0, 0, colorspace.SRGB).IsSpecified())
+
+	// Channels are clamped to [0, 1].
+	clamped := NewColor(2, -1, 0, 0, colorspace.SRGB)
+	require.Equal(t, float32(1), clamped.Alpha())
+	require.Equal(t, float32(0), clamped.Red())
+
+	nan := float32(math.NaN())
+	require.True(t, NewColor(nan, 0, 0, 0, colorspace.SRGB).IsUnspecified())
+	require.True(t, NewColor(1, 0, 0, 0, colorspace.ColorSpaceUnspecified).IsUnspecified())
+	require.True(t, NewColor(1, 0, 0, 0, nil).IsUnspecified())
+}
+
+func TestFromARGB(t *testing.T) {
+	for _, argb := range []uint32{0xFF000000, 0xFFFFFFFF, 0x80FF8000, 0x00000000, 0x12345678} {
+		require.Equal(t, argb, FromARGB(argb).ARGB())
+	}
+}
+
+func TestColorUnspecified(t *testing.T) {
+	require.Equal(t, Color(0), ColorUnspecified)
+	require.False(t, IsSpecifiedColor(ColorUnspecified))
+	require.True(t, ColorUnspecified.IsUnspecified())
+	require.Same(t, colorspace.ColorSpaceUnspecified, ColorUnspecified.ColorSpace())
+	require.True(t, math.IsNaN(float64(ColorUnspecified.Alpha())))
+	require.True(t, math.IsNaN(float64(ColorUnspecified.Red())))
+	require.Equal(t, uint32(0), ColorUnspecified.ARGB())
+}
+
+func TestTakeOrElseAndMergeColor(t *testing.T) {
+	red := FromARGB(0xFFFF0000)
+	blue := FromARGB(0xFF0000FF)
+
+	require.Equal(t, red, TakeOrElseColor(red, blue))
+	require.Equal(t, blue, TakeOrElseColor(ColorUnspecified, blue))
+	require.Equal(t, blue, ColorUnspecified.TakeOrElse(blue))
+
+	require.Equal(t, blue, MergeColor(red, blue))
+	require.Equal(t, red, MergeColor(red, ColorUnspecified))
+	require.Equal(t, ColorUnspecified, MergeColor(ColorUnspecified, ColorUnspecified))
+}
+
+func TestStringColor(t *testing.T) {
+	require.Equal(t, "Color{Unspecified}", StringColor(ColorUnspecified))
+	require.Equal(t, "Color{#ff8000}", FromARGB(0xFFFF8000).String())
+	require.Equal(t, "Color{color(display-p3 1 0 0)}", NewColor(1, 1, 0, 0, colorspace.DisplayP3).String())
+}
+
+func TestConvert(t *testing.T) {
+	white := FromARGB(0xFFFFFFFF)
+	for _, space := range []*colorspace.ColorSpace{colorspace.LinearSRGB, colorspace.DisplayP3} {
+		w := white.Convert(space)
+		require.Same(t, space, w.ColorSpace())
+		require.InDelta(t, 1, w.Red(), 1e-3)
+		require.InDelta(t, 1, w.Green(), 1e-3)
+		require.InDelta(t, 1, w.Blue(), 1e-3)
+	}
+
+	// sRGB mid grey is ~0.214 in linear light.
+	grey := FromARGB(0xFF808080).Convert(colorspace.LinearSRGB)
+	require.InDelta(t, 0.2158, grey.Red(), 1e-3)
+
+	// sRGB red sits inside Display P3.
+	red := FromARGB(0xFFFF0000)
+	p3 := red.Convert(colorspace.DisplayP3)
+	require.InDelta(t, 0.9175, p3.Red(), 1e-3)
+	require.InDelta(t, 0.2003, p3.Green(), 1e-3)
+	require.InDelta(t, 0.1386, p3.Blue(), 1e-3)
+	require.Equal(t, uint32(0xFFFF0000), p3.ARGB())
+
+	// Alpha is carried over, Unspecified stays Unspecified.
+	half := FromARGB(0x80FF0000).Convert(colorspace.DisplayP3)
+	require.InDelta(t, 128.0/255, half.Alpha(), 1e-3)
+	require.Equal(t, ColorUnspecified, ColorUnspecified.Convert(colorspace.DisplayP3))
+	require.Equal(t, red, red.Convert(colorspace.SRGB))
+}
+
+func TestColorEquality(t *testing.T) {
+	red := FromARGB(0xFFFF0000)
+	tests := []struct {
+		name           string
+		a, b           Color
+		same, semantic bool
+	}{
+		{"both unspecified", ColorUnspecified, ColorUnspecified, true, true},
+		{"one unspecified", red, ColorUnspecified, false, false},
+		{"identical", red, FromARGB(0xFFFF0000), true, true},
+		{"float rounding", red, NewColor(1, 0.9999, 0.0001, 0, colorspace.SRGB), false, true},
+		{"across spaces", red, red.Convert(colorspace.DisplayP3), false, true},
+		{"different", red, FromARGB(0xFFFE0000), false, false},
+		{"different alpha", red, FromARGB(0xFEFF0000), false, false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.same, SameColor(tc.a, tc.b))
+			require.Equal(t, tc.semantic, SemanticEqualColor(tc.a, tc.b))
+			require.Equal(t, tc.same || tc.semantic, EqualColor(tc.a, tc.b))
+		})
+	}
+}
+
+func TestColorEqualitySymmetric(t *testing.T) {
+	wide := NewColor(1, 1, 1, 0, colorspace.DisplayP3)
+	clipped := wide.Convert(colorspace.SRGB)
+	red := FromARGB(0xFFFF0000)
+	for _, pair := range [][2]Color{
+		{wide, clipped},
+		{red, red.Convert(colorspace.DisplayP3)},
+		{red, red.Convert(colorspace.LinearSRGB)},
+		{clipped, ColorUnspecified},
+	} {
+		a, b := pair[0], pair[1]
+		require.Equal(t, SemanticEqualColor(a, b), SemanticEqualColor(b, a), "%v, %v", a, b)
+		require.Equal(t, a.Equal(b), b.Equal(a), "%v, %v", a, b)
+	}
+	require.False(t, wide.Equal(clipped), "a clipped conversion is a different color")
+	require.True(t, red.Equal(red.Convert(colorspace.DisplayP3)))
+}
+
+func TestCopyColor(t *testing.T) {
+	c := NewColor(1, 0.2, 0.4, 0.6, colorspace.DisplayP3)
+
+	require.Equal(t, c, c.Copy())
+
+	half := c.Copy(CopyWithAlpha(0.5))
+	require.Same(t, colorspace.DisplayP3, half.ColorSpace())
+	require.InDelta(t, 0.5, half.Alpha(), 1e-3)
+	require.Equal(t, c.Red(), half.Red())
+	require.Equal(t, c.Green(), half.Green())
+	require.Equal(t, c.Blue(), half.Blue())
+
+	rgb := CopyColor(c, CopyWithRed(1), CopyWithGreen(0), CopyWithBlue(0.5))
+	require.Equal(t, float32(1), rgb.Red())
+	require.Equal(t, float32(0), rgb.Green())
+	require.InDelta(t, 0.5, rgb.Blue(), 1e-4)
+	require.Equal(t, c.Alpha(), rgb.Alpha())
+
+	// Float sentinels leave the channel untouched.
+	require.Equal(t, c, c.Copy(CopyWithRed(float32(math.NaN()))))
+
+	require.Equal(t, ColorUnspecified, ColorUnspecified.Copy(CopyWithAlpha(1)))
+}
+
+func BenchmarkCopyWithAlpha(b *testing.B) {
+	c := FromARGB(0xFFFF8000)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		c = c.Copy(CopyWithAlpha(0.5))
+	}
+	_ = c
+}
@@ -0,0 +1,256 @@
+// Package colorspace defines the RGB color spaces a color.Color can be
+// expressed in. Every space shares the D65 white point, so converting
+// between them is a transfer-function decode, two 3x3 matrix products
+// through CIE XYZ and a re-encode.
+package colorspace
+
+import (
+	"fmt"
+	"math"
+)
+
+// ID identifies a color space inside a packed color. It fits in 6 bits.
+type ID uint8
+
+const (
+	// IDUnspecified is the zero ID; colors carrying it are Unspecified.
+	IDUnspecified ID = iota
+	IDSRGB
+	IDLinearSRGB
+	IDDisplayP3
+)
+
+// MaxID is the largest ID that fits in a packed color.
+const MaxID ID = 1<<6 - 1
+
+type matrix3 [3][3]float64
+
+func (m matrix3) apply(a, b, c float64) (float64, float64, float64) {
+	return m[0][0]*a + m[0][1]*b + m[0][2]*c,
+		m[1][0]*a + m[1][1]*b + m[1][2]*c,
+		m[2][0]*a + m[2][1]*b + m[2][2]*c
+}
+
+func (m matrix3) inverse() matrix3 {
+	det := m[0][0]*(m[1][1]*m[2][2]-m[1][2]*m[2][1]) -
+		m[0][1]*(m[1][0]*m[2][2]-m[1][2]*m[2][0]) +
+		m[0][2]*(m[1][0]*m[2][1]-m[1][1]*m[2][0])
+	inv := 1 / det
+	return matrix3{
+		{
+			(m[1][1]*m[2][2] - m[1][2]*m[2][1]) * inv,
+			(m[0][2]*m[2][1] - m[0][1]*m[2][2]) * inv,
+			(m[0][1]*m[1][2] - m[0][2]*m[1][1]) * inv,
+		},
+		{
+			(m[1][2]*m[2][0] - m[1][0]*m[2][2]) * inv,
+			(m[0][0]*m[2][2] - m[0][2]*m[2][0]) * inv,
+			(m[0][2]*m[1][0] - m[0][0]*m[1][2]) * inv,
+		},
+		{
+			(m[1][0]*m[2][1] - m[1][1]*m[2][0]) * inv,
+			(m[0][1]*m[2][0] - m[0][0]*m[2][1]) * inv,
+			(m[0][0]*m[1][1] - m[0][1]*m[1][0]) * inv,
+		},
+	}
+}
+
+// ColorSpace is an RGB color space with a D65 white point.
+// Uses Pattern 1-C from sentinel_pattern.md: spaces are immutable
+// singletons compared by pointer, with ColorSpaceUnspecified as sentinel.
+type ColorSpace struct {
+	id      ID
+	name    string
+	cssName string
+	linear  bool
+	toXYZ   matrix3
+	fromXYZ matrix3
+}
+
+func newColorSpace(id ID, name, cssName string, linear bool, toXYZ matrix3) *ColorSpace {
+	return &ColorSpace{
+		id:      id,
+		name:    name,
+		cssName: cssName,
+		linear:  linear,
+		toXYZ:   toXYZ,
+		fromXYZ: toXYZ.inverse(),
+	}
+}
+
+var (
+	srgbToXYZ = matrix3{
+		{0.4124564, 0.3575761, 0.1804375},
+		{0.2126729, 0.7151522, 0.0721750},
+		{0.0193339, 0.1191920, 0.9503041},
+	}
+	displayP3ToXYZ = matrix3{
+		{0.4865709, 0.2656677, 0.1982173},
+		{0.2289746, 0.6917385, 0.0792869},
+		{0.0000000, 0.0451134, 1.0439444},
+	}
+)
+
+// 1. Sentinel - ColorSpaceUnspecified singleton
+var ColorSpaceUnspecified = &ColorSpace{id: IDUnspecified, name: "Unspecified"}
+
+var (
+	// SRGB is IEC 61966-2-1 sRGB, the default space for hex and rgb() colors.
+	SRGB = newColorSpace(IDSRGB, "sRGB", "srgb", false, srgbToXYZ)
+	// LinearSRGB shares sRGB's primaries without the transfer function.
+	LinearSRGB = newColorSpace(IDLinearSRGB, "Linear sRGB", "srgb-linear", true, srgbToXYZ)
+	// DisplayP3 uses the DCI-P3 primaries with the sRGB transfer function.
+	DisplayP3 = newColorSpace(IDDisplayP3, "Display P3", "display-p3", false, displayP3ToXYZ)
+)
+
+var registry = [...]*ColorSpace{
+	IDUnspecified: ColorSpaceUnspecified,
+	IDSRGB:        SRGB,
+	IDLinearSRGB:  LinearSRGB,
+	IDDisplayP3:   DisplayP3,
+}
+
+// Get returns the color space registered under id,
+// or ColorSpaceUnspecified if there is none.
+func Get(id ID) *ColorSpace {
+	if int(id) < len(registry) {
+		return registry[id]
+	}
+	return ColorSpaceUnspecified
+}
+
+// Lookup returns the color space with the given CSS color() identifier,
+// e.g. "srgb", "srgb-linear" or "display-p3".
+func Lookup(cssName string) (*ColorSpace, bool) {
+	for _, cs := range registry {
+		if cs.cssName != "" && cs.cssName == cssName {
+			return cs, true
+		}
+	}
+	return ColorSpaceUnspecified, false
+}
+
+// ID returns the identifier stored in packed colors.
+func (cs *ColorSpace) ID() ID {
+	return CoalesceColorSpace(cs, ColorSpaceUnspecified).id
+}
+
+// Name returns the human readable name, e.g. "Display P3".
+func (cs *ColorSpace) Name() string {
+	return CoalesceColorSpace(cs, ColorSpaceUnspecified).name
+}
+
+// CSSName returns the CSS color() identifier, empty if Unspecified.
+func (cs *ColorSpace) CSSName() string {
+	return CoalesceColorSpace(cs, ColorSpaceUnspecified).cssName
+}
+
+// IsLinear reports whether the space has an identity transfer function.
+func (cs *ColorSpace) IsLinear() bool {
+	return CoalesceColorSpace(cs, ColorSpaceUnspecified).linear
+}
+
+// ToLinear decodes a single channel with the transfer function.
+func (cs *ColorSpace) ToLinear(v float64) float64 {
+	if cs.IsLinear() {
+		return v
+	}
+	sign := 1.0
+	if v < 0 {
+		sign, v = -1, -v
+	}
+	if v <= 0.04045 {
+		return sign * v / 12.92
+	}
+	return sign * math.Pow((v+0.055)/1.055, 2.4)
+}
+
+// FromLinear encodes a single linear channel with the transfer function.
+func (cs *ColorSpace) FromLinear(v float64) float64 {
+	if cs.IsLinear() {
+		return v
+	}
+	sign := 1.0
+	if v < 0 {
+		sign, v = -1, -v
+	}
+	if v <= 0.0031308 {
+		return sign * v * 12.92
+	}
+	return sign * (1.055*math.Pow(v, 1/2.4) - 0.055)
+}
+
+// ToXYZ converts encoded channels to CIE XYZ (D65).
+func (cs *ColorSpace) ToXYZ(r, g, b float64) (x, y, z float64) {
+	cs = CoalesceColorSpace(cs, ColorSpaceUnspecified)
+	return cs.toXYZ.apply(cs.ToLinear(r), cs.ToLinear(g), cs.ToLinear(b))
+}
+
+// FromXYZ converts CIE XYZ (D65) to encoded channels.
+func (cs *ColorSpace) FromXYZ(x, y, z float64) (r, g, b float64) {
+	cs = CoalesceColorSpace(cs, ColorSpaceUnspecified)
+	r, g, b = cs.fromXYZ.apply(x, y, z)
+	return cs.FromLinear(r), cs.FromLinear(g), cs.FromLinear(b)
+}
+
+// 2. IsSpecified - predicate
+func IsSpecifiedColorSpace(cs *ColorSpace) bool {
+	return cs != nil && cs != ColorSpaceUnspecified
+}
+
+// 3. TakeOrElse - 2-param fallback
+func TakeOrElseColorSpace(cs, def *ColorSpace) *ColorSpace {
+	if IsSpecifiedColorSpace(cs) {
+		return cs
+	}
+	return def
+}
+
+// 4. Merge - spaces are atomic, the incoming specified space wins
+func MergeColorSpace(a, b *ColorSpace) *ColorSpace {
+	if IsSpecifiedColorSpace(b) {
+		return b
+	}
+	return CoalesceColorSpace(a, ColorSpaceUnspecified)
+}
+
+// 5. String - stringification
+func (cs *ColorSpace) String() string {
+	return StringColorSpace(cs)
+}
+
+// StringColorSpace - package-level string function
+func StringColorSpace(cs *ColorSpace) string {
+	if !IsSpecifiedColorSpace(cs) {
+		return "ColorSpace{Unspecified}"
+	}
+	return fmt.Sprintf("ColorSpace{%s}", cs.name)
+}
+
+// 6. Coalesce - nil coalescing
+func CoalesceColorSpace(cs, def *ColorSpace) *ColorSpace {
+	if cs == nil {
+		return def
+	}
+	return cs
+}
+
+// 7. Same - identity; spaces are singletons
+func SameColorSpace(a, b *ColorSpace) bool {
+	return CoalesceColorSpace(a, ColorSpaceUnspecified) == CoalesceColorSpace(b, ColorSpaceUnspecified)
+}
+
+// 8. SemanticEqual - spaces with the same ID are the same space
+func SemanticEqualColorSpace(a, b *ColorSpace) bool {
+	return a.ID() == b.ID()
+}
+
+// 9. Equal - equality check (combines Same and SemanticEqual)
+func EqualColorSpace(a, b *ColorSpace) bool {
+	return SameColorSpace(a, b) || SemanticEqualColorSpace(a, b)
+}
+
+// 10. Copy - spaces are immutable, so the singleton is returned
+func CopyColorSpace(cs *ColorSpace) *ColorSpace {
+	return CoalesceColorSpace(cs, ColorSpaceUnspecified)
+}
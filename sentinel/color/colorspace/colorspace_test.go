@@ -0,0 +1,88 @@
+package colorspace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGet(t *testing.T) {
+	require.Same(t, ColorSpaceUnspecified, Get(IDUnspecified))
+	require.Same(t, SRGB, Get(IDSRGB))
+	require.Same(t, LinearSRGB, Get(IDLinearSRGB))
+	require.Same(t, DisplayP3, Get(IDDisplayP3))
+	require.Same(t, ColorSpaceUnspecified, Get(MaxID))
+}
+
+func TestLookup(t *testing.T) {
+	cs, ok := Lookup("display-p3")
+	require.True(t, ok)
+	require.Same(t, DisplayP3, cs)
+
+	cs, ok = Lookup("rec2020")
+	require.False(t, ok)
+	require.Same(t, ColorSpaceUnspecified, cs)
+
+	_, ok = Lookup("")
+	require.False(t, ok)
+}
+
+func TestColorSpaceAccessors(t *testing.T) {
+	require.Equal(t, IDDisplayP3, DisplayP3.ID())
+	require.Equal(t, "Display P3", DisplayP3.Name())
+	require.Equal(t, "srgb-linear", LinearSRGB.CSSName())
+	require.True(t, LinearSRGB.IsLinear())
+	require.False(t, SRGB.IsLinear())
+
+	var nilSpace *ColorSpace
+	require.Equal(t, IDUnspecified, nilSpace.ID())
+	require.Equal(t, "Unspecified", nilSpace.Name())
+}
+
+func TestTransferFunction(t *testing.T) {
+	for _, v := range []float64{0, 0.001, 0.04045, 0.5, 1} {
+		require.InDelta(t, v, SRGB.FromLinear(SRGB.ToLinear(v)), 1e-7)
+	}
+	require.InDelta(t, 0.2140, SRGB.ToLinear(0.5), 1e-4)
+	require.Equal(t, 0.5, LinearSRGB.ToLinear(0.5))
+}
+
+func TestXYZRoundTrip(t *testing.T) {
+	for _, cs := range []*ColorSpace{SRGB, LinearSRGB, DisplayP3} {
+		x, y, z := cs.ToXYZ(1, 1, 1)
+		// D65 white point.
+		require.InDelta(t, 0.9505, x, 1e-3)
+		require.InDelta(t, 1.0, y, 1e-3)
+		require.InDelta(t, 1.089, z, 1e-3)
+
+		r, g, b := cs.FromXYZ(cs.ToXYZ(0.2, 0.4, 0.6))
+		require.InDelta(t, 0.2, r, 1e-9)
+		require.InDelta(t, 0.4, g, 1e-9)
+		require.InDelta(t, 0.6, b, 1e-9)
+	}
+}
+
+func TestColorSpaceContract(t *testing.T) {
+	require.False(t, IsSpecifiedColorSpace(nil))
+	require.False(t, IsSpecifiedColorSpace(ColorSpaceUnspecified))
+	require.True(t, IsSpecifiedColorSpace(SRGB))
+
+	require.Same(t, SRGB, TakeOrElseColorSpace(SRGB, DisplayP3))
+	require.Same(t, DisplayP3, TakeOrElseColorSpace(nil, DisplayP3))
+
+	require.Same(t, DisplayP3, MergeColorSpace(SRGB, DisplayP3))
+	require.Same(t, SRGB, MergeColorSpace(SRGB, ColorSpaceUnspecified))
+	require.Same(t, ColorSpaceUnspecified, MergeColorSpace(nil, nil))
+
+	require.Equal(t, "ColorSpace{sRGB}", SRGB.String())
+	require.Equal(t, "ColorSpace{Unspecified}", StringColorSpace(nil))
+
+	require.Same(t, SRGB, CoalesceColorSpace(nil, SRGB))
+	require.True(t, SameColorSpace(nil, ColorSpaceUnspecified))
+	require.False(t, SameColorSpace(SRGB, LinearSRGB))
+	require.True(t, SemanticEqualColorSpace(SRGB, Get(IDSRGB)))
+	require.True(t, EqualColorSpace(DisplayP3, DisplayP3))
+	require.False(t, EqualColorSpace(DisplayP3, SRGB))
+	require.Same(t, DisplayP3, CopyColorSpace(DisplayP3))
+	require.Same(t, ColorSpaceUnspecified, CopyColorSpace(nil))
+}
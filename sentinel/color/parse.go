@@ -0,0 +1,263 @@
+package color
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/zodimo/go-sentinel-helper/sentinel/color/colorspace"
+)
+
+// ErrInvalidColor is wrapped by every parse failure.
+var ErrInvalidColor = errors.New("color: invalid color")
+
+// namedColors are the CSS basic color keywords plus transparent.
+var namedColors = map[string]uint32{
+	"transparent": 0x00000000,
+	"black":       0xFF000000,
+	"silver":      0xFFC0C0C0,
+	"gray":        0xFF808080,
+	"grey":        0xFF808080,
+	"white":       0xFFFFFFFF,
+	"maroon":      0xFF800000,
+	"red":         0xFFFF0000,
+	"purple":      0xFF800080,
+	"fuchsia":     0xFFFF00FF,
+	"magenta":     0xFFFF00FF,
+	"green":       0xFF008000,
+	"lime":        0xFF00FF00,
+	"olive":       0xFF808000,
+	"yellow":      0xFFFFFF00,
+	"navy":        0xFF000080,
+	"blue":        0xFF0000FF,
+	"teal":        0xFF008080,
+	"aqua":        0xFF00FFFF,
+	"cyan":        0xFF00FFFF,
+}
+
+// Hex formats c converted to sRGB as "#rrggbb", or "#rrggbbaa" when it is
+// not opaque. Unspecified formats as the empty string.
+func (c Color) Hex() string {
+	if c.IsUnspecified() {
+		return ""
+	}
+	argb := c.ARGB()
+	if argb>>24 == 0xFF {
+		return fmt.Sprintf("#%06x", argb&0xFFFFFF)
+	}
+	return fmt.Sprintf("#%06x%02x", argb&0xFFFFFF, argb>>24)
+}
+
+// formatChannel returns the shortest decimal that quantizes back to the
+// same unorm step, so parsing the result restores the same packed bits.
+func formatChannel(v float32, max float64) string {
+	q := quantize(v, max)
+	for digits := 0; ; digits++ {
+		scale := math.Pow10(digits)
+		r := math.Round(float64(v)*scale) / scale
+		if quantize(float32(r), max) == q {
+			return strconv.FormatFloat(r, 'f', -1, 64)
+		}
+	}
+}
+
+// CSS formats c as CSS Color Level 4: hex for sRGB and
+// "color(<space> r g b[ / a])" for the other spaces.
+// Unspecified formats as the empty string.
+func (c Color) CSS() string {
+	if c.IsUnspecified() {
+		return ""
+	}
+	space := c.ColorSpace()
+	if space == colorspace.SRGB {
+		return c.Hex()
+	}
+	var sb strings.Builder
+	sb.WriteString("color(")
+	sb.WriteString(space.CSSName())
+	for _, v := range [...]float32{c.Red(), c.Green(), c.Blue()} {
+		sb.WriteByte(' ')
+		sb.WriteString(formatChannel(v, channelMax))
+	}
+	if a := c.Alpha(); a < 1 {
+		sb.WriteString(" / ")
+		sb.WriteString(formatChannel(a, alphaMax))
+	}
+	sb.WriteByte(')')
+	return sb.String()
+}
+
+// ParseHex parses "#rgb", "#rgba", "#rrggbb" or "#rrggbbaa" as sRGB.
+// The leading '#' is optional.
+func ParseHex(s string) (Color, error) {
+	h := strings.TrimPrefix(s, "#")
+	switch len(h) {
+	case 3, 4:
+		var sb strings.Builder
+		for i := 0; i < len(h); i++ {
+			sb.WriteByte(h[i])
+			sb.WriteByte(h[i])
+		}
+		h = sb.String()
+	case 6, 8:
+	default:
+		return ColorUnspecified, fmt.Errorf("%w %q: hex must have 3, 4, 6 or 8 digits", ErrInvalidColor, s)
+	}
+	v, err := strconv.ParseUint(h, 16, 32)
+	if err != nil {
+		return ColorUnspecified, fmt.Errorf("%w %q: %v", ErrInvalidColor, s, err)
+	}
+	if len(h) == 6 {
+		return FromARGB(0xFF000000 | uint32(v)), nil
+	}
+	return FromARGB(uint32(v)>>8 | uint32(v)<<24), nil
+}
+
+// ParseCSS parses a CSS color: hex, a basic named color, rgb()/rgba() in
+// legacy comma or modern space syntax, or color() in one of the
+// colorspace spaces. The empty string parses as ColorUnspecified.
+func ParseCSS(s string) (Color, error) {
+	css := strings.ToLower(strings.TrimSpace(s))
+	switch {
+	case css == "":
+		return ColorUnspecified, nil
+	case strings.HasPrefix(css, "#"):
+		return ParseHex(css)
+	case strings.HasPrefix(css, "rgb(") || strings.HasPrefix(css, "rgba("):
+		return parseRGB(s, css)
+	case strings.HasPrefix(css, "color("):
+		return parseColorFunc(s, css)
+	}
+	if argb, ok := namedColors[css]; ok {
+		return FromARGB(argb), nil
+	}
+	return ColorUnspecified, fmt.Errorf("%w %q", ErrInvalidColor, s)
+}
+
+// MustParseCSS is like ParseCSS but panics on error.
+func MustParseCSS(s string) Color {
+	c, err := ParseCSS(s)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// funcArgs splits the arguments of "name(a b c / d)" into the channel
+// list and the optional alpha.
+func funcArgs(css string) (channels []string, alpha string, ok bool) {
+	open := strings.IndexByte(css, '(')
+	if open < 0 || !strings.HasSuffix(css, ")") {
+		return nil, "", false
+	}
+	body := strings.ReplaceAll(css[open+1:len(css)-1], ",", " ")
+	parts := strings.Split(body, "/")
+	switch len(parts) {
+	case 1:
+	case 2:
+		alpha = strings.TrimSpace(parts[1])
+		if alpha == "" {
+			return nil, "", false
+		}
+	default:
+		return nil, "", false
+	}
+	return strings.Fields(parts[0]), alpha, true
+}
+
+// parseNumber parses a number or percentage; scale maps a bare number
+// onto [0, 1] (255 for rgb() channels, 1 otherwise). NaN and the
+// infinities, which strconv accepts, are rejected: NaN would make the
+// whole color read as ColorUnspecified.
+func parseNumber(s string, scale float64) (float32, error) {
+	p, percent := strings.CutSuffix(s, "%")
+	v, err := strconv.ParseFloat(p, 64)
+	if err != nil {
+		return 0, err
+	}
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, fmt.Errorf("%q is not a finite number", s)
+	}
+	if percent {
+		scale = 100
+	}
+	return float32(v / scale), nil
+}
+
+func parseChannels(s string, channels []string, alpha string, scale float64) ([4]float32, error) {
+	out := [4]float32{1, 1, 1, 1}
+	if len(channels) == 4 && alpha == "" {
+		// Legacy rgba(r, g, b, a).
+		channels, alpha = channels[:3], channels[3]
+	}
+	if len(channels) != 3 {
+		return out, fmt.Errorf("%w %q: want 3 channels, got %d", ErrInvalidColor, s, len(channels))
+	}
+	for i, ch := range channels {
+		v, err := parseNumber(ch, scale)
+		if err != nil {
+			return out, fmt.Errorf("%w %q: %v", ErrInvalidColor, s, err)
+		}
+		out[i+1] = v
+	}
+	if alpha != "" {
+		v, err := parseNumber(alpha, 1)
+		if err != nil {
+			return out, fmt.Errorf("%w %q: %v", ErrInvalidColor, s, err)
+		}
+		out[0] = v
+	}
+	return out, nil
+}
+
+func parseRGB(s, css string) (Color, error) {
+	channels, alpha, ok := funcArgs(css)
+	if !ok {
+		return ColorUnspecified, fmt.Errorf("%w %q", ErrInvalidColor, s)
+	}
+	v, err := parseChannels(s, channels, alpha, 255)
+	if err != nil {
+		return ColorUnspecified, err
+	}
+	return NewColor(v[0], v[1], v[2], v[3], colorspace.SRGB), nil
+}
+
+func parseColorFunc(s, css string) (Color, error) {
+	channels, alpha, ok := funcArgs(css)
+	if !ok || len(channels) == 0 {
+		return ColorUnspecified, fmt.Errorf("%w %q", ErrInvalidColor, s)
+	}
+	space, ok := colorspace.Lookup(channels[0])
+	if !ok {
+		return ColorUnspecified, fmt.Errorf("%w %q: unknown color space %q", ErrInvalidColor, s, channels[0])
+	}
+	v, err := parseChannels(s, channels[1:], alpha, 1)
+	if err != nil {
+		return ColorUnspecified, err
+	}
+	return NewColor(v[0], v[1], v[2], v[3], space), nil
+}
+
+// IsZero reports whether c is Unspecified, so that encoders honouring
+// `omitempty`/`omitzero` drop it.
+func (c Color) IsZero() bool {
+	return c.IsUnspecified()
+}
+
+// MarshalText implements encoding.TextMarshaler using the CSS form.
+// Unspecified encodes as the empty string.
+func (c Color) MarshalText() ([]byte, error) {
+	return []byte(c.CSS()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler via ParseCSS.
+func (c *Color) UnmarshalText(text []byte) error {
+	parsed, err := ParseCSS(string(text))
+	if err != nil {
+		return err
+	}
+	*c = parsed
+	return nil
+}
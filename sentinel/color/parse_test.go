@@ -0,0 +1,119 @@
+package color
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zodimo/go-sentinel-helper/sentinel/color/colorspace"
+)
+
+func TestParseHex(t *testing.T) {
+	tests := []struct {
+		in   string
+		want uint32
+	}{
+		{"#f80", 0xFFFF8800},
+		{"f80", 0xFFFF8800},
+		{"#f808", 0x88FF8800},
+		{"#FF8000", 0xFFFF8000},
+		{"#ff800080", 0x80FF8000},
+		{"#00000000", 0x00000000},
+	}
+	for _, tc := range tests {
+		t.Run(tc.in, func(t *testing.T) {
+			c, err := ParseHex(tc.in)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, c.ARGB())
+		})
+	}
+
+	for _, bad := range []string{"", "#ff", "#fffff", "#gggggg"} {
+		_, err := ParseHex(bad)
+		require.ErrorIs(t, err, ErrInvalidColor, bad)
+	}
+}
+
+func TestHex(t *testing.T) {
+	require.Equal(t, "", ColorUnspecified.Hex())
+	require.Equal(t, "#ff8000", FromARGB(0xFFFF8000).Hex())
+	require.Equal(t, "#ff800080", FromARGB(0x80FF8000).Hex())
+	require.Equal(t, "#ff0000", NewColor(1, 1, 0, 0, colorspace.LinearSRGB).Hex())
+}
+
+func TestParseCSS(t *testing.T) {
+	tests := []struct {
+		in    string
+		want  uint32
+		space *colorspace.ColorSpace
+	}{
+		{"#ff8000", 0xFFFF8000, colorspace.SRGB},
+		{"  Red ", 0xFFFF0000, colorspace.SRGB},
+		{"transparent", 0x00000000, colorspace.SRGB},
+		{"rgb(255, 128, 0)", 0xFFFF8000, colorspace.SRGB},
+		{"rgba(255, 128, 0, 0.5)", 0x80FF8000, colorspace.SRGB},
+		{"rgb(255 128 0 / 50%)", 0x80FF8000, colorspace.SRGB},
+		{"rgb(100% 0% 0%)", 0xFFFF0000, colorspace.SRGB},
+		{"color(srgb 1 0.5 0)", 0xFFFF8000, colorspace.SRGB},
+		{"color(srgb-linear 1 0 0)", 0xFFFF0000, colorspace.LinearSRGB},
+		{"color(display-p3 0.9175 0.2003 0.1386)", 0xFFFF0000, colorspace.DisplayP3},
+		{"color(display-p3 1 1 1 / 0.5)", 0x80FFFFFF, colorspace.DisplayP3},
+	}
+	for _, tc := range tests {
+		t.Run(tc.in, func(t *testing.T) {
+			c, err := ParseCSS(tc.in)
+			require.NoError(t, err)
+			require.Same(t, tc.space, c.ColorSpace())
+			require.Equal(t, tc.want, c.ARGB())
+		})
+	}
+
+	c, err := ParseCSS("")
+	require.NoError(t, err)
+	require.Equal(t, ColorUnspecified, c)
+
+	for _, bad := range []string{"nope", "rgb(1, 2)", "rgb(1 2 3", "rgb(a b c)", "color(rec2020 1 0 0)", "rgb(1 2 3 / )", "rgb(1 2 3 / 4 / 5)", "rgb(nan 0 0)", "rgb(0 inf 0)", "rgb(0 0 -Infinity%)", "rgb(0 0 0 / NaN)"} {
+		_, err := ParseCSS(bad)
+		require.ErrorIs(t, err, ErrInvalidColor, bad)
+	}
+	require.Panics(t, func() { MustParseCSS("nope") })
+}
+
+func TestCSSRoundTrip(t *testing.T) {
+	colors := []Color{
+		ColorUnspecified,
+		FromARGB(0xFFFF8000),
+		FromARGB(0x40123456),
+		NewColor(1, 0.1, 0.2, 0.3, colorspace.DisplayP3),
+		NewColor(0.25, 0.123456, 0.654321, 1, colorspace.LinearSRGB),
+	}
+	for _, c := range colors {
+		t.Run(c.String(), func(t *testing.T) {
+			parsed, err := ParseCSS(c.CSS())
+			require.NoError(t, err)
+			require.Equal(t, c, parsed)
+		})
+	}
+	require.Equal(t, "color(display-p3 1 1 1 / 0.5)", MustParseCSS("color(display-p3 1 1 1 / 0.5)").CSS())
+}
+
+func TestColorText(t *testing.T) {
+	type style struct {
+		Fg Color `json:"fg"`
+		Bg Color `json:"bg"`
+	}
+	in := style{Fg: FromARGB(0xFFFF8000)}
+	data, err := json.Marshal(in)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"fg":"#ff8000","bg":""}`, string(data))
+
+	var out style
+	require.NoError(t, json.Unmarshal(data, &out))
+	require.Equal(t, in, out)
+
+	require.True(t, ColorUnspecified.IsZero())
+	require.False(t, out.Fg.IsZero())
+
+	var c Color
+	require.ErrorIs(t, c.UnmarshalText([]byte("nope")), ErrInvalidColor)
+}
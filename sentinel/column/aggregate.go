@@ -0,0 +1,61 @@
+package column
+
+// Number is a column element type the aggregations accept.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// Aggregations skip null slots. Those with no valid slot to report on
+// return false, since any value of T could be real data.
+
+// Sum returns the sum of the valid values, 0 if there are none. Integer
+// sums wrap on overflow like Go arithmetic.
+func Sum[T Number](c Column[T]) T {
+	var sum T
+	for _, v := range c.All() {
+		sum += v
+	}
+	return sum
+}
+
+// Min returns the smallest valid value, or false if there is none.
+func Min[T Number](c Column[T]) (T, bool) {
+	var out T
+	found := false
+	for _, v := range c.All() {
+		if !found || v < out {
+			out, found = v, true
+		}
+	}
+	return out, found
+}
+
+// Max returns the largest valid value, or false if there is none.
+func Max[T Number](c Column[T]) (T, bool) {
+	var out T
+	found := false
+	for _, v := range c.All() {
+		if !found || v > out {
+			out, found = v, true
+		}
+	}
+	return out, found
+}
+
+// Mean returns the arithmetic mean of the valid values as a float64, or
+// false if there is none. Values are summed in float64, so integer
+// columns do not overflow.
+func Mean[T Number](c Column[T]) (float64, bool) {
+	var sum float64
+	n := 0
+	for _, v := range c.All() {
+		sum += float64(v)
+		n++
+	}
+	if n == 0 {
+		return 0, false
+	}
+	return sum / float64(n), true
+}
@@ -0,0 +1,84 @@
+package column
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zodimo/go-sentinel-helper/sentinel/floatutils"
+	"github.com/zodimo/go-sentinel-helper/sentinel/intutils"
+)
+
+func TestAggregateFloats(t *testing.T) {
+	nan := floatutils.Float64Unspecified
+	c := FromFloat64s([]float64{nan, 4, -1, nan, 3})
+
+	require.Equal(t, 6.0, Sum(c))
+	lo, ok := Min(c)
+	require.True(t, ok)
+	require.Equal(t, -1.0, lo)
+	hi, ok := Max(c)
+	require.True(t, ok)
+	require.Equal(t, 4.0, hi)
+	mean, ok := Mean(c)
+	require.True(t, ok)
+	require.Equal(t, 2.0, mean)
+}
+
+func TestAggregateInts(t *testing.T) {
+	u := intutils.IntValueUnspecified
+	c := FromIntValues([]intutils.IntValue{u, 5, u, 10})
+	require.Equal(t, 15, Sum(c))
+	lo, _ := Min(c)
+	hi, _ := Max(c)
+	require.Equal(t, 5, lo)
+	require.Equal(t, 10, hi)
+	mean, _ := Mean(c)
+	require.Equal(t, 7.5, mean)
+
+	// Sentinels must not leak into the result.
+	only := FromIntValues([]intutils.IntValue{u, -3})
+	lo, _ = Min(only)
+	require.Equal(t, -3, lo)
+}
+
+func TestAggregateEmpty(t *testing.T) {
+	for _, c := range []Column[float32]{
+		{},
+		FromFloat32s([]float32{floatutils.Float32Unspecified}),
+	} {
+		require.Equal(t, float32(0), Sum(c))
+		_, ok := Min(c)
+		require.False(t, ok)
+		_, ok = Max(c)
+		require.False(t, ok)
+		_, ok = Mean(c)
+		require.False(t, ok)
+	}
+}
+
+func BenchmarkSum(b *testing.B) {
+	values := make([]float64, 1<<16)
+	for i := range values {
+		values[i] = float64(i)
+		if i%7 == 0 {
+			values[i] = floatutils.Float64Unspecified
+		}
+	}
+	c := FromFloat64s(values)
+	b.Run("column", func(b *testing.B) {
+		for b.Loop() {
+			Sum(c)
+		}
+	})
+	b.Run("scalar", func(b *testing.B) {
+		for b.Loop() {
+			var sum float64
+			for _, v := range values {
+				if floatutils.IsSpecified(v) {
+					sum += v
+				}
+			}
+			_ = sum
+		}
+	})
+}
@@ -0,0 +1,58 @@
+// Package column converts between in-band sentinel slices and
+// Arrow-style nullable columns: a dense value array next to a validity
+// bitmap.
+//
+// Sentinel packages mark "not set" inside the value itself (NaN,
+// math.MinInt, "\x00unspecified"); columnar formats such as Apache Arrow
+// keep a separate bit per slot instead. FromX builds a Column from an
+// in-band slice and ToX goes back, failing with ErrSentinelCollision
+// when a valid slot holds a value that would read as Unspecified.
+// Where the value types agree, FromX shares the slice rather than
+// copying it, so only the bitmap is allocated.
+package column
+
+import "math/bits"
+
+// Bitmap is a little-endian validity bitmap: bit i%64 of word i/64 is
+// set when slot i holds a value. It matches the layout of the Arrow
+// validity buffer and of the SpecifiedMask functions in floatutils,
+// intutils and boolutils.
+type Bitmap []uint64
+
+// BitmapWords returns the number of words a Bitmap needs for n slots.
+func BitmapWords(n int) int {
+	return (n + 63) / 64
+}
+
+// NewBitmap returns a Bitmap for n slots, all null.
+func NewBitmap(n int) Bitmap {
+	return make(Bitmap, BitmapWords(n))
+}
+
+// Get reports whether slot i is valid.
+func (b Bitmap) Get(i int) bool {
+	return b[i/64]>>(i%64)&1 == 1
+}
+
+// Set marks slot i valid or null.
+func (b Bitmap) Set(i int, valid bool) {
+	if valid {
+		b[i/64] |= 1 << (i % 64)
+	} else {
+		b[i/64] &^= 1 << (i % 64)
+	}
+}
+
+// count returns the number of valid slots among the first n. Bits past
+// n are ignored, so bitmaps from other producers may leave them set.
+func (b Bitmap) count(n int) int {
+	full := n / 64
+	c := 0
+	for _, w := range b[:full] {
+		c += bits.OnesCount64(w)
+	}
+	if rem := n % 64; rem != 0 {
+		c += bits.OnesCount64(b[full] & (1<<rem - 1))
+	}
+	return c
+}
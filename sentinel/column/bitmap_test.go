@@ -0,0 +1,30 @@
+package column
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitmap(t *testing.T) {
+	require.Equal(t, 0, BitmapWords(0))
+	require.Equal(t, 1, BitmapWords(64))
+	require.Equal(t, 2, BitmapWords(65))
+
+	b := NewBitmap(70)
+	require.Len(t, b, 2)
+	b.Set(0, true)
+	b.Set(65, true)
+	b.Set(3, true)
+	b.Set(3, false)
+	require.True(t, b.Get(0))
+	require.False(t, b.Get(3))
+	require.True(t, b.Get(65))
+	require.Equal(t, Bitmap{1, 2}, b)
+	require.Equal(t, 2, b.count(70))
+
+	// Padding bits past the length are not counted.
+	b[1] |= 1 << 10
+	require.Equal(t, 2, b.count(70))
+	require.Equal(t, 1, b.count(65))
+}
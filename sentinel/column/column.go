@@ -0,0 +1,132 @@
+package column
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"math/bits"
+)
+
+var (
+	// ErrBitmapLength is returned by NewColumn when the bitmap has fewer
+	// words than the values need.
+	ErrBitmapLength = errors.New("column: validity bitmap is too short")
+	// ErrSentinelCollision is returned when a valid slot holds a value
+	// that reads as Unspecified in the in-band representation, such as a
+	// valid NaN in a float column.
+	ErrSentinelCollision = errors.New("column: valid value collides with the Unspecified sentinel")
+)
+
+// Column is a nullable column: Values holds one slot per row and Valid
+// marks which slots hold a value. The content of a null slot is
+// unspecified; columns built by FromX keep the in-band sentinel there. A
+// nil Valid means every slot is valid, as in Arrow.
+type Column[T any] struct {
+	Values []T
+	Valid  Bitmap
+}
+
+// NewColumn checks that valid covers values and returns the column over
+// both, without copying.
+func NewColumn[T any](values []T, valid Bitmap) (Column[T], error) {
+	if valid != nil && len(valid) < BitmapWords(len(values)) {
+		return Column[T]{}, fmt.Errorf("%w: %d words for %d values", ErrBitmapLength, len(valid), len(values))
+	}
+	return Column[T]{Values: values, Valid: valid}, nil
+}
+
+// Len returns the number of slots.
+func (c Column[T]) Len() int {
+	return len(c.Values)
+}
+
+// IsValid reports whether slot i holds a value.
+func (c Column[T]) IsValid(i int) bool {
+	return c.Valid == nil || c.Valid.Get(i)
+}
+
+// Value returns slot i and whether it is valid.
+func (c Column[T]) Value(i int) (T, bool) {
+	if !c.IsValid(i) {
+		var zero T
+		return zero, false
+	}
+	return c.Values[i], true
+}
+
+// NullCount returns the number of null slots.
+func (c Column[T]) NullCount() int {
+	if c.Valid == nil {
+		return 0
+	}
+	return len(c.Values) - c.Valid.count(len(c.Values))
+}
+
+// All iterates over the valid slots in order, yielding index and value.
+// Runs of 64 valid slots are walked without testing each bit.
+func (c Column[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		n := len(c.Values)
+		for w := 0; w*64 < n; w++ {
+			base := w * 64
+			chunk := c.Values[base:min(n, base+64)]
+			word := ^uint64(0)
+			if c.Valid != nil {
+				word = c.Valid[w]
+			}
+			if word == ^uint64(0) {
+				for j, v := range chunk {
+					if !yield(base+j, v) {
+						return
+					}
+				}
+				continue
+			}
+			for word != 0 {
+				j := bits.TrailingZeros64(word)
+				if j >= len(chunk) {
+					break
+				}
+				word &= word - 1
+				if !yield(base+j, chunk[j]) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// FillNulls writes v into every null slot of c, in place. Filling with
+// the in-band sentinel turns c.Values into a sentinel slice without a
+// copy; unlike ToX it does not check the valid slots for collisions.
+func FillNulls[T any](c Column[T], v T) {
+	if c.Valid == nil {
+		return
+	}
+	for i := range c.Values {
+		if !c.Valid.Get(i) {
+			c.Values[i] = v
+		}
+	}
+}
+
+// view returns the column over values, sharing the slice.
+func view[T any](values []T, mask []uint64) Column[T] {
+	return Column[T]{Values: values, Valid: Bitmap(mask)}
+}
+
+// toInBand copies c into a new slice with sentinel in the null slots.
+func toInBand[T any](c Column[T], sentinel T, isSpecified func(T) bool) ([]T, error) {
+	out := make([]T, len(c.Values))
+	for i, v := range c.Values {
+		if !c.IsValid(i) {
+			out[i] = sentinel
+			continue
+		}
+		if !isSpecified(v) {
+			return nil, fmt.Errorf("%w at index %d", ErrSentinelCollision, i)
+		}
+		out[i] = v
+	}
+	return out, nil
+}
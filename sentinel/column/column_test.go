@@ -0,0 +1,184 @@
+package column
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zodimo/go-sentinel-helper/sentinel/boolutils"
+	"github.com/zodimo/go-sentinel-helper/sentinel/floatutils"
+	"github.com/zodimo/go-sentinel-helper/sentinel/intutils"
+	"github.com/zodimo/go-sentinel-helper/sentinel/stringutils"
+)
+
+func TestNewColumn(t *testing.T) {
+	c, err := NewColumn([]int{1, 2, 3}, Bitmap{0b101})
+	require.NoError(t, err)
+	require.Equal(t, 3, c.Len())
+	require.Equal(t, 1, c.NullCount())
+	v, ok := c.Value(2)
+	require.True(t, ok)
+	require.Equal(t, 3, v)
+	_, ok = c.Value(1)
+	require.False(t, ok)
+
+	all, err := NewColumn([]int{1, 2}, nil)
+	require.NoError(t, err)
+	require.True(t, all.IsValid(1), "a nil bitmap means all valid")
+	require.Equal(t, 0, all.NullCount())
+
+	_, err = NewColumn(make([]int, 65), Bitmap{0})
+	require.ErrorIs(t, err, ErrBitmapLength)
+}
+
+func TestAll(t *testing.T) {
+	values := make([]int, 130)
+	for i := range values {
+		values[i] = i
+	}
+	valid := NewBitmap(len(values))
+	for i := range 64 {
+		valid.Set(i, true) // a full word
+	}
+	valid.Set(100, true)
+	valid.Set(129, true)
+	valid[2] |= 1 << 5 // padding past the end
+
+	var got []int
+	for i, v := range (Column[int]{Values: values, Valid: valid}).All() {
+		require.Equal(t, i, v)
+		got = append(got, i)
+	}
+	require.Len(t, got, 66)
+	require.Equal(t, []int{63, 100, 129}, got[63:])
+
+	// Stopping early.
+	n := 0
+	for range (Column[int]{Values: values}).All() {
+		n++
+		if n == 70 {
+			break
+		}
+	}
+	require.Equal(t, 70, n)
+}
+
+func TestFloat64s(t *testing.T) {
+	nan := floatutils.Float64Unspecified
+	values := []float64{1.5, nan, -2, nan}
+	c := FromFloat64s(values)
+	require.Equal(t, 2, c.NullCount())
+	require.False(t, c.IsValid(1))
+	require.Same(t, &values[0], &c.Values[0], "FromFloat64s shares the slice")
+
+	back, err := ToFloat64s(c)
+	require.NoError(t, err)
+	require.Equal(t, 1.5, back[0])
+	require.True(t, math.IsNaN(back[1]))
+	require.NotSame(t, &values[0], &back[0])
+
+	// Data from elsewhere: null slots hold junk, a valid NaN collides.
+	foreign := Column[float64]{Values: []float64{1, 99, 3}, Valid: Bitmap{0b101}}
+	back, err = ToFloat64s(foreign)
+	require.NoError(t, err)
+	require.True(t, math.IsNaN(back[1]))
+	_, err = ToFloat64s(Column[float64]{Values: []float64{nan}})
+	require.ErrorIs(t, err, ErrSentinelCollision)
+
+	// FillNulls turns the column's own values into a sentinel slice.
+	FillNulls(foreign, nan)
+	require.Equal(t, 1.0, foreign.Values[0])
+	require.True(t, math.IsNaN(foreign.Values[1]))
+}
+
+func TestFloat32s(t *testing.T) {
+	c := FromFloat32s([]float32{floatutils.Float32Unspecified, 2})
+	require.Equal(t, Bitmap{0b10}, c.Valid)
+	back, err := ToFloat32s(c)
+	require.NoError(t, err)
+	require.True(t, floatutils.IsUnspecified(back[0]))
+	require.Equal(t, float32(2), back[1])
+}
+
+func TestIntValues(t *testing.T) {
+	u := intutils.IntValueUnspecified
+	values := []intutils.IntValue{u, 0, 7}
+	c := FromIntValues(values)
+	require.Equal(t, Bitmap{0b110}, c.Valid)
+	require.Same(t, &values[0], &c.Values[0])
+
+	back, err := ToIntValues(Column[intutils.IntValue]{Values: []intutils.IntValue{0, 0, 7}, Valid: c.Valid})
+	require.NoError(t, err)
+	require.Equal(t, values, back)
+	_, err = ToIntValues(Column[intutils.IntValue]{Values: []intutils.IntValue{u}})
+	require.ErrorIs(t, err, ErrSentinelCollision)
+}
+
+func TestInts(t *testing.T) {
+	values := []intutils.Int{intutils.IntNone, 0, 7}
+	c := FromInts(values)
+	require.Equal(t, Bitmap{0b110}, c.Valid)
+	require.Same(t, &values[0], &c.Values[0])
+
+	back, err := ToInts(Column[intutils.Int]{Values: []intutils.Int{0, 0, 7}, Valid: c.Valid})
+	require.NoError(t, err)
+	require.Equal(t, values, back)
+	_, err = ToInts(Column[intutils.Int]{Values: []intutils.Int{intutils.IntNone}})
+	require.ErrorIs(t, err, ErrSentinelCollision)
+}
+
+func TestStrs(t *testing.T) {
+	values := []stringutils.Str{"a", stringutils.StrNone, ""}
+	c := FromStrs(values)
+	require.Equal(t, Bitmap{0b101}, c.Valid)
+	require.Same(t, &values[0], &c.Values[0])
+
+	back, err := ToStrs(Column[stringutils.Str]{Values: []stringutils.Str{"a", "", ""}, Valid: c.Valid})
+	require.NoError(t, err)
+	require.Equal(t, values, back)
+	_, err = ToStrs(Column[stringutils.Str]{Values: []stringutils.Str{stringutils.StrNone}})
+	require.ErrorIs(t, err, ErrSentinelCollision)
+}
+
+func TestHalfFloats(t *testing.T) {
+	halves := []floatutils.Float16{floatutils.Float16From(1.5), floatutils.Float16Unspecified}
+	c := FromFloat16s(halves)
+	require.Equal(t, Bitmap{0b01}, c.Valid)
+	back, err := ToFloat16s(c)
+	require.NoError(t, err)
+	require.Equal(t, halves, back)
+	_, err = ToFloat16s(Column[floatutils.Float16]{Values: []floatutils.Float16{floatutils.Float16Unspecified}})
+	require.ErrorIs(t, err, ErrSentinelCollision)
+
+	bfloats := []floatutils.BFloat16{floatutils.BFloat16Unspecified, floatutils.BFloat16From(-2)}
+	bc := FromBFloat16s(bfloats)
+	require.Equal(t, Bitmap{0b10}, bc.Valid)
+	bback, err := ToBFloat16s(bc)
+	require.NoError(t, err)
+	require.Equal(t, bfloats, bback)
+	_, err = ToBFloat16s(Column[floatutils.BFloat16]{Values: []floatutils.BFloat16{floatutils.BFloat16Unspecified}})
+	require.ErrorIs(t, err, ErrSentinelCollision)
+}
+
+func TestStringValues(t *testing.T) {
+	u := stringutils.StringValueUnspecified
+	values := []stringutils.StringValue{"a", u, ""}
+	c := FromStringValues(values)
+	require.Equal(t, Bitmap{0b101}, c.Valid)
+
+	back, err := ToStringValues(Column[string]{Values: []string{"a", "", ""}, Valid: c.Valid})
+	require.NoError(t, err)
+	require.Equal(t, values, back)
+	_, err = ToStringValues(Column[string]{Values: []string{u}})
+	require.ErrorIs(t, err, ErrSentinelCollision)
+}
+
+func TestBooleanValues(t *testing.T) {
+	values := []boolutils.BooleanValue{boolutils.BooleanValueTrue(), boolutils.BooleanValueUnspecified, boolutils.BooleanValueFalse()}
+	c := FromBooleanValues(values)
+	require.Equal(t, []bool{true, false, false}, c.Values)
+	require.Equal(t, Bitmap{0b101}, c.Valid)
+	require.True(t, slices.Equal(values, ToBooleanValues(c)))
+	require.Equal(t, 3, boolutils.CountSpecified(ToBooleanValues(Column[bool]{Values: []bool{true, false, true}})))
+}
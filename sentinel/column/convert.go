@@ -0,0 +1,134 @@
+package column
+
+import (
+	"github.com/zodimo/go-sentinel-helper/sentinel/boolutils"
+	"github.com/zodimo/go-sentinel-helper/sentinel/floatutils"
+	"github.com/zodimo/go-sentinel-helper/sentinel/intutils"
+	"github.com/zodimo/go-sentinel-helper/sentinel/stringutils"
+)
+
+// FromFloat32s returns a column over values; NaN slots are null. The
+// column shares values; only the bitmap is allocated.
+func FromFloat32s(values []float32) Column[float32] {
+	return view(values, floatutils.SpecifiedMask(values))
+}
+
+// ToFloat32s returns a new slice with NaN in the null slots of c.
+func ToFloat32s(c Column[float32]) ([]float32, error) {
+	return toInBand(c, floatutils.Float32Unspecified, floatutils.IsSpecified[float32])
+}
+
+// FromFloat64s returns a column over values; NaN slots are null. The
+// column shares values; only the bitmap is allocated.
+func FromFloat64s(values []float64) Column[float64] {
+	return view(values, floatutils.SpecifiedMask(values))
+}
+
+// ToFloat64s returns a new slice with NaN in the null slots of c.
+func ToFloat64s(c Column[float64]) ([]float64, error) {
+	return toInBand(c, floatutils.Float64Unspecified, floatutils.IsSpecified[float64])
+}
+
+// FromIntValues returns a column over values; math.MinInt slots are
+// null. The column shares values; only the bitmap is allocated.
+func FromIntValues(values []intutils.IntValue) Column[intutils.IntValue] {
+	return view(values, intutils.SpecifiedMask(values))
+}
+
+// ToIntValues returns a new slice with IntValueUnspecified in the null
+// slots of c.
+func ToIntValues(c Column[intutils.IntValue]) ([]intutils.IntValue, error) {
+	return toInBand(c, intutils.IntValueUnspecified, intutils.IsSpecifiedIntValue)
+}
+
+// FromInts is FromIntValues for intutils.Int, which shares its
+// representation: IntNone slots are null and the column shares values.
+func FromInts(values []intutils.Int) Column[intutils.Int] {
+	return view(values, intutils.SpecifiedMask(values))
+}
+
+// ToInts returns a new slice with IntNone in the null slots of c.
+func ToInts(c Column[intutils.Int]) ([]intutils.Int, error) {
+	return toInBand(c, intutils.IntNone, intutils.IsSpecifiedInt)
+}
+
+// FromStringValues returns a column over values; StringValueUnspecified
+// slots are null. The column shares values; only the bitmap is allocated.
+func FromStringValues(values []stringutils.StringValue) Column[stringutils.StringValue] {
+	return view(values, maskOf(values, stringutils.IsSpecifiedString))
+}
+
+// ToStringValues returns a new slice with StringValueUnspecified in the
+// null slots of c.
+func ToStringValues(c Column[stringutils.StringValue]) ([]stringutils.StringValue, error) {
+	return toInBand(c, stringutils.StringValueUnspecified, stringutils.IsSpecifiedString)
+}
+
+// FromBooleanValues returns a bool column for values. BooleanValue is not
+// a bool, so the values are copied; null slots hold false.
+func FromBooleanValues(values []boolutils.BooleanValue) Column[bool] {
+	out := make([]bool, len(values))
+	for i, v := range values {
+		out[i] = v.Bool()
+	}
+	return view(out, boolutils.SpecifiedMask(values))
+}
+
+// ToBooleanValues returns the BooleanValues of c, Unspecified in its null
+// slots. Every bool is representable, so it cannot collide.
+func ToBooleanValues(c Column[bool]) []boolutils.BooleanValue {
+	out := make([]boolutils.BooleanValue, len(c.Values))
+	for i, v := range c.Values {
+		if c.IsValid(i) {
+			out[i] = boolutils.BooleanValueFrom(v)
+		}
+	}
+	return out
+}
+
+// FromStrs is FromStringValues for stringutils.Str, which shares its
+// representation: StrNone slots are null and the column shares values.
+func FromStrs(values []stringutils.Str) Column[stringutils.Str] {
+	return view(values, maskOf(values, stringutils.IsSpecifiedStr))
+}
+
+// ToStrs returns a new slice with StrNone in the null slots of c.
+func ToStrs(c Column[stringutils.Str]) ([]stringutils.Str, error) {
+	return toInBand(c, stringutils.StrNone, stringutils.IsSpecifiedStr)
+}
+
+// FromFloat16s returns a column over values; NaN slots are null. The
+// column shares values; only the bitmap is allocated.
+func FromFloat16s(values []floatutils.Float16) Column[floatutils.Float16] {
+	return view(values, maskOf(values, floatutils.IsSpecifiedFloat16))
+}
+
+// ToFloat16s returns a new slice with Float16Unspecified in the null slots
+// of c.
+func ToFloat16s(c Column[floatutils.Float16]) ([]floatutils.Float16, error) {
+	return toInBand(c, floatutils.Float16Unspecified, floatutils.IsSpecifiedFloat16)
+}
+
+// FromBFloat16s returns a column over values; NaN slots are null. The
+// column shares values; only the bitmap is allocated.
+func FromBFloat16s(values []floatutils.BFloat16) Column[floatutils.BFloat16] {
+	return view(values, maskOf(values, floatutils.IsSpecifiedBFloat16))
+}
+
+// ToBFloat16s returns a new slice with BFloat16Unspecified in the null
+// slots of c.
+func ToBFloat16s(c Column[floatutils.BFloat16]) ([]floatutils.BFloat16, error) {
+	return toInBand(c, floatutils.BFloat16Unspecified, floatutils.IsSpecifiedBFloat16)
+}
+
+// maskOf builds the Bitmap of the specified elements of values, for types
+// without a bulk SpecifiedMask.
+func maskOf[T any](values []T, isSpecified func(T) bool) Bitmap {
+	mask := NewBitmap(len(values))
+	for i, v := range values {
+		if isSpecified(v) {
+			mask[i/64] |= 1 << (i % 64)
+		}
+	}
+	return mask
+}
@@ -0,0 +1,182 @@
+package decimalutils
+
+import (
+	"math"
+	"math/big"
+)
+
+// RoundingMode selects how digits are discarded.
+type RoundingMode uint8
+
+const (
+	// RoundHalfEven rounds to nearest, ties to even ("banker's rounding").
+	RoundHalfEven RoundingMode = iota
+	// RoundHalfUp rounds to nearest, ties away from zero.
+	RoundHalfUp
+	// RoundHalfDown rounds to nearest, ties toward zero.
+	RoundHalfDown
+	// RoundDown truncates toward zero.
+	RoundDown
+	// RoundUp rounds away from zero.
+	RoundUp
+	// RoundFloor rounds toward negative infinity.
+	RoundFloor
+	// RoundCeiling rounds toward positive infinity.
+	RoundCeiling
+)
+
+// Arithmetic follows floatutils: an Unspecified operand yields
+// DecimalUnspecified and a nil error, as NaN propagates through float
+// arithmetic.
+
+// Neg returns -d.
+func (d Decimal) Neg() Decimal {
+	return Decimal{coef: -d.coef, exp: d.exp}
+}
+
+// Abs returns |d|.
+func (d Decimal) Abs() Decimal {
+	if d.coef < 0 {
+		return d.Neg()
+	}
+	return d
+}
+
+// Add returns d + other exactly, at the larger of the two scales.
+func (d Decimal) Add(other Decimal) (Decimal, error) {
+	if d.IsUnspecified() || other.IsUnspecified() {
+		return DecimalUnspecified, nil
+	}
+	a, b, scale, ok := align(d, other)
+	if !ok {
+		return DecimalUnspecified, ErrOverflow
+	}
+	sum, ok := addInt64(a, b)
+	if !ok {
+		return DecimalUnspecified, ErrOverflow
+	}
+	return New(sum, scale)
+}
+
+// Sub returns d - other exactly, at the larger of the two scales.
+func (d Decimal) Sub(other Decimal) (Decimal, error) {
+	return d.Add(other.Neg())
+}
+
+// Mul returns d × other exactly; the scale is the sum of both scales and
+// must not exceed MaxScale. Use MulRound to bound it.
+func (d Decimal) Mul(other Decimal) (Decimal, error) {
+	if d.IsUnspecified() || other.IsUnspecified() {
+		return DecimalUnspecified, nil
+	}
+	c, ok := mulInt64(d.coef, other.coef)
+	if !ok {
+		return DecimalUnspecified, ErrOverflow
+	}
+	return New(c, d.Scale()+other.Scale())
+}
+
+// MulRound returns d × other rounded to scale.
+func (d Decimal) MulRound(other Decimal, scale int, mode RoundingMode) (Decimal, error) {
+	if d.IsUnspecified() || other.IsUnspecified() {
+		return DecimalUnspecified, nil
+	}
+	n := new(big.Int).Mul(d.big(), other.big())
+	return fromBig(n, d.Scale()+other.Scale(), scale, mode)
+}
+
+// Quo returns d ÷ other rounded to scale.
+func (d Decimal) Quo(other Decimal, scale int, mode RoundingMode) (Decimal, error) {
+	if d.IsUnspecified() || other.IsUnspecified() {
+		return DecimalUnspecified, nil
+	}
+	if other.coef == 0 {
+		return DecimalUnspecified, ErrDivisionByZero
+	}
+	if scale < 0 || scale > MaxScale {
+		return DecimalUnspecified, ErrScale
+	}
+	// d/other = (cd × 10^so) / (co × 10^sd); scaled by 10^scale.
+	n := new(big.Int).Mul(d.big(), bigPow10(other.Scale()+scale))
+	m := new(big.Int).Mul(other.big(), bigPow10(d.Scale()))
+	return fromBigInt(divRound(n, m, mode), scale)
+}
+
+// Round returns d at the given scale, rounding with mode when digits are
+// dropped. Raising the scale is exact but may overflow.
+func (d Decimal) Round(scale int, mode RoundingMode) (Decimal, error) {
+	if d.IsUnspecified() {
+		return DecimalUnspecified, nil
+	}
+	return fromBig(d.big(), d.Scale(), scale, mode)
+}
+
+// Reduce returns d with trailing fractional zeros removed: 1.500 → 1.5.
+func (d Decimal) Reduce() Decimal {
+	for d.exp > 1 && d.coef%10 == 0 {
+		d.coef /= 10
+		d.exp--
+	}
+	return d
+}
+
+func (d Decimal) big() *big.Int {
+	return big.NewInt(d.coef)
+}
+
+func bigPow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// fromBig converts n × 10^-from to a Decimal at scale to.
+func fromBig(n *big.Int, from, to int, mode RoundingMode) (Decimal, error) {
+	if to < 0 || to > MaxScale {
+		return DecimalUnspecified, ErrScale
+	}
+	if to >= from {
+		return fromBigInt(n.Mul(n, bigPow10(to-from)), to)
+	}
+	return fromBigInt(divRound(n, bigPow10(from-to), mode), to)
+}
+
+func fromBigInt(c *big.Int, scale int) (Decimal, error) {
+	if !c.IsInt64() || c.Int64() == math.MinInt64 {
+		return DecimalUnspecified, ErrOverflow
+	}
+	return New(c.Int64(), scale)
+}
+
+// divRound returns n / d rounded with mode.
+func divRound(n, d *big.Int, mode RoundingMode) *big.Int {
+	q, r := new(big.Int).QuoRem(n, d, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+	negative := (n.Sign() < 0) != (d.Sign() < 0)
+	// half compares the discarded fraction with one half.
+	twice := new(big.Int).Lsh(r.Abs(r), 1)
+	half := twice.Cmp(new(big.Int).Abs(d))
+
+	var away bool
+	switch mode {
+	case RoundHalfEven:
+		away = half > 0 || half == 0 && new(big.Int).Abs(q).Bit(0) == 1
+	case RoundHalfUp:
+		away = half >= 0
+	case RoundHalfDown:
+		away = half > 0
+	case RoundUp:
+		away = true
+	case RoundFloor:
+		away = negative
+	case RoundCeiling:
+		away = !negative
+	}
+	if !away {
+		return q
+	}
+	if negative {
+		return q.Sub(q, big.NewInt(1))
+	}
+	return q.Add(q, big.NewInt(1))
+}
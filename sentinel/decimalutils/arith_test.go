@@ -0,0 +1,121 @@
+package decimalutils
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func requireDecimal(t *testing.T, want string, got Decimal, err error) {
+	t.Helper()
+	require.NoError(t, err)
+	require.Equal(t, want, got.Text())
+}
+
+func TestAddSub(t *testing.T) {
+	got, err := MustParse("0.1").Add(MustParse("0.2"))
+	requireDecimal(t, "0.3", got, err)
+	got, err = MustParse("1.25").Add(MustParse("-3"))
+	requireDecimal(t, "-1.75", got, err)
+	got, err = MustParse("10.00").Sub(MustParse("0.005"))
+	requireDecimal(t, "9.995", got, err)
+
+	_, err = MustNew(math.MaxInt64, 0).Add(MustNew(1, 0))
+	require.ErrorIs(t, err, ErrOverflow)
+	_, err = MustNew(math.MaxInt64, 0).Add(MustNew(1, 1))
+	require.ErrorIs(t, err, ErrOverflow, "aligning overflows")
+	_, err = MustNew(-math.MaxInt64, 0).Sub(MustNew(1, 0))
+	require.ErrorIs(t, err, ErrOverflow, "MinInt64 is reserved")
+}
+
+func TestMul(t *testing.T) {
+	got, err := MustParse("19.99").Mul(MustParse("3"))
+	requireDecimal(t, "59.97", got, err)
+	got, err = MustParse("-0.5").Mul(MustParse("0.5"))
+	requireDecimal(t, "-0.25", got, err)
+
+	_, err = MustNew(1, 10).Mul(MustNew(1, 10))
+	require.ErrorIs(t, err, ErrScale)
+	_, err = MustNew(math.MaxInt64/2+1, 0).Mul(MustNew(2, 0))
+	require.ErrorIs(t, err, ErrOverflow)
+
+	// VAT at 17.5% on 19.99, to the cent.
+	got, err = MustParse("19.99").MulRound(MustParse("0.175"), 2, RoundHalfUp)
+	requireDecimal(t, "3.50", got, err)
+	got, err = MustNew(1, 10).MulRound(MustNew(1, 10), 18, RoundHalfEven)
+	requireDecimal(t, "0.000000000000000000", got, err)
+}
+
+func TestQuo(t *testing.T) {
+	got, err := MustParse("10").Quo(MustParse("3"), 4, RoundHalfEven)
+	requireDecimal(t, "3.3333", got, err)
+	got, err = MustParse("2").Quo(MustParse("3"), 2, RoundDown)
+	requireDecimal(t, "0.66", got, err)
+	got, err = MustParse("-1").Quo(MustParse("0.08"), 1, RoundHalfEven)
+	requireDecimal(t, "-12.5", got, err)
+	got, err = MustParse("1.5").Quo(MustParse("-0.25"), 0, RoundHalfEven)
+	requireDecimal(t, "-6", got, err)
+
+	_, err = MustParse("1").Quo(MustParse("0.00"), 2, RoundHalfEven)
+	require.ErrorIs(t, err, ErrDivisionByZero)
+	_, err = MustParse("1").Quo(MustParse("3"), MaxScale+1, RoundHalfEven)
+	require.ErrorIs(t, err, ErrScale)
+	_, err = MustNew(math.MaxInt64, 0).Quo(MustNew(1, 1), 0, RoundHalfEven)
+	require.ErrorIs(t, err, ErrOverflow)
+}
+
+func TestRoundingModes(t *testing.T) {
+	tests := []struct {
+		in   string
+		want [7]string // by mode, in declaration order
+	}{
+		{"2.5", [7]string{"2", "3", "2", "2", "3", "2", "3"}},
+		{"3.5", [7]string{"4", "4", "3", "3", "4", "3", "4"}},
+		{"-2.5", [7]string{"-2", "-3", "-2", "-2", "-3", "-3", "-2"}},
+		{"2.51", [7]string{"3", "3", "3", "2", "3", "2", "3"}},
+		{"-2.49", [7]string{"-2", "-2", "-2", "-2", "-3", "-3", "-2"}},
+		{"7", [7]string{"7", "7", "7", "7", "7", "7", "7"}},
+	}
+	for _, tc := range tests {
+		for mode, want := range tc.want {
+			got, err := MustParse(tc.in).Round(0, RoundingMode(mode))
+			require.NoError(t, err)
+			require.Equal(t, want, got.Text(), "%s mode %d", tc.in, mode)
+		}
+	}
+
+	got, err := MustParse("1.5").Round(3, RoundHalfEven)
+	requireDecimal(t, "1.500", got, err)
+	_, err = MustNew(math.MaxInt64, 0).Round(1, RoundHalfEven)
+	require.ErrorIs(t, err, ErrOverflow)
+	_, err = MustParse("1").Round(-1, RoundHalfEven)
+	require.ErrorIs(t, err, ErrScale)
+}
+
+func TestUnspecifiedPropagates(t *testing.T) {
+	one := MustParse("1")
+	for _, op := range []func() (Decimal, error){
+		func() (Decimal, error) { return one.Add(DecimalUnspecified) },
+		func() (Decimal, error) { return DecimalUnspecified.Sub(one) },
+		func() (Decimal, error) { return one.Mul(DecimalUnspecified) },
+		func() (Decimal, error) { return one.MulRound(DecimalUnspecified, 2, RoundHalfEven) },
+		func() (Decimal, error) { return DecimalUnspecified.Quo(one, 2, RoundHalfEven) },
+		func() (Decimal, error) { return DecimalUnspecified.Round(2, RoundHalfEven) },
+	} {
+		got, err := op()
+		require.NoError(t, err)
+		require.True(t, got.IsUnspecified())
+	}
+	require.True(t, DecimalUnspecified.Neg().IsUnspecified())
+	require.True(t, DecimalUnspecified.Abs().IsUnspecified())
+	require.True(t, DecimalUnspecified.Reduce().IsUnspecified())
+}
+
+func TestNegAbsReduce(t *testing.T) {
+	require.Equal(t, "-1.50", MustParse("1.50").Neg().Text())
+	require.Equal(t, "1.50", MustParse("-1.50").Abs().Text())
+	require.Equal(t, "1.5", MustParse("1.500").Reduce().Text())
+	require.Equal(t, "100", MustParse("100.00").Reduce().Text())
+	require.Equal(t, "0", MustParse("0.000").Reduce().Text())
+}
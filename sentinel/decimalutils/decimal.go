@@ -0,0 +1,254 @@
+// Package decimalutils provides Decimal, a fixed-point sentinel type for
+// money and percentages, where a float epsilon is not acceptable.
+//
+// A Decimal is an int64 coefficient and a scale (digits after the point,
+// 0 to MaxScale): coefficient 150 at scale 2 is 1.50. Arithmetic is exact
+// and reports overflow instead of losing digits; operations that must
+// discard digits (Round, Quo, MulRound) take a RoundingMode.
+package decimalutils
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"strconv"
+)
+
+// MaxScale is the largest supported scale; 10^18 is the largest power of
+// ten that fits in an int64.
+const MaxScale = 18
+
+var pow10 = [MaxScale + 1]int64{
+	1, 1e1, 1e2, 1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9,
+	1e10, 1e11, 1e12, 1e13, 1e14, 1e15, 1e16, 1e17, 1e18,
+}
+
+var (
+	// ErrSyntax is returned by Parse for malformed input.
+	ErrSyntax = errors.New("decimalutils: invalid decimal syntax")
+	// ErrOverflow is returned when a coefficient would not fit in an int64.
+	// math.MinInt64 is excluded too, so that negation never overflows.
+	ErrOverflow = errors.New("decimalutils: coefficient overflows int64")
+	// ErrScale is returned for a scale outside 0..MaxScale.
+	ErrScale = errors.New("decimalutils: scale out of range")
+	// ErrDivisionByZero is returned by Quo for a zero divisor.
+	ErrDivisionByZero = errors.New("decimalutils: division by zero")
+)
+
+// Decimal is a fixed-point decimal number.
+// Uses Pattern 1-D from sentinel_pattern.md: the zero value is Unspecified.
+type Decimal struct {
+	coef int64
+	exp  uint8 // scale+1; 0 marks Unspecified
+}
+
+// 1. Sentinel - DecimalUnspecified
+var DecimalUnspecified = Decimal{}
+
+// New returns coef × 10^-scale.
+func New(coef int64, scale int) (Decimal, error) {
+	if scale < 0 || scale > MaxScale {
+		return DecimalUnspecified, ErrScale
+	}
+	if coef == math.MinInt64 {
+		return DecimalUnspecified, ErrOverflow
+	}
+	return Decimal{coef: coef, exp: uint8(scale) + 1}, nil
+}
+
+// MustNew is New that panics on error, for constants.
+func MustNew(coef int64, scale int) Decimal {
+	d, err := New(coef, scale)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// Coefficient returns the unscaled value; 0 if d is Unspecified.
+func (d Decimal) Coefficient() int64 {
+	return d.coef
+}
+
+// Scale returns the number of digits after the point; -1 if d is
+// Unspecified.
+func (d Decimal) Scale() int {
+	return int(d.exp) - 1
+}
+
+// Sign returns -1, 0 or +1; 0 if d is Unspecified.
+func (d Decimal) Sign() int {
+	switch {
+	case d.coef < 0:
+		return -1
+	case d.coef > 0:
+		return 1
+	}
+	return 0
+}
+
+// Float64 returns the nearest float64, or NaN (floatutils' Unspecified)
+// if d is Unspecified.
+func (d Decimal) Float64() float64 {
+	if d.IsUnspecified() {
+		return math.NaN()
+	}
+	f, _ := strconv.ParseFloat(d.Text(), 64)
+	return f
+}
+
+// 2. IsSpecified - predicate (method on value receiver)
+func (d Decimal) IsSpecified() bool {
+	return d.exp != 0
+}
+
+// IsUnspecified - convenience predicate
+func (d Decimal) IsUnspecified() bool {
+	return d.exp == 0
+}
+
+// SetUnspecified - stores DecimalUnspecified in the receiver
+func (d *Decimal) SetUnspecified() {
+	*d = DecimalUnspecified
+}
+
+// 3. TakeOrElse - returns d if specified, otherwise def
+func (d Decimal) TakeOrElse(def Decimal) Decimal {
+	if d.IsSpecified() {
+		return d
+	}
+	return def
+}
+
+// 4. Merge - prefers other if specified
+func (d Decimal) Merge(other Decimal) Decimal {
+	if other.IsSpecified() {
+		return other
+	}
+	return d
+}
+
+// 5. String - implements fmt.Stringer
+func (d Decimal) String() string {
+	if d.IsUnspecified() {
+		return "Decimal{Unspecified}"
+	}
+	return fmt.Sprintf("Decimal{%s}", d.Text())
+}
+
+// 6. Coalesce - N/A for value types
+
+// 7. Same - identical coefficient and scale: 1.50 is not the same as 1.5
+func (d Decimal) Same(other Decimal) bool {
+	return d == other
+}
+
+// 8. SemanticEqual - exact numeric equality across scales: 1.50 equals 1.5
+func (d Decimal) SemanticEqual(other Decimal) bool {
+	if d.IsUnspecified() || other.IsUnspecified() {
+		return d == other
+	}
+	return d.Cmp(other) == 0
+}
+
+// 9. Equal - equality check
+func (d Decimal) Equal(other Decimal) bool {
+	return d.Same(other) || d.SemanticEqual(other)
+}
+
+// 10. Copy - identity for immutable value types
+func (d Decimal) Copy() Decimal {
+	return d
+}
+
+// Package-level forms of the contract, for use as function values.
+func IsSpecifiedDecimal(d Decimal) bool      { return d.IsSpecified() }
+func TakeOrElseDecimal(a, b Decimal) Decimal { return a.TakeOrElse(b) }
+func MergeDecimal(a, b Decimal) Decimal      { return a.Merge(b) }
+func StringDecimal(d Decimal) string         { return d.String() }
+func SameDecimal(a, b Decimal) bool          { return a.Same(b) }
+func SemanticEqualDecimal(a, b Decimal) bool { return a.SemanticEqual(b) }
+func EqualDecimal(a, b Decimal) bool         { return a.Equal(b) }
+func CopyDecimal(d Decimal) Decimal          { return d.Copy() }
+
+// Cmp compares d and other numerically, returning -1, 0 or +1.
+// Unspecified sorts before every specified value and equals itself.
+func (d Decimal) Cmp(other Decimal) int {
+	switch {
+	case d.IsUnspecified() || other.IsUnspecified():
+		return cmpInt64(int64(min(d.exp, 1)), int64(min(other.exp, 1)))
+	case d.exp == other.exp:
+		return cmpInt64(d.coef, other.coef)
+	}
+	if a, b, _, ok := align(d, other); ok {
+		return cmpInt64(a, b)
+	}
+	a, b := d.big(), other.big()
+	scale := max(d.Scale(), other.Scale())
+	a.Mul(a, bigPow10(scale-d.Scale()))
+	b.Mul(b, bigPow10(scale-other.Scale()))
+	return a.Cmp(b)
+}
+
+func cmpInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// mulPow10 returns c × 10^n, or false on overflow.
+func mulPow10(c int64, n int) (int64, bool) {
+	if n == 0 {
+		return c, true
+	}
+	if n > MaxScale {
+		return 0, c == 0
+	}
+	return mulInt64(c, pow10[n])
+}
+
+// mulInt64 returns a × b, or false if the product is not a valid
+// coefficient.
+func mulInt64(a, b int64) (int64, bool) {
+	hi, lo := bits.Mul64(absUint64(a), absUint64(b))
+	if hi != 0 || lo > math.MaxInt64 {
+		return 0, false
+	}
+	if (a < 0) != (b < 0) {
+		return -int64(lo), true
+	}
+	return int64(lo), true
+}
+
+func absUint64(c int64) uint64 {
+	if c < 0 {
+		return uint64(-c)
+	}
+	return uint64(c)
+}
+
+// addInt64 returns a + b, or false if the sum is not a valid coefficient.
+func addInt64(a, b int64) (int64, bool) {
+	s := a + b
+	if (a > 0 && b > 0 && s < 0) || (a < 0 && b < 0 && s >= 0) || s == math.MinInt64 {
+		return 0, false
+	}
+	return s, true
+}
+
+// align rescales a and b to the larger of their scales.
+func align(a, b Decimal) (ca, cb int64, scale int, ok bool) {
+	scale = max(a.Scale(), b.Scale())
+	if ca, ok = mulPow10(a.coef, scale-a.Scale()); !ok {
+		return 0, 0, 0, false
+	}
+	if cb, ok = mulPow10(b.coef, scale-b.Scale()); !ok {
+		return 0, 0, 0, false
+	}
+	return ca, cb, scale, true
+}
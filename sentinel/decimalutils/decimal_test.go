@@ -0,0 +1,71 @@
+package decimalutils
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	d, err := New(150, 2)
+	require.NoError(t, err)
+	require.Equal(t, int64(150), d.Coefficient())
+	require.Equal(t, 2, d.Scale())
+
+	_, err = New(1, MaxScale+1)
+	require.ErrorIs(t, err, ErrScale)
+	_, err = New(1, -1)
+	require.ErrorIs(t, err, ErrScale)
+	_, err = New(math.MinInt64, 0)
+	require.ErrorIs(t, err, ErrOverflow)
+	require.Panics(t, func() { MustNew(1, 99) })
+}
+
+func TestContract(t *testing.T) {
+	var zero Decimal
+	require.True(t, zero.IsUnspecified())
+	require.Equal(t, -1, zero.Scale())
+	require.True(t, MustNew(0, 0).IsSpecified(), "0 is a specified amount")
+
+	a, b := MustParse("1.50"), MustParse("2")
+	require.Equal(t, a, zero.TakeOrElse(a))
+	require.Equal(t, a, TakeOrElseDecimal(a, b))
+	require.Equal(t, b, MergeDecimal(a, b))
+	require.Equal(t, a, MergeDecimal(a, DecimalUnspecified))
+	require.Equal(t, "Decimal{1.50}", StringDecimal(a))
+	require.Equal(t, "Decimal{Unspecified}", fmt.Sprint(zero))
+	require.Equal(t, a, CopyDecimal(a))
+}
+
+func TestEquality(t *testing.T) {
+	a, b := MustParse("1.50"), MustParse("1.5")
+	require.False(t, SameDecimal(a, b))
+	require.True(t, SemanticEqualDecimal(a, b))
+	require.True(t, EqualDecimal(a, b))
+	require.False(t, EqualDecimal(a, MustParse("1.51")))
+	require.True(t, EqualDecimal(DecimalUnspecified, Decimal{}))
+	require.False(t, EqualDecimal(DecimalUnspecified, MustParse("0")))
+	require.True(t, SemanticEqualDecimal(MustParse("-0.0"), MustParse("0")))
+
+	// Aligning would overflow int64; Cmp falls back to big.Int.
+	big1, small := MustNew(math.MaxInt64, 0), MustNew(1, MaxScale)
+	require.Equal(t, 1, big1.Cmp(small))
+	require.Equal(t, -1, small.Cmp(big1))
+	require.False(t, SemanticEqualDecimal(big1, small))
+}
+
+func TestCmp(t *testing.T) {
+	require.Equal(t, -1, MustParse("1.09").Cmp(MustParse("1.1")))
+	require.Equal(t, 1, MustParse("-1").Cmp(MustParse("-1.5")))
+	require.Equal(t, 0, MustParse("2.000").Cmp(MustParse("2")))
+	require.Equal(t, -1, DecimalUnspecified.Cmp(MustParse("-100")))
+	require.Equal(t, 1, MustParse("-100").Cmp(DecimalUnspecified))
+	require.Equal(t, 0, DecimalUnspecified.Cmp(DecimalUnspecified))
+}
+
+func TestFloat64(t *testing.T) {
+	require.Equal(t, 0.1, MustParse("0.10").Float64())
+	require.True(t, math.IsNaN(DecimalUnspecified.Float64()))
+}
@@ -0,0 +1,150 @@
+package decimalutils
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/zodimo/go-sentinel-helper/internal/sentinellog"
+)
+
+// Parse parses a plain decimal such as "12", "-0.50" or "+3.1400". The
+// scale is the number of fractional digits given, so trailing zeros are
+// kept. Exponents are not accepted. The empty string parses as
+// DecimalUnspecified.
+func Parse(s string) (Decimal, error) {
+	if s == "" {
+		return DecimalUnspecified, nil
+	}
+	body := strings.TrimLeft(s, "+-")
+	if len(s)-len(body) > 1 {
+		return DecimalUnspecified, ErrSyntax
+	}
+	intPart, frac, hasPoint := strings.Cut(body, ".")
+	if intPart == "" || hasPoint && frac == "" {
+		return DecimalUnspecified, ErrSyntax
+	}
+	if len(frac) > MaxScale {
+		return DecimalUnspecified, ErrScale
+	}
+	var coef int64
+	for _, r := range intPart + frac {
+		if r < '0' || r > '9' {
+			return DecimalUnspecified, ErrSyntax
+		}
+		var ok bool
+		if coef, ok = mulInt64(coef, 10); ok {
+			coef, ok = addInt64(coef, int64(r-'0'))
+		}
+		if !ok {
+			return DecimalUnspecified, ErrOverflow
+		}
+	}
+	if s[0] == '-' {
+		coef = -coef
+	}
+	return New(coef, len(frac))
+}
+
+// MustParse is Parse that panics on error, for constants.
+func MustParse(s string) Decimal {
+	d, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// Text formats d as a plain decimal with exactly Scale fractional digits;
+// Unspecified formats as the empty string. Parse(d.Text()) returns d.
+func (d Decimal) Text() string {
+	if d.IsUnspecified() {
+		return ""
+	}
+	digits := strconv.FormatUint(absUint64(d.coef), 10)
+	scale := d.Scale()
+	if pad := scale + 1 - len(digits); pad > 0 {
+		digits = strings.Repeat("0", pad) + digits
+	}
+	var b strings.Builder
+	if d.coef < 0 {
+		b.WriteByte('-')
+	}
+	b.WriteString(digits[:len(digits)-scale])
+	if scale > 0 {
+		b.WriteByte('.')
+		b.WriteString(digits[len(digits)-scale:])
+	}
+	return b.String()
+}
+
+// IsZero reports whether d is Unspecified, so that encoders honouring
+// `omitempty`/`omitzero` drop it. A specified 0 is kept.
+func (d Decimal) IsZero() bool {
+	return d.IsUnspecified()
+}
+
+// MarshalText implements encoding.TextMarshaler via Text.
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.Text()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// The empty string, "null" and "~" decode to Unspecified.
+func (d *Decimal) UnmarshalText(text []byte) error {
+	s := string(text)
+	if s == "null" || s == "~" {
+		s = ""
+	}
+	v, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. Decimals encode as JSON strings,
+// so no decoder turns them into floats; Unspecified encodes as null.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	if d.IsUnspecified() {
+		return []byte("null"), nil
+	}
+	return strconv.AppendQuote(nil, d.Text()), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It accepts a string, null
+// (Unspecified) and, for interoperability, a bare JSON number without
+// exponent.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*d = DecimalUnspecified
+		return nil
+	}
+	if bytes.HasPrefix(data, []byte(`"`)) {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		if s == "" {
+			return ErrSyntax
+		}
+		data = []byte(s)
+	}
+	v, err := Parse(string(data))
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// LogValue implements slog.LogValuer.
+func (d Decimal) LogValue() slog.Value {
+	if d.IsUnspecified() {
+		return sentinellog.Value()
+	}
+	return slog.StringValue(d.Text())
+}
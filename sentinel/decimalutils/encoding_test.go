@@ -0,0 +1,78 @@
+package decimalutils
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	for in, want := range map[string]string{
+		"12":                   "12",
+		"-0.50":                "-0.50",
+		"+3.1400":              "3.1400",
+		"007.5":                "7.5",
+		"-0":                   "0",
+		"9223372036854775807":  "9223372036854775807",
+		"0.000000000000000001": "0.000000000000000001",
+	} {
+		d, err := Parse(in)
+		require.NoError(t, err, in)
+		require.Equal(t, want, d.Text(), in)
+	}
+
+	d, err := Parse("")
+	require.NoError(t, err)
+	require.True(t, d.IsUnspecified())
+
+	for _, in := range []string{"-", ".5", "5.", "1e3", "1,5", "--1", "+-1", " 1", "0x10", "1.2.3"} {
+		_, err := Parse(in)
+		require.ErrorIs(t, err, ErrSyntax, in)
+	}
+	_, err = Parse("9223372036854775808")
+	require.ErrorIs(t, err, ErrOverflow)
+	_, err = Parse("-9223372036854775808")
+	require.ErrorIs(t, err, ErrOverflow, "MinInt64 is reserved")
+	_, err = Parse("0.0000000000000000001")
+	require.ErrorIs(t, err, ErrScale)
+}
+
+func TestTextRoundTrip(t *testing.T) {
+	for _, d := range []Decimal{DecimalUnspecified, MustNew(5, 3), MustNew(-5, 3), MustNew(123456, 0), MustNew(-1, 18)} {
+		text, err := d.MarshalText()
+		require.NoError(t, err)
+		var back Decimal
+		require.NoError(t, back.UnmarshalText(text))
+		require.True(t, d.Same(back), "%s", text)
+	}
+	var d Decimal = MustParse("1")
+	require.NoError(t, d.UnmarshalText([]byte("null")))
+	require.True(t, d.IsUnspecified())
+}
+
+func TestJSON(t *testing.T) {
+	type invoice struct {
+		Total    Decimal `json:"total"`
+		Discount Decimal `json:"discount,omitzero"`
+		Tax      Decimal `json:"tax"`
+	}
+	out, err := json.Marshal(invoice{Total: MustParse("10.50"), Discount: DecimalUnspecified, Tax: DecimalUnspecified})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"total":"10.50","tax":null}`, string(out))
+
+	var in invoice
+	require.NoError(t, json.Unmarshal([]byte(`{"total":"10.50","discount":2.25,"tax":null}`), &in))
+	require.True(t, in.Total.Same(MustParse("10.50")))
+	require.True(t, in.Discount.Same(MustParse("2.25")))
+	require.True(t, in.Tax.IsUnspecified())
+
+	require.Error(t, json.Unmarshal([]byte(`{"total":""}`), &in))
+	require.Error(t, json.Unmarshal([]byte(`{"total":1e3}`), &in))
+	require.Error(t, json.Unmarshal([]byte(`{"total":true}`), &in))
+}
+
+func TestLogValue(t *testing.T) {
+	require.Equal(t, "1.50", MustParse("1.50").LogValue().String())
+	require.Equal(t, "<unspecified>", DecimalUnspecified.LogValue().Resolve().String())
+}
@@ -0,0 +1,248 @@
+// Package diffutils computes sentinel patches between two values of a
+// struct type and applies them back.
+//
+// A patch is a value of the same type whose fields are Unspecified where
+// nothing changed and hold the updated value where something did, so that
+//
+//	Equal(Merge(base, Diff(base, updated)), updated)
+//
+// holds whenever no field of updated went from specified back to
+// Unspecified: an Unspecified patch field means "unchanged", so a patch
+// cannot clear a field.
+//
+// Fields are classified like envutils and yamlutils do: int, float and
+// string kinds, wrapperspb pointers, time.Duration, time.Time and 1-D value
+// types such as boolutils.BooleanValue use their sentinel; untagged nested
+// structs are walked field by field, except those with no exported fields,
+// an Equal method or a text or binary encoding (netip.Addr), which are
+// compared whole; every other exported field treats its zero value as
+// Unspecified. Unexported fields are left zero in patches and copied
+// from base by Merge.
+//
+// Types processed by cmd/sentineldiff register generated, reflection-free
+// functions with Register, which Diff and Merge then use instead.
+package diffutils
+
+import (
+	"bytes"
+	"reflect"
+	"sync"
+
+	"github.com/zodimo/go-sentinel-helper/internal/sentinelreflect"
+	"github.com/zodimo/go-sentinel-helper/sentinel/floatutils"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type fastPath[T any] struct {
+	diff  func(base, updated *T) *T
+	merge func(base, patch *T) *T
+}
+
+var fastPaths sync.Map // reflect.Type -> fastPath[T]
+
+// Register installs generated Diff and Merge functions for T.
+// It is called from the init functions written by cmd/sentineldiff.
+func Register[T any](diff func(base, updated *T) *T, merge func(base, patch *T) *T) {
+	fastPaths.Store(reflect.TypeFor[T](), fastPath[T]{diff: diff, merge: merge})
+}
+
+func lookup[T any]() (fastPath[T], bool) {
+	fp, ok := fastPaths.Load(reflect.TypeFor[T]())
+	if !ok {
+		return fastPath[T]{}, false
+	}
+	return fp.(fastPath[T]), true
+}
+
+// Unspecified returns a T whose every field holds its Unspecified sentinel.
+func Unspecified[T any]() *T {
+	out := new(T)
+	presetUnspecified(reflect.ValueOf(out).Elem())
+	return out
+}
+
+// Diff returns a patch that turns base into updated; T must be a struct
+// type. A nil base or updated is read as a T whose fields are all
+// Unspecified.
+func Diff[T any](base, updated *T) *T {
+	if fp, ok := lookup[T](); ok {
+		return fp.diff(base, updated)
+	}
+	return DiffReflect(base, updated)
+}
+
+// DiffReflect is Diff without the generated fast path.
+func DiffReflect[T any](base, updated *T) *T {
+	base, updated = orUnspecified(base), orUnspecified(updated)
+	patch := new(T)
+	diffStruct(reflect.ValueOf(patch).Elem(), reflect.ValueOf(base).Elem(), reflect.ValueOf(updated).Elem())
+	return patch
+}
+
+// Merge returns a fresh T holding base overlaid with every specified
+// field of patch. Neither input is modified.
+func Merge[T any](base, patch *T) *T {
+	if fp, ok := lookup[T](); ok {
+		return fp.merge(base, patch)
+	}
+	return MergeReflect(base, patch)
+}
+
+// MergeReflect is Merge without the generated fast path.
+func MergeReflect[T any](base, patch *T) *T {
+	base, patch = orUnspecified(base), orUnspecified(patch)
+	out := new(T)
+	mergeStruct(reflect.ValueOf(out).Elem(), reflect.ValueOf(base).Elem(), reflect.ValueOf(patch).Elem())
+	return out
+}
+
+// Equal reports whether a and b are equal field by field under the
+// Equal… semantics of each field's type.
+func Equal[T any](a, b *T) bool {
+	a, b = orUnspecified(a), orUnspecified(b)
+	return equalStruct(reflect.ValueOf(a).Elem(), reflect.ValueOf(b).Elem())
+}
+
+// Changed reports whether updated differs from base, given the type's
+// IsSpecified… and Equal… functions. Gaining or losing specified-ness is
+// always a change, even where Equal… coalesces Unspecified to a zero value.
+// Generated fast paths use it for every leaf field.
+func Changed[V any](base, updated V, isSpecified func(V) bool, equal func(a, b V) bool) bool {
+	if isSpecified(base) != isSpecified(updated) {
+		return true
+	}
+	return isSpecified(updated) && !equal(base, updated)
+}
+
+// SameStringValue reports whether two StringValue wrappers hold the same
+// bytes, as DiffReflect compares them. Generated fast paths use it, and
+// stringutils.SameString for strings, instead of the canonical Equal
+// functions, which would drop an NFC to NFD edit from the patch.
+func SameStringValue(a, b *wrapperspb.StringValue) bool {
+	return a.GetValue() == b.GetValue()
+}
+
+func orUnspecified[T any](v *T) *T {
+	if v == nil {
+		return Unspecified[T]()
+	}
+	return v
+}
+
+func presetUnspecified(sv reflect.Value) {
+	for i := 0; i < sv.NumField(); i++ {
+		if !sv.Type().Field(i).IsExported() {
+			continue
+		}
+		fv := sv.Field(i)
+		if sentinelreflect.IsNested(fv.Type()) {
+			presetUnspecified(fv)
+			continue
+		}
+		sentinelreflect.SetUnspecified(fv)
+	}
+}
+
+// leafEqual compares two specified values of a leaf or zero-convention type.
+func leafEqual(a, b reflect.Value) bool {
+	t := a.Type()
+	if sentinelreflect.IsWrapper(t) {
+		fa, fb := sentinelreflect.WrapperField(a), sentinelreflect.WrapperField(b)
+		if fa.Kind() == reflect.Slice {
+			return bytes.Equal(fa.Bytes(), fb.Bytes())
+		}
+		return fa.Equal(fb)
+	}
+	if m, ok := sentinelreflect.EqualMethod(t); ok {
+		return m.Func.Call([]reflect.Value{a, b})[0].Bool()
+	}
+	switch t.Kind() {
+	case reflect.Float32:
+		return floatutils.Equal(float32(a.Float()), float32(b.Float()))
+	case reflect.Float64:
+		return floatutils.Equal(a.Float(), b.Float())
+	}
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
+
+func fieldEqual(a, b reflect.Value) bool {
+	ua, ub := sentinelreflect.IsUnspecifiedOrZero(a), sentinelreflect.IsUnspecifiedOrZero(b)
+	if ua || ub {
+		return ua && ub
+	}
+	return leafEqual(a, b)
+}
+
+// copyValue returns v, or for wrappers a fresh wrapper holding a copy of
+// v's Value so patches and merge results never alias their inputs.
+func copyValue(v reflect.Value) reflect.Value {
+	if !sentinelreflect.IsWrapper(v.Type()) || sentinelreflect.IsUnspecified(v) {
+		return v
+	}
+	ptr, field := sentinelreflect.NewWrapper(v.Type())
+	src := sentinelreflect.WrapperField(v)
+	if src.Kind() == reflect.Slice {
+		field.SetBytes(append([]byte(nil), src.Bytes()...))
+	} else {
+		field.Set(src)
+	}
+	return ptr
+}
+
+func diffStruct(patch, base, updated reflect.Value) {
+	for i := 0; i < patch.NumField(); i++ {
+		if !patch.Type().Field(i).IsExported() {
+			continue
+		}
+		pf, bf, uf := patch.Field(i), base.Field(i), updated.Field(i)
+		if sentinelreflect.IsNested(pf.Type()) {
+			diffStruct(pf, bf, uf)
+			continue
+		}
+		if fieldEqual(bf, uf) {
+			sentinelreflect.SetUnspecifiedOrZero(pf)
+		} else {
+			pf.Set(copyValue(uf))
+		}
+	}
+}
+
+func mergeStruct(out, base, patch reflect.Value) {
+	// Start from base so unexported fields carry over, then overwrite
+	// every exported field.
+	out.Set(base)
+	for i := 0; i < out.NumField(); i++ {
+		if !out.Type().Field(i).IsExported() {
+			continue
+		}
+		of, bf, pf := out.Field(i), base.Field(i), patch.Field(i)
+		if sentinelreflect.IsNested(of.Type()) {
+			mergeStruct(of, bf, pf)
+			continue
+		}
+		if sentinelreflect.IsUnspecifiedOrZero(pf) {
+			of.Set(copyValue(bf))
+		} else {
+			of.Set(copyValue(pf))
+		}
+	}
+}
+
+func equalStruct(a, b reflect.Value) bool {
+	for i := 0; i < a.NumField(); i++ {
+		if !a.Type().Field(i).IsExported() {
+			continue
+		}
+		af, bf := a.Field(i), b.Field(i)
+		if sentinelreflect.IsNested(af.Type()) {
+			if !equalStruct(af, bf) {
+				return false
+			}
+			continue
+		}
+		if !fieldEqual(af, bf) {
+			return false
+		}
+	}
+	return true
+}
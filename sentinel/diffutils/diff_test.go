@@ -0,0 +1,202 @@
+package diffutils
+
+import (
+	"math"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zodimo/go-sentinel-helper/sentinel/boolutils"
+	"github.com/zodimo/go-sentinel-helper/sentinel/intutils"
+	"github.com/zodimo/go-sentinel-helper/sentinel/protobufwrapper"
+	"github.com/zodimo/go-sentinel-helper/sentinel/stringutils"
+	"github.com/zodimo/go-sentinel-helper/sentinel/timeutils"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type border struct {
+	Width float64
+	Color string
+}
+
+type widget struct {
+	Label   string
+	Count   int
+	Opacity float32
+	Visible boolutils.BooleanValue
+	Limit   *wrapperspb.Int32Value
+	Data    *wrapperspb.BytesValue
+	Tags    []string
+	Border  border
+	secret  int
+}
+
+func base() *widget {
+	return &widget{
+		Label:   "ok",
+		Count:   1,
+		Opacity: 0.5,
+		Visible: boolutils.BooleanValueTrue(),
+		Limit:   wrapperspb.Int32(10),
+		Data:    wrapperspb.Bytes([]byte{1}),
+		Tags:    []string{"a"},
+		Border:  border{Width: 1, Color: "red"},
+		secret:  7,
+	}
+}
+
+func TestUnspecified(t *testing.T) {
+	u := Unspecified[widget]()
+	require.Equal(t, stringutils.StringValueUnspecified, u.Label)
+	require.Equal(t, intutils.IntValueUnspecified, u.Count)
+	require.True(t, math.IsNaN(float64(u.Opacity)))
+	require.True(t, u.Visible.IsUnspecified())
+	require.Same(t, protobufwrapper.Int32ValueUnspecified, u.Limit)
+	require.Nil(t, u.Tags)
+	require.True(t, math.IsNaN(u.Border.Width))
+	require.Equal(t, stringutils.StringValueUnspecified, u.Border.Color)
+}
+
+func TestDiffUnchanged(t *testing.T) {
+	patch := Diff(base(), base())
+	require.True(t, Equal(Unspecified[widget](), patch))
+	require.Equal(t, 0, patch.secret)
+}
+
+func TestDiffChanged(t *testing.T) {
+	updated := base()
+	updated.Count = 2
+	updated.Visible = boolutils.BooleanValueFalse()
+	updated.Limit = wrapperspb.Int32(0)
+	updated.Tags = []string{"a", "b"}
+	updated.Border.Color = "blue"
+
+	patch := Diff(base(), updated)
+	require.Equal(t, stringutils.StringValueUnspecified, patch.Label)
+	require.Equal(t, 2, patch.Count)
+	require.True(t, math.IsNaN(float64(patch.Opacity)))
+	require.Equal(t, boolutils.BooleanValueFalse(), patch.Visible)
+	require.Equal(t, int32(0), patch.Limit.GetValue())
+	require.NotSame(t, updated.Limit, patch.Limit)
+	require.Same(t, protobufwrapper.BytesValueUnspecified, patch.Data)
+	require.Equal(t, []string{"a", "b"}, patch.Tags)
+	require.True(t, math.IsNaN(patch.Border.Width))
+	require.Equal(t, "blue", patch.Border.Color)
+
+	merged := Merge(base(), patch)
+	require.True(t, Equal(updated, merged))
+	require.Equal(t, 7, merged.secret, "unexported fields come from base")
+	require.NotSame(t, updated.Limit, merged.Limit)
+}
+
+func TestDiffWithinTolerance(t *testing.T) {
+	updated := base()
+	updated.Opacity += 1e-7
+	patch := Diff(base(), updated)
+	require.True(t, math.IsNaN(float64(patch.Opacity)))
+}
+
+func TestDiffFromUnspecified(t *testing.T) {
+	updated := base()
+	patch := Diff(nil, updated)
+	require.True(t, Equal(updated, patch))
+	require.True(t, Equal(updated, Merge(nil, patch)))
+
+	// A wrapper holding its zero value is a change from Unspecified.
+	b := Unspecified[widget]()
+	u := Unspecified[widget]()
+	u.Limit = wrapperspb.Int32(0)
+	require.Equal(t, int32(0), Diff(b, u).Limit.GetValue())
+	require.True(t, protobufwrapper.IsSpecifiedInt32Value(Diff(b, u).Limit))
+}
+
+func TestDiffCannotClear(t *testing.T) {
+	updated := base()
+	updated.Count = intutils.IntValueUnspecified
+	patch := Diff(base(), updated)
+	require.Equal(t, intutils.IntValueUnspecified, patch.Count)
+	require.Equal(t, 1, Merge(base(), patch).Count)
+}
+
+func TestMergeDoesNotAlias(t *testing.T) {
+	b := base()
+	merged := Merge(b, Unspecified[widget]())
+	require.True(t, Equal(b, merged))
+	require.NotSame(t, b.Limit, merged.Limit)
+	merged.Data.Value[0] = 9
+	require.Equal(t, byte(1), b.Data.Value[0])
+}
+
+type endpoint struct {
+	Listen netip.Addr
+	Since  time.Time
+}
+
+func TestDiffOpaqueStructs(t *testing.T) {
+	since := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	b := &endpoint{Listen: netip.MustParseAddr("127.0.0.1"), Since: since}
+	u := &endpoint{Listen: netip.IPv4Unspecified(), Since: since.Add(time.Hour)}
+
+	patch := Diff(b, u)
+	require.Equal(t, netip.IPv4Unspecified(), patch.Listen)
+	require.True(t, patch.Since.Equal(u.Since))
+	require.True(t, Equal(u, Merge(b, patch)))
+	require.False(t, Equal(b, u))
+
+	// time.Time compares with its Equal method, not field by field.
+	u = &endpoint{Listen: b.Listen, Since: since.In(time.FixedZone("X", 3600))}
+	require.True(t, Equal(b, u))
+	require.True(t, Equal(Unspecified[endpoint](), Diff(b, u)))
+}
+
+type schedule struct {
+	Timeout time.Duration
+	Since   time.Time
+}
+
+func TestDiffTimeSentinels(t *testing.T) {
+	u := Unspecified[schedule]()
+	require.Equal(t, timeutils.DurationUnspecified, u.Timeout)
+	require.True(t, timeutils.IsUnspecifiedTime(u.Since))
+
+	b := &schedule{Timeout: 5 * time.Second, Since: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)}
+	updated := &schedule{}
+	patch := Diff(b, updated)
+	require.Equal(t, time.Duration(0), patch.Timeout)
+	require.True(t, patch.Since.IsZero())
+
+	merged := Merge(b, patch)
+	require.Equal(t, time.Duration(0), merged.Timeout)
+	require.True(t, merged.Since.IsZero())
+	require.True(t, Equal(updated, merged))
+
+	require.True(t, Equal(u, Diff(b, b)))
+}
+
+func TestEqual(t *testing.T) {
+	require.True(t, Equal(base(), base()))
+	require.True(t, Equal[widget](nil, Unspecified[widget]()))
+
+	other := base()
+	other.Border.Width = 2
+	require.False(t, Equal(base(), other))
+
+	other = base()
+	other.Limit = nil
+	require.False(t, Equal(base(), other))
+	require.True(t, Equal(other, &widget{
+		Label: "ok", Count: 1, Opacity: 0.5, Visible: boolutils.BooleanValueTrue(),
+		Limit: protobufwrapper.Int32ValueUnspecified, Data: wrapperspb.Bytes([]byte{1}),
+		Tags: []string{"a"}, Border: border{Width: 1, Color: "red"},
+	}))
+}
+
+func TestChanged(t *testing.T) {
+	isSpecified, equal := protobufwrapper.IsSpecifiedInt32Value, protobufwrapper.EqualInt32Value
+	require.False(t, Changed(nil, protobufwrapper.Int32ValueUnspecified, isSpecified, equal))
+	require.True(t, Changed(nil, wrapperspb.Int32(0), isSpecified, equal))
+	require.True(t, Changed(wrapperspb.Int32(0), nil, isSpecified, equal))
+	require.False(t, Changed(wrapperspb.Int32(1), wrapperspb.Int32(1), isSpecified, equal))
+	require.True(t, Changed(wrapperspb.Int32(1), wrapperspb.Int32(2), isSpecified, equal))
+}
@@ -0,0 +1,40 @@
+// Package difftest holds fixture types whose diffutils fast path is
+// generated by cmd/sentineldiff, so tests can compare it with reflection.
+package difftest
+
+import (
+	"github.com/zodimo/go-sentinel-helper/sentinel/boolutils"
+	"github.com/zodimo/go-sentinel-helper/sentinel/enumutils"
+	"github.com/zodimo/go-sentinel-helper/sentinel/intutils"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+//go:generate go run ../../../../cmd/sentineldiff -type Settings
+
+type alignmentDef struct{}
+
+func (alignmentDef) TypeName() string { return "Alignment" }
+func (alignmentDef) Names() []string  { return []string{"Start", "Center", "End"} }
+
+type Alignment = enumutils.Enum[alignmentDef]
+
+type Scale float64
+
+type Theme struct {
+	Accent  string
+	Opacity float64
+	Bold    boolutils.BooleanValue
+}
+
+type Settings struct {
+	Name    string
+	Width   intutils.IntValue
+	Ratio   float32
+	Scale   Scale
+	Enabled boolutils.BooleanValue
+	Timeout *wrapperspb.Int64Value
+	Tag     *wrapperspb.BytesValue
+	Label   *wrapperspb.StringValue
+	Align   Alignment
+	Theme   Theme
+}
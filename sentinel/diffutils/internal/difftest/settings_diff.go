@@ -0,0 +1,157 @@
+// Code generated by sentineldiff; DO NOT EDIT.
+
+package difftest
+
+import (
+	"github.com/zodimo/go-sentinel-helper/sentinel/boolutils"
+	"github.com/zodimo/go-sentinel-helper/sentinel/diffutils"
+	"github.com/zodimo/go-sentinel-helper/sentinel/floatutils"
+	"github.com/zodimo/go-sentinel-helper/sentinel/intutils"
+	"github.com/zodimo/go-sentinel-helper/sentinel/protobufwrapper"
+	"github.com/zodimo/go-sentinel-helper/sentinel/stringutils"
+)
+
+func init() {
+	diffutils.Register(diffTheme, mergeTheme)
+	diffutils.Register(diffSettings, mergeSettings)
+}
+
+// diffTheme is the generated fast path of diffutils.Diff for Theme.
+func diffTheme(base, updated *Theme) *Theme {
+	if base == nil {
+		base = diffutils.Unspecified[Theme]()
+	}
+	if updated == nil {
+		updated = diffutils.Unspecified[Theme]()
+	}
+	patch := new(Theme)
+	diffThemeInto(patch, base, updated)
+	return patch
+}
+
+// mergeTheme is the generated fast path of diffutils.Merge for Theme.
+func mergeTheme(base, patch *Theme) *Theme {
+	if base == nil {
+		base = diffutils.Unspecified[Theme]()
+	}
+	if patch == nil {
+		patch = diffutils.Unspecified[Theme]()
+	}
+	out := new(Theme)
+	mergeThemeInto(out, base, patch)
+	return out
+}
+
+func diffThemeInto(p, b, u *Theme) {
+	if diffutils.Changed(b.Accent, u.Accent, stringutils.IsSpecifiedString, stringutils.SameString) {
+		p.Accent = u.Accent
+	} else {
+		p.Accent = stringutils.StringValueUnspecified
+	}
+	if diffutils.Changed(b.Opacity, u.Opacity, floatutils.IsSpecified[float64], floatutils.Equal[float64]) {
+		p.Opacity = u.Opacity
+	} else {
+		p.Opacity = floatutils.Float64Unspecified
+	}
+	if diffutils.Changed(b.Bold, u.Bold, boolutils.BooleanValue.IsSpecified, boolutils.BooleanValue.Equal) {
+		p.Bold = u.Bold
+	} else {
+		p.Bold = boolutils.BooleanValue{}
+	}
+}
+
+func mergeThemeInto(out, b, p *Theme) {
+	*out = *b
+	out.Accent = stringutils.MergeString(b.Accent, p.Accent)
+	out.Opacity = floatutils.Merge(b.Opacity, p.Opacity)
+	out.Bold = b.Bold.Merge(p.Bold)
+}
+
+// diffSettings is the generated fast path of diffutils.Diff for Settings.
+func diffSettings(base, updated *Settings) *Settings {
+	if base == nil {
+		base = diffutils.Unspecified[Settings]()
+	}
+	if updated == nil {
+		updated = diffutils.Unspecified[Settings]()
+	}
+	patch := new(Settings)
+	diffSettingsInto(patch, base, updated)
+	return patch
+}
+
+// mergeSettings is the generated fast path of diffutils.Merge for Settings.
+func mergeSettings(base, patch *Settings) *Settings {
+	if base == nil {
+		base = diffutils.Unspecified[Settings]()
+	}
+	if patch == nil {
+		patch = diffutils.Unspecified[Settings]()
+	}
+	out := new(Settings)
+	mergeSettingsInto(out, base, patch)
+	return out
+}
+
+func diffSettingsInto(p, b, u *Settings) {
+	if diffutils.Changed(b.Name, u.Name, stringutils.IsSpecifiedString, stringutils.SameString) {
+		p.Name = u.Name
+	} else {
+		p.Name = stringutils.StringValueUnspecified
+	}
+	if diffutils.Changed(b.Width, u.Width, intutils.IsSpecifiedIntValue, intutils.EqualIntValue) {
+		p.Width = u.Width
+	} else {
+		p.Width = intutils.IntValueUnspecified
+	}
+	if diffutils.Changed(b.Ratio, u.Ratio, floatutils.IsSpecified[float32], floatutils.Equal[float32]) {
+		p.Ratio = u.Ratio
+	} else {
+		p.Ratio = floatutils.Float32Unspecified
+	}
+	if diffutils.Changed(b.Scale, u.Scale, floatutils.IsSpecified[Scale], floatutils.Equal[Scale]) {
+		p.Scale = u.Scale
+	} else {
+		p.Scale = Scale(floatutils.Float64Unspecified)
+	}
+	if diffutils.Changed(b.Enabled, u.Enabled, boolutils.BooleanValue.IsSpecified, boolutils.BooleanValue.Equal) {
+		p.Enabled = u.Enabled
+	} else {
+		p.Enabled = boolutils.BooleanValue{}
+	}
+	if diffutils.Changed(b.Timeout, u.Timeout, protobufwrapper.IsSpecifiedInt64Value, protobufwrapper.EqualInt64Value) {
+		p.Timeout = protobufwrapper.CopyInt64Value(u.Timeout)
+	} else {
+		p.Timeout = protobufwrapper.Int64ValueUnspecified
+	}
+	if diffutils.Changed(b.Tag, u.Tag, protobufwrapper.IsSpecifiedBytesValue, protobufwrapper.EqualBytesValue) {
+		p.Tag = protobufwrapper.CopyBytesValue(u.Tag)
+	} else {
+		p.Tag = protobufwrapper.BytesValueUnspecified
+	}
+	if diffutils.Changed(b.Label, u.Label, protobufwrapper.IsSpecifiedStringValue, diffutils.SameStringValue) {
+		p.Label = protobufwrapper.CopyStringValue(u.Label)
+	} else {
+		p.Label = protobufwrapper.StringValueUnspecified
+	}
+	if diffutils.Changed(b.Align, u.Align, Alignment.IsSpecified, Alignment.Equal) {
+		p.Align = u.Align
+	} else {
+		p.Align = Alignment{}
+	}
+	diffThemeInto(&p.Theme, &b.Theme, &u.Theme)
+}
+
+func mergeSettingsInto(out, b, p *Settings) {
+	*out = *b
+	out.Name = stringutils.MergeString(b.Name, p.Name)
+	out.Width = intutils.MergeIntValue(b.Width, p.Width)
+	out.Ratio = floatutils.Merge(b.Ratio, p.Ratio)
+	out.Scale = floatutils.Merge(b.Scale, p.Scale)
+	out.Enabled = b.Enabled.Merge(p.Enabled)
+	out.Timeout = protobufwrapper.CopyInt64Value(protobufwrapper.MergeInt64Value(b.Timeout, p.Timeout))
+	out.Tag = protobufwrapper.CopyBytesValue(protobufwrapper.MergeBytesValue(b.Tag, p.Tag))
+	out.Label = protobufwrapper.CopyStringValue(protobufwrapper.MergeStringValue(b.Label, p.Label))
+	out.Align = b.Align.Merge(p.Align)
+	mergeThemeInto(&out.Theme, &b.Theme, &p.Theme)
+}
@@ -0,0 +1,103 @@
+package difftest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zodimo/go-sentinel-helper/sentinel/boolutils"
+	"github.com/zodimo/go-sentinel-helper/sentinel/diffutils"
+	"github.com/zodimo/go-sentinel-helper/sentinel/enumutils"
+	"github.com/zodimo/go-sentinel-helper/sentinel/intutils"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func settings() *Settings {
+	return &Settings{
+		Name:    "caf\u00e9",
+		Width:   640,
+		Ratio:   1.5,
+		Scale:   2,
+		Enabled: boolutils.BooleanValueTrue(),
+		Timeout: wrapperspb.Int64(30),
+		Tag:     wrapperspb.Bytes([]byte("v1")),
+		Label:   wrapperspb.String("caf\u00e9"),
+		Align:   enumutils.MustParse[alignmentDef]("Start"),
+		Theme:   Theme{Accent: "teal", Opacity: 0.8, Bold: boolutils.BooleanValueFalse()},
+	}
+}
+
+func edits() map[string]func(*Settings) {
+	return map[string]func(*Settings){
+		"none":    func(*Settings) {},
+		"name":    func(s *Settings) { s.Name = "" },
+		"width":   func(s *Settings) { s.Width = 0 },
+		"ratio":   func(s *Settings) { s.Ratio = 2 },
+		"scale":   func(s *Settings) { s.Scale = 1 },
+		"enabled": func(s *Settings) { s.Enabled = boolutils.BooleanValueFalse() },
+		"timeout": func(s *Settings) { s.Timeout = wrapperspb.Int64(0) },
+		"tag":     func(s *Settings) { s.Tag = wrapperspb.Bytes(nil) },
+		"nfd":     func(s *Settings) { s.Name = "cafe\u0301"; s.Label = wrapperspb.String("cafe\u0301") },
+		"align":   func(s *Settings) { s.Align = enumutils.MustParse[alignmentDef]("End") },
+		"nested":  func(s *Settings) { s.Theme.Bold = boolutils.BooleanValueTrue(); s.Theme.Opacity = 1 },
+		"all": func(s *Settings) {
+			s.Name, s.Width, s.Ratio = "x", 1, 0
+			s.Timeout = wrapperspb.Int64(1)
+			s.Theme.Accent = "red"
+		},
+	}
+}
+
+func TestGeneratedMatchesReflection(t *testing.T) {
+	for name, edit := range edits() {
+		t.Run(name, func(t *testing.T) {
+			base, updated := settings(), settings()
+			edit(updated)
+
+			fast := diffutils.Diff(base, updated)
+			slow := diffutils.DiffReflect(base, updated)
+			require.True(t, diffutils.Equal(slow, fast))
+
+			require.True(t, diffutils.Equal(updated, diffutils.Merge(base, fast)))
+			require.True(t, diffutils.Equal(updated, diffutils.MergeReflect(base, slow)))
+		})
+	}
+}
+
+func TestGeneratedNilAndUnspecified(t *testing.T) {
+	updated := settings()
+	require.True(t, diffutils.Equal(updated, diffutils.Diff(nil, updated)))
+	require.True(t, diffutils.Equal(diffutils.Unspecified[Settings](), diffutils.Diff(updated, nil)))
+	require.True(t, diffutils.Equal(updated, diffutils.Merge(updated, nil)))
+	require.True(t, diffutils.Equal(updated, diffutils.Merge(nil, updated)))
+
+	patch := diffutils.Diff(settings(), settings())
+	require.Equal(t, intutils.IntValueUnspecified, patch.Width)
+	require.True(t, patch.Align.IsUnspecified())
+	require.True(t, patch.Theme.Bold.IsUnspecified())
+}
+
+func TestGeneratedMergeDoesNotAlias(t *testing.T) {
+	base, patch := settings(), settings()
+	merged := diffutils.Merge(base, patch)
+	require.NotSame(t, patch.Timeout, merged.Timeout)
+	require.NotSame(t, base.Tag, merged.Tag)
+}
+
+func BenchmarkDiff(b *testing.B) {
+	base, updated := settings(), settings()
+	updated.Width = 1024
+	updated.Theme.Accent = "red"
+
+	b.Run("generated", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = diffutils.Diff(base, updated)
+		}
+	})
+	b.Run("reflect", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = diffutils.DiffReflect(base, updated)
+		}
+	})
+}
@@ -0,0 +1,232 @@
+package enumutils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrUnknownName is returned by Parse for a name that is not part of the enum.
+var ErrUnknownName = errors.New("enumutils: unknown name")
+
+// Definition describes an enum family. Implement it on an empty struct:
+//
+//	type alignmentDef struct{}
+//	var alignmentNames = []string{"Start", "Center", "End"}
+//	func (alignmentDef) TypeName() string { return "Alignment" }
+//	func (alignmentDef) Names() []string  { return alignmentNames }
+//
+// Names should return a package-level slice so that lookups do not allocate.
+// The order of Names is the declaration order and must never change.
+type Definition interface {
+	comparable
+	TypeName() string
+	Names() []string
+}
+
+// Enum is a type-safe enum value of family D.
+// Uses Pattern 1-D from sentinel_pattern.md: the zero value is Unspecified and,
+// since the ordinal is unexported, `Enum[D](3)` does not compile.
+type Enum[D Definition] struct {
+	ordinal int
+}
+
+// 1. Sentinel - the zero value
+func Unspecified[D Definition]() Enum[D] {
+	return Enum[D]{}
+}
+
+// Constructors (the ONLY way to create specified Enums)
+
+// Parse returns the value of D named name.
+func Parse[D Definition](name string) (Enum[D], error) {
+	var def D
+	for i, n := range def.Names() {
+		if n == name {
+			return Enum[D]{ordinal: i + 1}, nil
+		}
+	}
+	return Enum[D]{}, fmt.Errorf("%w %q for %s", ErrUnknownName, name, def.TypeName())
+}
+
+// MustParse is like Parse but panics on an unknown name.
+// Intended for package-level declarations of the named values.
+func MustParse[D Definition](name string) Enum[D] {
+	e, err := Parse[D](name)
+	if err != nil {
+		panic(err)
+	}
+	return e
+}
+
+// Values returns every specified value of D in declaration order.
+func Values[D Definition]() []Enum[D] {
+	var def D
+	values := make([]Enum[D], len(def.Names()))
+	for i := range values {
+		values[i] = Enum[D]{ordinal: i + 1}
+	}
+	return values
+}
+
+// 2. IsSpecified - predicate (method on value receiver)
+func (e Enum[D]) IsSpecified() bool {
+	return e.ordinal != 0
+}
+
+// IsUnspecified - convenience predicate
+func (e Enum[D]) IsUnspecified() bool {
+	return e.ordinal == 0
+}
+
+// SetUnspecified - stores Unspecified in the receiver
+func (e *Enum[D]) SetUnspecified() {
+	*e = Unspecified[D]()
+}
+
+// Name returns the declared name, or "Unspecified".
+func (e Enum[D]) Name() string {
+	if e.ordinal == 0 {
+		return "Unspecified"
+	}
+	var def D
+	return def.Names()[e.ordinal-1]
+}
+
+// Index returns the position of e in Names, or -1 if Unspecified.
+func (e Enum[D]) Index() int {
+	return e.ordinal - 1
+}
+
+// 3. TakeOrElse - 2-param fallback (method on value receiver)
+func (e Enum[D]) TakeOrElse(def Enum[D]) Enum[D] {
+	if e.IsSpecified() {
+		return e
+	}
+	return def
+}
+
+// 4. Merge - composition merge (method on value receiver for atomic types)
+func (e Enum[D]) Merge(other Enum[D]) Enum[D] {
+	if other.IsSpecified() {
+		return other
+	}
+	return e
+}
+
+// 5. String - stringification (method on value receiver)
+func (e Enum[D]) String() string {
+	var def D
+	return def.TypeName() + "{" + e.Name() + "}"
+}
+
+// 6. Coalesce - N/A for value types (no nil possible)
+
+// 7. Same - identity (for value types, uses == operator)
+func (e Enum[D]) Same(other Enum[D]) bool {
+	return e.ordinal == other.ordinal
+}
+
+// 8. SemanticEqual - semantic equality (for value types, same as Same)
+func (e Enum[D]) SemanticEqual(other Enum[D]) bool {
+	return e.ordinal == other.ordinal
+}
+
+// 9. Equal - equality check (combines Same and SemanticEqual)
+func (e Enum[D]) Equal(other Enum[D]) bool {
+	return e.ordinal == other.ordinal
+}
+
+// 10. Copy - identity for immutable value types (just returns the value)
+func (e Enum[D]) Copy() Enum[D] {
+	return e
+}
+
+// Package-level forms of the contract, for use as function values.
+
+func IsSpecified[D Definition](e Enum[D]) bool      { return e.IsSpecified() }
+func TakeOrElse[D Definition](a, b Enum[D]) Enum[D] { return a.TakeOrElse(b) }
+func Merge[D Definition](a, b Enum[D]) Enum[D]      { return a.Merge(b) }
+func String[D Definition](e Enum[D]) string         { return e.String() }
+func Same[D Definition](a, b Enum[D]) bool          { return a.Same(b) }
+func SemanticEqual[D Definition](a, b Enum[D]) bool { return a.SemanticEqual(b) }
+func Equal[D Definition](a, b Enum[D]) bool         { return a.Equal(b) }
+func Copy[D Definition](e Enum[D]) Enum[D]          { return e.Copy() }
+
+// Format implements fmt.Formatter for custom formatting
+func (e Enum[D]) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v', 's':
+		fmt.Fprint(f, e.String())
+	default:
+		fmt.Fprintf(f, "%%!%c(Enum=%s)", verb, e.String())
+	}
+}
+
+// IsZero reports whether e is Unspecified, for `omitempty`/`omitzero` encoders.
+func (e Enum[D]) IsZero() bool {
+	return e.IsUnspecified()
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// Unspecified encodes as the empty string.
+func (e Enum[D]) MarshalText() ([]byte, error) {
+	if e.IsUnspecified() {
+		return []byte{}, nil
+	}
+	return []byte(e.Name()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// The empty string decodes to Unspecified.
+func (e *Enum[D]) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*e = Enum[D]{}
+		return nil
+	}
+	v, err := Parse[D](string(text))
+	if err != nil {
+		return err
+	}
+	*e = v
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. Unspecified encodes as null.
+func (e Enum[D]) MarshalJSON() ([]byte, error) {
+	if e.IsUnspecified() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(e.Name())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. null decodes to Unspecified.
+func (e *Enum[D]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*e = Enum[D]{}
+		return nil
+	}
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+	return e.UnmarshalText([]byte(name))
+}
+
+// MarshalYAML implements yaml.Marshaler. Unspecified encodes as null.
+func (e Enum[D]) MarshalYAML() (any, error) {
+	if e.IsUnspecified() {
+		return nil, nil
+	}
+	return e.Name(), nil
+}
+
+// UnmarshalYAML implements the yaml.v2/v3 obsolete unmarshaler interface.
+// Null nodes never reach it, so a null or absent key leaves Unspecified.
+func (e *Enum[D]) UnmarshalYAML(unmarshal func(any) error) error {
+	var name string
+	if err := unmarshal(&name); err != nil {
+		return err
+	}
+	return e.UnmarshalText([]byte(name))
+}
@@ -0,0 +1,224 @@
+package enumutils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+type alignmentDef struct{}
+
+var alignmentNames = []string{"Start", "Center", "End"}
+
+func (alignmentDef) TypeName() string { return "Alignment" }
+func (alignmentDef) Names() []string  { return alignmentNames }
+
+type Alignment = Enum[alignmentDef]
+
+var (
+	AlignmentUnspecified = Unspecified[alignmentDef]()
+	AlignmentStart       = MustParse[alignmentDef]("Start")
+	AlignmentCenter      = MustParse[alignmentDef]("Center")
+	AlignmentEnd         = MustParse[alignmentDef]("End")
+)
+
+type weightDef struct{}
+
+func (weightDef) TypeName() string { return "FontWeight" }
+func (weightDef) Names() []string  { return []string{"Start"} }
+
+func TestEnum_ZeroValueIsUnspecified(t *testing.T) {
+	var a Alignment
+	if a.IsSpecified() {
+		t.Error("zero value should be unspecified")
+	}
+	if !a.Equal(AlignmentUnspecified) {
+		t.Error("zero value should equal AlignmentUnspecified")
+	}
+	if a.Index() != -1 {
+		t.Errorf("Index() = %d, want -1", a.Index())
+	}
+}
+
+func TestEnum_SetUnspecified(t *testing.T) {
+	a := AlignmentCenter
+	a.SetUnspecified()
+	if !a.IsUnspecified() {
+		t.Errorf("SetUnspecified left %v", a)
+	}
+}
+
+func TestEnum_Constructors(t *testing.T) {
+	if !AlignmentCenter.IsSpecified() {
+		t.Error("Center should be specified")
+	}
+	if got := AlignmentCenter.Name(); got != "Center" {
+		t.Errorf("Name() = %q, want Center", got)
+	}
+	if got := AlignmentEnd.Index(); got != 2 {
+		t.Errorf("Index() = %d, want 2", got)
+	}
+
+	values := Values[alignmentDef]()
+	if len(values) != 3 || !values[0].Equal(AlignmentStart) || !values[2].Equal(AlignmentEnd) {
+		t.Errorf("Values() = %v", values)
+	}
+
+	if _, err := Parse[alignmentDef]("Justify"); !errors.Is(err, ErrUnknownName) {
+		t.Errorf("Parse(Justify) error = %v, want ErrUnknownName", err)
+	}
+	if _, err := Parse[alignmentDef]("Unspecified"); err == nil {
+		t.Error("Parse(Unspecified) should fail")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("MustParse should panic on unknown name")
+		}
+	}()
+	MustParse[alignmentDef]("Justify")
+}
+
+func TestEnum_TakeOrElseAndMerge(t *testing.T) {
+	tests := []struct {
+		name string
+		got  Alignment
+		want Alignment
+	}{
+		{"TakeOrElse specified", AlignmentStart.TakeOrElse(AlignmentEnd), AlignmentStart},
+		{"TakeOrElse unspecified", AlignmentUnspecified.TakeOrElse(AlignmentEnd), AlignmentEnd},
+		{"Merge specified incoming", AlignmentStart.Merge(AlignmentEnd), AlignmentEnd},
+		{"Merge unspecified incoming", AlignmentStart.Merge(AlignmentUnspecified), AlignmentStart},
+		{"Merge both unspecified", AlignmentUnspecified.Merge(AlignmentUnspecified), AlignmentUnspecified},
+		{"package TakeOrElse", TakeOrElse(AlignmentUnspecified, AlignmentCenter), AlignmentCenter},
+		{"package Merge", Merge(AlignmentCenter, AlignmentUnspecified), AlignmentCenter},
+		{"package Copy", Copy(AlignmentEnd), AlignmentEnd},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.got.Equal(tt.want) {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnum_String(t *testing.T) {
+	tests := []struct {
+		val  Alignment
+		want string
+	}{
+		{AlignmentStart, "Alignment{Start}"},
+		{AlignmentCenter, "Alignment{Center}"},
+		{AlignmentUnspecified, "Alignment{Unspecified}"},
+	}
+	for _, tt := range tests {
+		if got := tt.val.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+		if got := String(tt.val); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+		if got := fmt.Sprintf("%v", tt.val); got != tt.want {
+			t.Errorf("Sprintf(%%v) = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestEnum_Equality(t *testing.T) {
+	if !Same(AlignmentStart, MustParse[alignmentDef]("Start")) {
+		t.Error("Same(Start, Start) should be true")
+	}
+	if SemanticEqual(AlignmentStart, AlignmentEnd) {
+		t.Error("SemanticEqual(Start, End) should be false")
+	}
+	if Equal(AlignmentStart, AlignmentUnspecified) {
+		t.Error("Equal(Start, Unspecified) should be false")
+	}
+	if !IsSpecified(AlignmentEnd) {
+		t.Error("IsSpecified(End) should be true")
+	}
+
+	// Values of different families share ordinals but are distinct types;
+	// comparing them does not compile, which is the point of the type parameter.
+	w := MustParse[weightDef]("Start")
+	if w.String() != "FontWeight{Start}" {
+		t.Errorf("String() = %q", w.String())
+	}
+}
+
+func TestEnum_JSON(t *testing.T) {
+	type style struct {
+		Align  Alignment `json:"align"`
+		Other  Alignment `json:"other"`
+		Hidden Alignment `json:"hidden,omitzero"`
+	}
+
+	out, err := json.Marshal(style{Align: AlignmentCenter})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"align":"Center","other":null}`; string(out) != want {
+		t.Errorf("Marshal = %s, want %s", out, want)
+	}
+
+	var in style
+	if err := json.Unmarshal([]byte(`{"align":"End","other":null}`), &in); err != nil {
+		t.Fatal(err)
+	}
+	if !in.Align.Equal(AlignmentEnd) || in.Other.IsSpecified() || in.Hidden.IsSpecified() {
+		t.Errorf("Unmarshal = %+v", in)
+	}
+
+	if err := json.Unmarshal([]byte(`{"align":"Justify"}`), &in); !errors.Is(err, ErrUnknownName) {
+		t.Errorf("Unmarshal unknown name error = %v", err)
+	}
+	if err := json.Unmarshal([]byte(`{"align":1}`), &in); err == nil {
+		t.Error("Unmarshal of a number should fail")
+	}
+}
+
+func TestEnum_Text(t *testing.T) {
+	text, err := AlignmentStart.MarshalText()
+	if err != nil || string(text) != "Start" {
+		t.Errorf("MarshalText = %q, %v", text, err)
+	}
+	text, _ = AlignmentUnspecified.MarshalText()
+	if len(text) != 0 {
+		t.Errorf("MarshalText(Unspecified) = %q, want empty", text)
+	}
+
+	a := AlignmentEnd
+	if err := a.UnmarshalText(nil); err != nil || a.IsSpecified() {
+		t.Errorf("UnmarshalText(empty) = %v, %v", a, err)
+	}
+	if err := a.UnmarshalText([]byte("Center")); err != nil || !a.Equal(AlignmentCenter) {
+		t.Errorf("UnmarshalText(Center) = %v, %v", a, err)
+	}
+}
+
+func TestEnum_YAML(t *testing.T) {
+	type style struct {
+		Align Alignment `yaml:"align,omitempty"`
+		Other Alignment `yaml:"other,omitempty"`
+	}
+
+	out, err := yaml.Marshal(style{Align: AlignmentEnd})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "align: End\n"; string(out) != want {
+		t.Errorf("yaml.Marshal = %q, want %q", out, want)
+	}
+
+	var in style
+	if err := yaml.Unmarshal([]byte("align: Start\nother: ~\n"), &in); err != nil {
+		t.Fatal(err)
+	}
+	if !in.Align.Equal(AlignmentStart) || in.Other.IsSpecified() {
+		t.Errorf("yaml.Unmarshal = %+v", in)
+	}
+}
@@ -0,0 +1,21 @@
+package enumutils
+
+import (
+	"log/slog"
+
+	"github.com/zodimo/go-sentinel-helper/internal/sentinellog"
+)
+
+// LogValue implements slog.LogValuer: the member name when specified,
+// otherwise "<unspecified>".
+func (e Enum[D]) LogValue() slog.Value {
+	if e.IsUnspecified() {
+		return sentinellog.Value()
+	}
+	return slog.StringValue(e.Name())
+}
+
+// Attr returns a slog.Attr for e.
+func Attr[D Definition](key string, e Enum[D]) slog.Attr {
+	return slog.Attr{Key: key, Value: e.LogValue()}
+}
@@ -0,0 +1,14 @@
+package enumutils
+
+import (
+	"testing"
+)
+
+func TestEnum_LogValue(t *testing.T) {
+	if got := Attr("a", MustParse[alignmentDef]("End")).Value.Resolve().String(); got != "End" {
+		t.Errorf("Attr(End) = %q, want End", got)
+	}
+	if got := Unspecified[alignmentDef]().LogValue().Resolve().String(); got != "<unspecified>" {
+		t.Errorf("Unspecified logs as %q, want <unspecified>", got)
+	}
+}
@@ -0,0 +1,220 @@
+package envutils
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/zodimo/go-sentinel-helper/internal/sentinelreflect"
+	"github.com/zodimo/go-sentinel-helper/sentinel/boolutils"
+)
+
+// TagName is the struct tag read by Bind.
+const TagName = "env"
+
+// ErrInvalidTarget is returned when Bind is not given a non-nil pointer to a struct.
+var ErrInvalidTarget = errors.New("envutils: target must be a non-nil pointer to a struct")
+
+// ErrSentinelCollision is wrapped in the ParseError for a value that parses
+// to its field's Unspecified sentinel, such as math.MinInt for an int or
+// NaN for a float;
+// leaving the variable unset is the way to get Unspecified.
+var ErrSentinelCollision = errors.New("envutils: value collides with the Unspecified sentinel")
+
+// LookupFunc reports the value of an environment variable and whether it is set.
+// os.LookupEnv satisfies it.
+type LookupFunc func(key string) (string, bool)
+
+// ParseError reports a variable whose value could not be parsed into its field.
+type ParseError struct {
+	Name  string
+	Value string
+	Type  reflect.Type
+	Err   error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("envutils: cannot parse %s=%q as %s: %v", e.Name, e.Value, e.Type, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// UnsupportedTypeError reports a tagged field whose type Bind cannot populate.
+type UnsupportedTypeError struct {
+	Name string
+	Type reflect.Type
+}
+
+func (e *UnsupportedTypeError) Error() string {
+	return fmt.Sprintf("envutils: unsupported type %s for %s", e.Type, e.Name)
+}
+
+type bindOptions struct {
+	lookup LookupFunc
+	prefix string
+}
+
+// BindOption configures Bind.
+type BindOption func(*bindOptions)
+
+// WithLookup replaces os.LookupEnv, so tests never touch the real environment.
+func WithLookup(lookup LookupFunc) BindOption {
+	return func(o *bindOptions) {
+		o.lookup = lookup
+	}
+}
+
+// WithPrefix prepends prefix to every variable name before lookup.
+func WithPrefix(prefix string) BindOption {
+	return func(o *bindOptions) {
+		o.prefix = prefix
+	}
+}
+
+// MapLookup returns a LookupFunc backed by m.
+func MapLookup(m map[string]string) LookupFunc {
+	return func(key string) (string, bool) {
+		v, ok := m[key]
+		return v, ok
+	}
+}
+
+// Bind populates the struct pointed to by dst from environment variables.
+//
+// Every field tagged `env:"NAME"` is assigned from NAME. A variable that is
+// absent leaves the field at its type's Unspecified sentinel; a variable that
+// is set, even to the empty string, is parsed into a specified value.
+// Untagged struct fields are bound recursively.
+func Bind(dst any, opts ...BindOption) error {
+	o := bindOptions{lookup: os.LookupEnv}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return ErrInvalidTarget
+	}
+	return bindStruct(rv.Elem(), &o)
+}
+
+func bindStruct(sv reflect.Value, o *bindOptions) error {
+	st := sv.Type()
+	for i := 0; i < st.NumField(); i++ {
+		sf := st.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		fv := sv.Field(i)
+
+		name, ok := sf.Tag.Lookup(TagName)
+		if !ok || name == "" {
+			if sentinelreflect.IsNested(sf.Type) {
+				if err := bindStruct(fv, o); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if name == "-" {
+			continue
+		}
+
+		name = o.prefix + name
+		if !sentinelreflect.IsLeaf(sf.Type) {
+			return &UnsupportedTypeError{Name: name, Type: sf.Type}
+		}
+		raw, present := o.lookup(name)
+		if !present {
+			sentinelreflect.SetUnspecified(fv)
+			continue
+		}
+		if err := setValue(fv, name, raw); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+
+func setValue(fv reflect.Value, name, raw string) error {
+	t := fv.Type()
+	var err error
+	switch {
+	case t == sentinelreflect.BooleanValueType:
+		var b bool
+		if b, err = strconv.ParseBool(raw); err == nil {
+			fv.Set(reflect.ValueOf(boolutils.BooleanValueFrom(b)))
+		}
+	case reflect.PointerTo(t).Implements(textUnmarshalerType):
+		err = fv.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(raw))
+	case sentinelreflect.IsWrapper(t):
+		ptr, field := sentinelreflect.NewWrapper(t)
+		if err = parseScalar(field, raw); err == nil {
+			fv.Set(ptr)
+		}
+	default:
+		if err = parseScalar(fv, raw); err == nil {
+			if err = sentinelreflect.FromUntrusted(fv); err != nil {
+				err = fmt.Errorf("%w: %w", ErrSentinelCollision, err)
+			}
+		}
+	}
+	if err != nil {
+		return &ParseError{Name: name, Value: raw, Type: t, Err: err}
+	}
+	return nil
+}
+
+// parseScalar parses raw according to the kind of v and stores the result.
+// A time.Duration takes time.ParseDuration syntax such as "1m30s".
+func parseScalar(v reflect.Value, raw string) error {
+	switch v.Kind() {
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		if v.Type() == sentinelreflect.DurationType {
+			d, err := time.ParseDuration(raw)
+			if err != nil {
+				return err
+			}
+			v.SetInt(int64(d))
+			return nil
+		}
+		i, err := strconv.ParseInt(raw, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(raw, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Slice:
+		v.SetBytes([]byte(raw))
+	default:
+		return fmt.Errorf("unsupported kind %s", v.Kind())
+	}
+	return nil
+}
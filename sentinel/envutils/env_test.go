@@ -0,0 +1,205 @@
+package envutils
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zodimo/go-sentinel-helper/sentinel/boolutils"
+	"github.com/zodimo/go-sentinel-helper/sentinel/enumutils"
+	"github.com/zodimo/go-sentinel-helper/sentinel/floatutils"
+	"github.com/zodimo/go-sentinel-helper/sentinel/intutils"
+	"github.com/zodimo/go-sentinel-helper/sentinel/protobufwrapper"
+	"github.com/zodimo/go-sentinel-helper/sentinel/stringutils"
+	"github.com/zodimo/go-sentinel-helper/sentinel/timeutils"
+	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type serverConfig struct {
+	Port    intutils.IntValue       `env:"PORT"`
+	Ratio   float64                 `env:"RATIO"`
+	Scale   float32                 `env:"SCALE"`
+	Name    stringutils.StringValue `env:"NAME"`
+	Debug   boolutils.BooleanValue  `env:"DEBUG"`
+	Retries *wrapperspb.Int32Value  `env:"RETRIES"`
+	Label   *wrapperspb.StringValue `env:"LABEL"`
+	Limit   *wrapperspb.UInt64Value `env:"LIMIT"`
+	Enabled *wrapperspb.BoolValue   `env:"ENABLED"`
+	Weight  *wrapperspb.DoubleValue `env:"WEIGHT"`
+	Timeout time.Duration           `env:"TIMEOUT"`
+	Ignored string                  `env:"-"`
+	TLS     tlsConfig
+	Secret  []byte
+	hidden  intutils.IntValue `env:"HIDDEN"`
+}
+
+type tlsConfig struct {
+	Cert stringutils.StringValue `env:"TLS_CERT"`
+}
+
+func TestBind_AllAbsentAreUnspecified(t *testing.T) {
+	var cfg serverConfig
+	err := Bind(&cfg, WithLookup(MapLookup(nil)))
+	require.NoError(t, err)
+
+	require.True(t, intutils.IsUnspecifiedIntValue(cfg.Port))
+	require.True(t, floatutils.IsUnspecified(cfg.Ratio))
+	require.True(t, floatutils.IsUnspecified(cfg.Scale))
+	require.True(t, stringutils.IsUnspecifiedString(cfg.Name))
+	require.True(t, cfg.Debug.IsUnspecified())
+	require.Same(t, protobufwrapper.Int32ValueUnspecified, cfg.Retries)
+	require.Same(t, protobufwrapper.StringValueUnspecified, cfg.Label)
+	require.Same(t, protobufwrapper.UInt64ValueUnspecified, cfg.Limit)
+	require.Same(t, protobufwrapper.BoolValueUnspecified, cfg.Enabled)
+	require.Same(t, protobufwrapper.DoubleValueUnspecified, cfg.Weight)
+	require.Equal(t, timeutils.DurationUnspecified, cfg.Timeout)
+	require.True(t, stringutils.IsUnspecifiedString(cfg.TLS.Cert))
+	require.Equal(t, "", cfg.Ignored)
+	require.Equal(t, 0, cfg.hidden)
+}
+
+func TestBind_PresentValues(t *testing.T) {
+	env := map[string]string{
+		"PORT":     "8080",
+		"RATIO":    "0.25",
+		"SCALE":    "1.5",
+		"NAME":     "api",
+		"DEBUG":    "false",
+		"RETRIES":  "0",
+		"LABEL":    "",
+		"LIMIT":    "18446744073709551615",
+		"ENABLED":  "true",
+		"WEIGHT":   "2.5",
+		"TIMEOUT":  "0s",
+		"TLS_CERT": "/etc/cert.pem",
+	}
+	var cfg serverConfig
+	err := Bind(&cfg, WithLookup(MapLookup(env)))
+	require.NoError(t, err)
+
+	require.Equal(t, 8080, cfg.Port)
+	require.Equal(t, 0.25, cfg.Ratio)
+	require.Equal(t, float32(1.5), cfg.Scale)
+	require.Equal(t, "api", cfg.Name)
+	require.True(t, cfg.Debug.IsFalse())
+	require.True(t, protobufwrapper.IsSpecifiedInt32Value(cfg.Retries))
+	require.Equal(t, int32(0), cfg.Retries.Value)
+	require.True(t, protobufwrapper.IsSpecifiedStringValue(cfg.Label))
+	require.Equal(t, "", cfg.Label.Value)
+	require.Equal(t, uint64(18446744073709551615), cfg.Limit.Value)
+	require.True(t, cfg.Enabled.Value)
+	require.Equal(t, 2.5, cfg.Weight.Value)
+	require.Equal(t, time.Duration(0), cfg.Timeout)
+	require.Equal(t, "/etc/cert.pem", cfg.TLS.Cert)
+}
+
+func TestBind_EmptyStringIsSpecified(t *testing.T) {
+	var cfg tlsConfig
+	err := Bind(&cfg, WithLookup(MapLookup(map[string]string{"TLS_CERT": ""})))
+	require.NoError(t, err)
+	require.True(t, stringutils.IsSpecifiedString(cfg.Cert))
+	require.Equal(t, "", cfg.Cert)
+}
+
+func TestBind_ParseErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+	}{
+		{"int", map[string]string{"PORT": "eighty"}},
+		{"empty int", map[string]string{"PORT": ""}},
+		{"float", map[string]string{"RATIO": "half"}},
+		{"bool", map[string]string{"DEBUG": "maybe"}},
+		{"int32 overflow", map[string]string{"RETRIES": "4294967296"}},
+		{"uint64 negative", map[string]string{"LIMIT": "-1"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var cfg serverConfig
+			err := Bind(&cfg, WithLookup(MapLookup(tc.env)))
+			require.Error(t, err)
+
+			var pe *ParseError
+			require.True(t, errors.As(err, &pe))
+			for k := range tc.env {
+				require.Equal(t, k, pe.Name)
+				require.Contains(t, err.Error(), k)
+			}
+		})
+	}
+}
+
+func TestBind_SentinelCollision(t *testing.T) {
+	for name, env := range map[string]map[string]string{
+		"int":      {"PORT": strconv.Itoa(math.MinInt)},
+		"string":   {"NAME": stringutils.StringValueUnspecified},
+		"duration": {"TIMEOUT": timeutils.DurationUnspecified.String()},
+		"float64":  {"RATIO": "NaN"},
+		"float32":  {"SCALE": "nan"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var cfg serverConfig
+			err := Bind(&cfg, WithLookup(MapLookup(env)))
+			require.ErrorIs(t, err, ErrSentinelCollision)
+		})
+	}
+}
+
+func TestBind_Prefix(t *testing.T) {
+	var cfg tlsConfig
+	env := map[string]string{"APP_TLS_CERT": "cert", "TLS_CERT": "wrong"}
+	err := Bind(&cfg, WithLookup(MapLookup(env)), WithPrefix("APP_"))
+	require.NoError(t, err)
+	require.Equal(t, "cert", cfg.Cert)
+}
+
+func TestBind_InvalidTarget(t *testing.T) {
+	var cfg serverConfig
+	require.ErrorIs(t, Bind(cfg), ErrInvalidTarget)
+	require.ErrorIs(t, Bind((*serverConfig)(nil)), ErrInvalidTarget)
+	n := 1
+	require.ErrorIs(t, Bind(&n), ErrInvalidTarget)
+}
+
+func TestBind_UnsupportedType(t *testing.T) {
+	var cfg struct {
+		Items []string `env:"ITEMS"`
+	}
+	err := Bind(&cfg, WithLookup(MapLookup(nil)))
+	var ue *UnsupportedTypeError
+	require.True(t, errors.As(err, &ue))
+	require.Equal(t, "ITEMS", ue.Name)
+}
+
+func TestBind_OSLookupByDefault(t *testing.T) {
+	t.Setenv("ENVUTILS_TEST_NAME", "from-os")
+	var cfg struct {
+		Name stringutils.StringValue `env:"ENVUTILS_TEST_NAME"`
+	}
+	require.NoError(t, Bind(&cfg))
+	require.Equal(t, "from-os", cfg.Name)
+}
+
+type directionDef struct{}
+
+func (directionDef) TypeName() string { return "Direction" }
+func (directionDef) Names() []string  { return []string{"Ltr", "Rtl"} }
+
+func TestBind_TextUnmarshaler(t *testing.T) {
+	var cfg struct {
+		Dir  enumutils.Enum[directionDef] `env:"DIR"`
+		Next enumutils.Enum[directionDef] `env:"NEXT"`
+	}
+	err := Bind(&cfg, WithLookup(MapLookup(map[string]string{"DIR": "Rtl"})))
+	require.NoError(t, err)
+	require.Equal(t, "Rtl", cfg.Dir.Name())
+	require.True(t, cfg.Next.IsUnspecified())
+
+	err = Bind(&cfg, WithLookup(MapLookup(map[string]string{"DIR": "Up"})))
+	var pe *ParseError
+	require.True(t, errors.As(err, &pe))
+	require.Equal(t, "DIR", pe.Name)
+}
@@ -0,0 +1,144 @@
+package floatutils
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+
+	"github.com/zodimo/go-sentinel-helper/internal/sentinellog"
+)
+
+// BFloat16 is a bfloat16 value stored in its bit pattern: the upper half
+// of a float32. It keeps the float32 range with 8 bits of precision, so
+// it suits wide-ranging values where Float16 would overflow.
+//
+// Every NaN is Unspecified and BFloat16From always produces the
+// canonical quiet NaN BFloat16Unspecified, the upper half of
+// Float32Unspecified. The zero value is a specified +0.
+type BFloat16 uint16
+
+// 1. Sentinel - BFloat16Unspecified (canonical quiet NaN)
+const BFloat16Unspecified BFloat16 = 0x7FC0
+
+// BFloat16From rounds f to the nearest BFloat16, ties to even. Values
+// beyond the range become ±Inf and any NaN becomes BFloat16Unspecified.
+func BFloat16From(f float32) BFloat16 {
+	b := math.Float32bits(f)
+	if b&0x7FFFFFFF > 0x7F800000 {
+		return BFloat16Unspecified
+	}
+	// Adding just under half an ulp, plus the parity bit for ties, rounds
+	// the truncation; a carry rolls into the exponent, up to Inf.
+	b += 0x7FFF + b>>16&1
+	return BFloat16(b >> 16)
+}
+
+// Float32 widens b exactly; every NaN becomes Float32Unspecified.
+func (b BFloat16) Float32() float32 {
+	if b.IsUnspecified() {
+		return Float32Unspecified
+	}
+	return math.Float32frombits(uint32(b) << 16)
+}
+
+// 2. IsSpecified - predicate (method on value receiver)
+func (b BFloat16) IsSpecified() bool {
+	return b&0x7FFF <= 0x7F80
+}
+
+// IsUnspecified - convenience predicate
+func (b BFloat16) IsUnspecified() bool {
+	return !b.IsSpecified()
+}
+
+// SetUnspecified - stores BFloat16Unspecified in the receiver
+func (b *BFloat16) SetUnspecified() {
+	*b = BFloat16Unspecified
+}
+
+// 3. TakeOrElse - returns b if specified, otherwise def
+func (b BFloat16) TakeOrElse(def BFloat16) BFloat16 {
+	if b.IsSpecified() {
+		return b
+	}
+	return def
+}
+
+// 4. Merge - prefers other if specified
+func (b BFloat16) Merge(other BFloat16) BFloat16 {
+	if other.IsSpecified() {
+		return other
+	}
+	return b
+}
+
+// 5. String - implements fmt.Stringer
+func (b BFloat16) String() string {
+	if b.IsUnspecified() {
+		return "BFloat16{Unspecified}"
+	}
+	return fmt.Sprintf("BFloat16{%v}", b.Float32())
+}
+
+// 6. Coalesce - N/A for value types
+
+// 7. Same - identity with float semantics: every NaN is the same
+// Unspecified value and +0 is the same as -0
+func (b BFloat16) Same(other BFloat16) bool {
+	return Same(b.Float32(), other.Float32())
+}
+
+// 8. SemanticEqual - equality of the widened values within
+// Float32EqualityThreshold
+func (b BFloat16) SemanticEqual(other BFloat16) bool {
+	return SemanticEqual(b.Float32(), other.Float32())
+}
+
+// 9. Equal - equality check
+func (b BFloat16) Equal(other BFloat16) bool {
+	return b.Same(other) || b.SemanticEqual(other)
+}
+
+// 10. Copy - identity for immutable value types
+func (b BFloat16) Copy() BFloat16 {
+	return b
+}
+
+// Package-level forms of the contract, for use as function values.
+func IsSpecifiedBFloat16(b BFloat16) bool       { return b.IsSpecified() }
+func TakeOrElseBFloat16(a, b BFloat16) BFloat16 { return a.TakeOrElse(b) }
+func MergeBFloat16(a, b BFloat16) BFloat16      { return a.Merge(b) }
+func StringBFloat16(b BFloat16) string          { return b.String() }
+func SameBFloat16(a, b BFloat16) bool           { return a.Same(b) }
+func SemanticEqualBFloat16(a, b BFloat16) bool  { return a.SemanticEqual(b) }
+func EqualBFloat16(a, b BFloat16) bool          { return a.Equal(b) }
+func CopyBFloat16(b BFloat16) BFloat16          { return b.Copy() }
+
+// LogValue implements slog.LogValuer; see LogValue.
+func (b BFloat16) LogValue() slog.Value {
+	if b.IsUnspecified() {
+		return sentinellog.Value()
+	}
+	return slog.Float64Value(float64(b.Float32()))
+}
+
+// BFloat16sFromFloat32s converts src into dst with BFloat16From; see
+// Float16sFromFloat32s.
+func BFloat16sFromFloat32s(dst []BFloat16, src []float32) int {
+	n := min(len(dst), len(src))
+	dst, src = dst[:n], src[:n]
+	for i, f := range src {
+		dst[i] = BFloat16From(f)
+	}
+	return n
+}
+
+// Float32sFromBFloat16s converts src into dst with BFloat16.Float32.
+func Float32sFromBFloat16s(dst []float32, src []BFloat16) int {
+	n := min(len(dst), len(src))
+	dst, src = dst[:n], src[:n]
+	for i, b := range src {
+		dst[i] = b.Float32()
+	}
+	return n
+}
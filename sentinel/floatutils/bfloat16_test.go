@@ -0,0 +1,95 @@
+package floatutils
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBFloat16From(t *testing.T) {
+	tests := []struct {
+		in   float32
+		want BFloat16
+	}{
+		{0, 0x0000},
+		{1, 0x3F80},
+		{-2, 0xC000},
+		{math.Float32frombits(0x3F808000), 0x3F80}, // tie to even, down
+		{math.Float32frombits(0x3F818000), 0x3F82}, // tie to even, up
+		{math.Float32frombits(0x3F808001), 0x3F81}, // above the tie
+		{math.MaxFloat32, 0x7F80},                  // rounds up to Inf
+		{float32(math.Inf(-1)), 0xFF80},
+		{1e-40, 0x0001}, // subnormals keep their upper bits
+	}
+	for _, tt := range tests {
+		if got := BFloat16From(tt.in); got != tt.want {
+			t.Errorf("BFloat16From(%v) = %#04x, want %#04x", tt.in, uint16(got), uint16(tt.want))
+		}
+	}
+	for _, nan := range []uint32{0x7FC00000, 0xFFC00000, 0x7F800001, 0x7FFFFFFF} {
+		if got := BFloat16From(math.Float32frombits(nan)); got != BFloat16Unspecified {
+			t.Errorf("BFloat16From(NaN %#08x) = %#04x, want BFloat16Unspecified", nan, uint16(got))
+		}
+	}
+	if BFloat16From(Float32Unspecified) != BFloat16(math.Float32bits(Float32Unspecified)>>16) {
+		t.Error("BFloat16Unspecified is not the upper half of Float32Unspecified")
+	}
+}
+
+func TestBFloat16RoundTrip(t *testing.T) {
+	for i := 0; i <= math.MaxUint16; i++ {
+		b := BFloat16(i)
+		f := b.Float32()
+		want := b
+		if b.IsUnspecified() {
+			want = BFloat16Unspecified
+		}
+		if got := BFloat16From(f); got != want {
+			t.Fatalf("%#04x: round trip through %v gave %#04x", i, f, uint16(got))
+		}
+	}
+}
+
+func TestBFloat16Contract(t *testing.T) {
+	one := BFloat16From(1)
+	if BFloat16Unspecified.IsSpecified() || !IsSpecifiedBFloat16(0) || !BFloat16(0x7F80).IsSpecified() {
+		t.Error("IsSpecified mismatch")
+	}
+	if TakeOrElseBFloat16(BFloat16Unspecified, one) != one || MergeBFloat16(one, 0xFFC1) != one {
+		t.Error("TakeOrElse/Merge mismatch")
+	}
+	if got := StringBFloat16(BFloat16From(1.5)); got != "BFloat16{1.5}" {
+		t.Errorf("String = %q", got)
+	}
+	if got := BFloat16Unspecified.String(); got != "BFloat16{Unspecified}" {
+		t.Errorf("String = %q", got)
+	}
+	if !SameBFloat16(BFloat16Unspecified, 0xFFC1) || SameBFloat16(one, 0x3F81) || !EqualBFloat16(0x0000, 0x8000) {
+		t.Error("Same/Equal mismatch")
+	}
+	if !SemanticEqualBFloat16(one, one) || CopyBFloat16(one) != one {
+		t.Error("SemanticEqual/Copy mismatch")
+	}
+	if got := BFloat16Unspecified.LogValue().Resolve().String(); got != "<unspecified>" {
+		t.Errorf("LogValue = %q", got)
+	}
+}
+
+func TestBFloat16Bulk(t *testing.T) {
+	dst := make([]BFloat16, 4)
+	if n := BFloat16sFromFloat32s(dst, []float32{1, Float32Unspecified}); n != 2 {
+		t.Fatalf("n = %d, want 2", n)
+	}
+	back := make([]float32, 2)
+	Float32sFromBFloat16s(back, dst)
+	if back[0] != 1 || !IsUnspecified(back[1]) {
+		t.Errorf("back = %v", back)
+	}
+}
+
+func BenchmarkBFloat16sFromFloat32s(b *testing.B) {
+	dst := make([]BFloat16, len(benchFloat32s))
+	b.SetBytes(int64(4 * len(benchFloat32s)))
+	for b.Loop() {
+		BFloat16sFromFloat32s(dst, benchFloat32s)
+	}
+}
@@ -0,0 +1,76 @@
+package floatutils
+
+import "github.com/zodimo/go-sentinel-helper/internal/bulk"
+
+// Bulk operations apply the contract to whole columns; internal/bulk
+// describes the shape of their loops and masks.
+
+// CountSpecified returns the number of specified values.
+func CountSpecified[T Float](values []T) int {
+	var n uint64
+	for _, v := range values {
+		n += bulk.B2U(v == v)
+	}
+	return int(n)
+}
+
+// FillUnspecified replaces every Unspecified element of dst with def, in
+// place: the bulk form of TakeOrElse.
+func FillUnspecified[T Float](dst []T, def T) {
+	for i, v := range dst {
+		if v != v {
+			v = def
+		}
+		dst[i] = v
+	}
+}
+
+// MergeInto merges src into dst element by element, in place: the bulk
+// form of Merge, so specified elements of src win. Like copy it handles
+// min(len(dst), len(src)) elements and returns that count.
+func MergeInto[T Float](dst, src []T) int {
+	n := min(len(dst), len(src))
+	dst, src = dst[:n], src[:n]
+	for i, v := range src {
+		d := dst[i]
+		if v == v {
+			d = v
+		}
+		dst[i] = d
+	}
+	return n
+}
+
+// SpecifiedMask returns a bitmap of the specified elements of values,
+// one bit per element in (len(values)+63)/64 words. The layout matches
+// column.Bitmap, so the result can be used as one.
+func SpecifiedMask[T Float](values []T) []uint64 {
+	mask := make([]uint64, bulk.MaskWords(len(values)))
+	for w := range mask {
+		chunk := values[w*64 : min(len(values), w*64+64)]
+		var bits uint64
+		for j, v := range chunk {
+			bits |= bulk.B2U(v == v) << j
+		}
+		mask[w] = bits
+	}
+	return mask
+}
+
+// Compact removes the Unspecified elements of values in place, keeping
+// the order of the rest, and returns the shortened slice. Elements
+// between the new and the old length are set to Unspecified.
+func Compact[T Float](values []T) []T {
+	var k uint64
+	for _, v := range values {
+		// Every element is written; only specified ones advance k, so
+		// the next write overwrites an Unspecified one.
+		values[k] = v
+		k += bulk.B2U(v == v)
+	}
+	nan := T(Float64Unspecified)
+	for i := range values[k:] {
+		values[int(k)+i] = nan
+	}
+	return values[:k]
+}
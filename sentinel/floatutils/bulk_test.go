@@ -0,0 +1,225 @@
+package floatutils
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCountSpecified(t *testing.T) {
+	nan := Float32Unspecified
+	if got := CountSpecified([]float32{1, nan, 0, nan, -1}); got != 3 {
+		t.Errorf("CountSpecified = %d, want 3", got)
+	}
+	if got := CountSpecified([]float64(nil)); got != 0 {
+		t.Errorf("CountSpecified(nil) = %d, want 0", got)
+	}
+	if got := CountSpecified([]float64{FloatInfinite, Float64Unspecified}); got != 1 {
+		t.Errorf("CountSpecified = %d, want 1 (Inf is specified)", got)
+	}
+}
+
+func TestFillUnspecified(t *testing.T) {
+	values := []float64{1, Float64Unspecified, 3, Float64Unspecified}
+	FillUnspecified(values, 9)
+	if !slices.Equal(values, []float64{1, 9, 3, 9}) {
+		t.Errorf("FillUnspecified = %v", values)
+	}
+}
+
+func TestMergeInto(t *testing.T) {
+	nan := Float64Unspecified
+	dst := []float64{1, 2, nan, nan}
+	if n := MergeInto(dst, []float64{nan, 20, 30}); n != 3 {
+		t.Errorf("MergeInto n = %d, want 3", n)
+	}
+	if dst[0] != 1 || dst[1] != 20 || dst[2] != 30 || IsSpecified(dst[3]) {
+		t.Errorf("MergeInto = %v", dst)
+	}
+}
+
+func TestSpecifiedMask(t *testing.T) {
+	values := make([]float32, 130)
+	for i := range values {
+		if i%3 == 0 {
+			values[i] = Float32Unspecified
+		}
+	}
+	mask := SpecifiedMask(values)
+	if len(mask) != 3 {
+		t.Fatalf("len(mask) = %d, want 3", len(mask))
+	}
+	for i, v := range values {
+		if got := mask[i/64]>>(i%64)&1 == 1; got != IsSpecified(v) {
+			t.Fatalf("bit %d = %v, want %v", i, got, IsSpecified(v))
+		}
+	}
+	if mask[2]>>2 != 0 {
+		t.Errorf("bits past the end are set: %#x", mask[2])
+	}
+	if len(SpecifiedMask([]float64{})) != 0 {
+		t.Error("empty input should give an empty mask")
+	}
+}
+
+func TestCompact(t *testing.T) {
+	nan := Float64Unspecified
+	values := []float64{nan, 1, nan, nan, 2, 3, nan}
+	got := Compact(values)
+	if !slices.Equal(got, []float64{1, 2, 3}) {
+		t.Errorf("Compact = %v", got)
+	}
+	for _, v := range values[len(got):] {
+		if IsSpecified(v) {
+			t.Errorf("tail not cleared: %v", values)
+		}
+	}
+	if got := Compact([]float32{Float32Unspecified}); len(got) != 0 {
+		t.Errorf("Compact = %v, want empty", got)
+	}
+}
+
+// benchFloat64s is a column with about one Unspecified value in seven,
+// at irregular positions so branches cannot be predicted.
+var benchFloat64s = func() []float64 {
+	s := make([]float64, 1<<16)
+	x := uint32(1)
+	for i := range s {
+		x ^= x << 13
+		x ^= x >> 17
+		x ^= x << 5
+		if x%7 == 0 {
+			s[i] = Float64Unspecified
+		} else {
+			s[i] = float64(x)
+		}
+	}
+	return s
+}()
+
+// benchBulkFloat32s holds the same values as benchFloat64s, rounded.
+var benchBulkFloat32s = func() []float32 {
+	s := make([]float32, len(benchFloat64s))
+	for i, v := range benchFloat64s {
+		s[i] = float32(v)
+	}
+	return s
+}()
+
+// benchBoth runs a benchmark over both columns as float64 and float32
+// sub-benchmarks.
+func benchBoth(b *testing.B, bench64 func(*testing.B, []float64), bench32 func(*testing.B, []float32)) {
+	b.Run("float64", func(b *testing.B) { bench64(b, benchFloat64s) })
+	b.Run("float32", func(b *testing.B) { bench32(b, benchBulkFloat32s) })
+}
+
+func BenchmarkCountSpecified(b *testing.B) {
+	benchBoth(b, benchCountSpecified[float64], benchCountSpecified[float32])
+}
+
+func benchCountSpecified[T Float](b *testing.B, values []T) {
+	b.Run("bulk", func(b *testing.B) {
+		for b.Loop() {
+			CountSpecified(values)
+		}
+	})
+	b.Run("scalar", func(b *testing.B) {
+		for b.Loop() {
+			n := 0
+			for _, v := range values {
+				if IsSpecified(v) {
+					n++
+				}
+			}
+			_ = n
+		}
+	})
+}
+
+func BenchmarkFillUnspecified(b *testing.B) {
+	benchBoth(b, benchFillUnspecified[float64], benchFillUnspecified[float32])
+}
+
+func benchFillUnspecified[T Float](b *testing.B, values []T) {
+	dst := make([]T, len(values))
+	b.Run("bulk", func(b *testing.B) {
+		for b.Loop() {
+			copy(dst, values)
+			FillUnspecified(dst, 0)
+		}
+	})
+	b.Run("scalar", func(b *testing.B) {
+		for b.Loop() {
+			copy(dst, values)
+			for i, v := range dst {
+				dst[i] = TakeOrElse(v, 0)
+			}
+		}
+	})
+}
+
+func BenchmarkMergeInto(b *testing.B) {
+	benchBoth(b, benchMergeInto[float64], benchMergeInto[float32])
+}
+
+func benchMergeInto[T Float](b *testing.B, values []T) {
+	dst := make([]T, len(values))
+	b.Run("bulk", func(b *testing.B) {
+		for b.Loop() {
+			MergeInto(dst, values)
+		}
+	})
+	b.Run("scalar", func(b *testing.B) {
+		for b.Loop() {
+			for i, v := range values {
+				dst[i] = Merge(dst[i], v)
+			}
+		}
+	})
+}
+
+func BenchmarkSpecifiedMask(b *testing.B) {
+	benchBoth(b, benchSpecifiedMask[float64], benchSpecifiedMask[float32])
+}
+
+func benchSpecifiedMask[T Float](b *testing.B, values []T) {
+	b.Run("bulk", func(b *testing.B) {
+		for b.Loop() {
+			SpecifiedMask(values)
+		}
+	})
+	b.Run("scalar", func(b *testing.B) {
+		for b.Loop() {
+			mask := make([]uint64, (len(values)+63)/64)
+			for i, v := range values {
+				if IsSpecified(v) {
+					mask[i/64] |= 1 << (i % 64)
+				}
+			}
+		}
+	})
+}
+
+func BenchmarkCompact(b *testing.B) {
+	benchBoth(b, benchCompact[float64], benchCompact[float32])
+}
+
+func benchCompact[T Float](b *testing.B, values []T) {
+	buf := make([]T, len(values))
+	b.Run("bulk", func(b *testing.B) {
+		for b.Loop() {
+			copy(buf, values)
+			Compact(buf)
+		}
+	})
+	b.Run("scalar", func(b *testing.B) {
+		for b.Loop() {
+			copy(buf, values)
+			out := buf[:0]
+			for _, v := range buf {
+				if IsSpecified(v) {
+					out = append(out, v)
+				}
+			}
+		}
+	})
+}
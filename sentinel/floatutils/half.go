@@ -0,0 +1,196 @@
+package floatutils
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+
+	"github.com/zodimo/go-sentinel-helper/internal/sentinellog"
+)
+
+// Float16 is an IEEE 754 binary16 value stored in its bit pattern, for
+// compact tables of optional scalars. It has 11 bits of precision and a
+// range of ±65504; arithmetic happens in float32 via Float32.
+//
+// As with float32 every NaN is Unspecified. Float16From always produces
+// the canonical quiet NaN Float16Unspecified, so stored sentinels compare
+// equal bit for bit. The zero value is a specified +0.
+type Float16 uint16
+
+// 1. Sentinel - Float16Unspecified (canonical quiet NaN)
+const Float16Unspecified Float16 = 0x7E00
+
+const (
+	float16SignMask = 0x8000
+	float16ExpMask  = 0x7C00
+	float16MantMask = 0x03FF
+)
+
+// Float16From rounds f to the nearest Float16, ties to even. Values
+// beyond the range become ±Inf and any NaN becomes Float16Unspecified.
+func Float16From(f float32) Float16 {
+	b := math.Float32bits(f)
+	sign := Float16(b>>16) & float16SignMask
+	exp := int32(b >> 23 & 0xFF)
+	mant := b & 0x7FFFFF
+	if exp == 0xFF {
+		if mant != 0 {
+			return Float16Unspecified
+		}
+		return sign | float16ExpMask
+	}
+
+	e := exp - 127 + 15 // rebias
+	switch {
+	case e >= 0x1F:
+		return sign | float16ExpMask
+	case e < -10:
+		return sign // below half the smallest subnormal, including float32 subnormals
+	case e <= 0:
+		// Subnormal: shift the full 24-bit significand down to the
+		// 2^-24 grid, rounding on the bits shifted out.
+		mant |= 0x800000
+		shift := uint32(14 - e)
+		h := mant >> shift
+		return sign | Float16(roundHalfEven(h, mant&(1<<shift-1), 1<<(shift-1)))
+	}
+	// A carry out of the mantissa bumps the exponent, up to Inf.
+	h := uint32(e)<<10 | mant>>13
+	return sign | Float16(roundHalfEven(h, mant&0x1FFF, 0x1000))
+}
+
+// roundHalfEven rounds the truncated value v up when the remainder rem
+// is above halfway, or exactly halfway and v is odd.
+func roundHalfEven(v, rem, halfway uint32) uint32 {
+	if rem > halfway || rem == halfway && v&1 == 1 {
+		return v + 1
+	}
+	return v
+}
+
+// Float32 widens h exactly; every NaN becomes Float32Unspecified.
+func (h Float16) Float32() float32 {
+	b := uint32(h)
+	sign := b & float16SignMask << 16
+	exp := b & float16ExpMask >> 10
+	mant := b & float16MantMask
+	switch exp {
+	case 0x1F:
+		if mant != 0 {
+			return Float32Unspecified
+		}
+		return math.Float32frombits(sign | 0x7F800000)
+	case 0:
+		f := float32(mant) / (1 << 24) // subnormal, or zero
+		if sign != 0 {
+			return -f
+		}
+		return f
+	}
+	return math.Float32frombits(sign | (exp+127-15)<<23 | mant<<13)
+}
+
+// 2. IsSpecified - predicate (method on value receiver)
+func (h Float16) IsSpecified() bool {
+	return h&^float16SignMask <= float16ExpMask
+}
+
+// IsUnspecified - convenience predicate
+func (h Float16) IsUnspecified() bool {
+	return !h.IsSpecified()
+}
+
+// SetUnspecified - stores Float16Unspecified in the receiver
+func (h *Float16) SetUnspecified() {
+	*h = Float16Unspecified
+}
+
+// 3. TakeOrElse - returns h if specified, otherwise def
+func (h Float16) TakeOrElse(def Float16) Float16 {
+	if h.IsSpecified() {
+		return h
+	}
+	return def
+}
+
+// 4. Merge - prefers other if specified
+func (h Float16) Merge(other Float16) Float16 {
+	if other.IsSpecified() {
+		return other
+	}
+	return h
+}
+
+// 5. String - implements fmt.Stringer
+func (h Float16) String() string {
+	if h.IsUnspecified() {
+		return "Float16{Unspecified}"
+	}
+	return fmt.Sprintf("Float16{%v}", h.Float32())
+}
+
+// 6. Coalesce - N/A for value types
+
+// 7. Same - identity with float semantics: every NaN is the same
+// Unspecified value and +0 is the same as -0
+func (h Float16) Same(other Float16) bool {
+	return Same(h.Float32(), other.Float32())
+}
+
+// 8. SemanticEqual - equality of the widened values within
+// Float32EqualityThreshold
+func (h Float16) SemanticEqual(other Float16) bool {
+	return SemanticEqual(h.Float32(), other.Float32())
+}
+
+// 9. Equal - equality check
+func (h Float16) Equal(other Float16) bool {
+	return h.Same(other) || h.SemanticEqual(other)
+}
+
+// 10. Copy - identity for immutable value types
+func (h Float16) Copy() Float16 {
+	return h
+}
+
+// Package-level forms of the contract, for use as function values.
+func IsSpecifiedFloat16(h Float16) bool      { return h.IsSpecified() }
+func TakeOrElseFloat16(a, b Float16) Float16 { return a.TakeOrElse(b) }
+func MergeFloat16(a, b Float16) Float16      { return a.Merge(b) }
+func StringFloat16(h Float16) string         { return h.String() }
+func SameFloat16(a, b Float16) bool          { return a.Same(b) }
+func SemanticEqualFloat16(a, b Float16) bool { return a.SemanticEqual(b) }
+func EqualFloat16(a, b Float16) bool         { return a.Equal(b) }
+func CopyFloat16(h Float16) Float16          { return h.Copy() }
+
+// LogValue implements slog.LogValuer; see LogValue.
+func (h Float16) LogValue() slog.Value {
+	if h.IsUnspecified() {
+		return sentinellog.Value()
+	}
+	return slog.Float64Value(float64(h.Float32()))
+}
+
+// Bulk conversions work like copy: they convert min(len(dst), len(src))
+// elements and return that count. The loops are branch-light and free
+// of bounds checks so they stay cheap over large columns.
+
+// Float16sFromFloat32s converts src into dst with Float16From.
+func Float16sFromFloat32s(dst []Float16, src []float32) int {
+	n := min(len(dst), len(src))
+	dst, src = dst[:n], src[:n]
+	for i, f := range src {
+		dst[i] = Float16From(f)
+	}
+	return n
+}
+
+// Float32sFromFloat16s converts src into dst with Float16.Float32.
+func Float32sFromFloat16s(dst []float32, src []Float16) int {
+	n := min(len(dst), len(src))
+	dst, src = dst[:n], src[:n]
+	for i, h := range src {
+		dst[i] = h.Float32()
+	}
+	return n
+}
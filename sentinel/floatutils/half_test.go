@@ -0,0 +1,160 @@
+package floatutils
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFloat16From(t *testing.T) {
+	tests := []struct {
+		in   float32
+		want Float16
+	}{
+		{0, 0x0000},
+		{float32(math.Copysign(0, -1)), 0x8000},
+		{1, 0x3C00},
+		{-2, 0xC000},
+		{0.5, 0x3800},
+		{65504, 0x7BFF},                       // max finite
+		{65519, 0x7BFF},                       // rounds down below the tie
+		{65520, 0x7C00},                       // tie rounds to even, which is Inf
+		{float32(math.Inf(-1)), 0xFC00},       //
+		{1e10, 0x7C00},                        // overflow
+		{float32(math.Ldexp(1, -24)), 0x0001}, // smallest subnormal
+		{float32(math.Ldexp(1, -25)), 0x0000}, // tie rounds to even, which is 0
+		{float32(math.Ldexp(3, -26)), 0x0001}, // above the tie
+		{float32(math.Ldexp(1, -14)), 0x0400}, // smallest normal
+		{1e-40, 0x0000},                       // float32 subnormal
+		{1 + 1.0/2048, 0x3C00},                // tie to even, down
+		{1 + 3.0/2048, 0x3C02},                // tie to even, up
+	}
+	for _, tt := range tests {
+		if got := Float16From(tt.in); got != tt.want {
+			t.Errorf("Float16From(%v) = %#04x, want %#04x", tt.in, uint16(got), uint16(tt.want))
+		}
+	}
+	for _, nan := range []uint32{0x7FC00000, 0xFFC00000, 0x7F800001, 0x7FBFFFFF} {
+		if got := Float16From(math.Float32frombits(nan)); got != Float16Unspecified {
+			t.Errorf("Float16From(NaN %#08x) = %#04x, want Float16Unspecified", nan, uint16(got))
+		}
+	}
+}
+
+func TestFloat16RoundTrip(t *testing.T) {
+	for i := 0; i <= math.MaxUint16; i++ {
+		h := Float16(i)
+		f := h.Float32()
+		if h.IsUnspecified() {
+			if !IsUnspecified(f) {
+				t.Fatalf("%#04x: NaN widened to %v", i, f)
+			}
+			if Float16From(f) != Float16Unspecified {
+				t.Fatalf("%#04x: NaN did not narrow to the canonical sentinel", i)
+			}
+			continue
+		}
+		if got := Float16From(f); got != h {
+			t.Fatalf("%#04x: round trip through %v gave %#04x", i, f, uint16(got))
+		}
+		// The midpoint to the next value up rounds to whichever is even.
+		next := h + 1
+		if h&0x7FFF >= 0x7C00 || next.IsUnspecified() {
+			continue
+		}
+		mid := float32((float64(f) + float64(next.Float32())) / 2)
+		want := h
+		if h&1 == 1 {
+			want = next
+		}
+		if got := Float16From(mid); got != want {
+			t.Fatalf("%#04x: midpoint %v rounded to %#04x, want %#04x", i, mid, uint16(got), uint16(want))
+		}
+	}
+}
+
+func TestFloat16Contract(t *testing.T) {
+	one := Float16From(1)
+	if Float16Unspecified.IsSpecified() || !IsSpecifiedFloat16(0) {
+		t.Error("IsSpecified mismatch")
+	}
+	if !Float16(0xFE01).IsUnspecified() || !Float16(0x7C00).IsSpecified() {
+		t.Error("any NaN is Unspecified; Inf is specified")
+	}
+	if got := TakeOrElseFloat16(Float16Unspecified, one); got != one {
+		t.Errorf("TakeOrElse = %v", got)
+	}
+	if got := MergeFloat16(one, Float16Unspecified); got != one {
+		t.Errorf("Merge = %v", got)
+	}
+	if got := MergeFloat16(Float16Unspecified, one); got != one {
+		t.Errorf("Merge = %v", got)
+	}
+	if got := StringFloat16(Float16From(1.5)); got != "Float16{1.5}" {
+		t.Errorf("String = %q", got)
+	}
+	if got := Float16(0xFE01).String(); got != "Float16{Unspecified}" {
+		t.Errorf("String = %q", got)
+	}
+	if !SameFloat16(Float16Unspecified, 0xFE01) || !SameFloat16(0x0000, 0x8000) || SameFloat16(one, 0x3C01) {
+		t.Error("Same mismatch")
+	}
+	if !EqualFloat16(Float16Unspecified, 0x7C01) || EqualFloat16(one, Float16Unspecified) || !SemanticEqualFloat16(one, one) {
+		t.Error("Equal mismatch")
+	}
+	if CopyFloat16(one) != one {
+		t.Error("Copy mismatch")
+	}
+	if got := Float16Unspecified.LogValue().Resolve().String(); got != "<unspecified>" {
+		t.Errorf("LogValue = %q", got)
+	}
+	if got := one.LogValue().Float64(); got != 1 {
+		t.Errorf("LogValue = %v", got)
+	}
+}
+
+func TestFloat16Bulk(t *testing.T) {
+	src := []float32{1, Float32Unspecified, -0.5, 70000}
+	dst := make([]Float16, 3)
+	if n := Float16sFromFloat32s(dst, src); n != 3 {
+		t.Fatalf("n = %d, want 3", n)
+	}
+	if dst[0] != 0x3C00 || dst[1] != Float16Unspecified || dst[2] != 0xB800 {
+		t.Errorf("dst = %#04x", dst)
+	}
+	back := make([]float32, 4)
+	if n := Float32sFromFloat16s(back, dst); n != 3 {
+		t.Fatalf("n = %d, want 3", n)
+	}
+	if back[0] != 1 || !IsUnspecified(back[1]) || back[2] != -0.5 || back[3] != 0 {
+		t.Errorf("back = %v", back)
+	}
+}
+
+var benchFloat32s = func() []float32 {
+	s := make([]float32, 4096)
+	for i := range s {
+		s[i] = float32(i) * 0.37
+		if i%7 == 0 {
+			s[i] = Float32Unspecified
+		}
+	}
+	return s
+}()
+
+func BenchmarkFloat16sFromFloat32s(b *testing.B) {
+	dst := make([]Float16, len(benchFloat32s))
+	b.SetBytes(int64(4 * len(benchFloat32s)))
+	for b.Loop() {
+		Float16sFromFloat32s(dst, benchFloat32s)
+	}
+}
+
+func BenchmarkFloat32sFromFloat16s(b *testing.B) {
+	src := make([]Float16, len(benchFloat32s))
+	Float16sFromFloat32s(src, benchFloat32s)
+	dst := make([]float32, len(src))
+	b.SetBytes(int64(2 * len(src)))
+	for b.Loop() {
+		Float32sFromFloat16s(dst, src)
+	}
+}
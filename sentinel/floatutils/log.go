@@ -0,0 +1,21 @@
+package floatutils
+
+import (
+	"log/slog"
+
+	"github.com/zodimo/go-sentinel-helper/internal/sentinellog"
+)
+
+// LogValue returns f as a slog.Value. The Unspecified NaN logs as
+// "<unspecified>"; infinities log as numbers.
+func LogValue[T Float](f T) slog.Value {
+	if IsUnspecified(f) {
+		return sentinellog.Value()
+	}
+	return slog.Float64Value(float64(f))
+}
+
+// Attr returns a slog.Attr for f; see LogValue.
+func Attr[T Float](key string, f T) slog.Attr {
+	return slog.Attr{Key: key, Value: LogValue(f)}
+}
@@ -0,0 +1,21 @@
+package floatutils
+
+import (
+	"log/slog"
+	"math"
+	"testing"
+)
+
+func TestAttr(t *testing.T) {
+	if got := Attr("f", float32(0.5)).Value.Resolve(); got.Kind() != slog.KindFloat64 || got.Float64() != 0.5 {
+		t.Errorf("Attr(0.5) = %v, want 0.5", got)
+	}
+	if got := Attr("f", math.Inf(1)).Value.Resolve(); got.Kind() != slog.KindFloat64 {
+		t.Errorf("Attr(+Inf) = %v, want a number", got)
+	}
+	for _, v := range []slog.Value{Attr("f", Float32Unspecified).Value, LogValue(Float64Unspecified)} {
+		if got := v.Resolve().String(); got != "<unspecified>" {
+			t.Errorf("Unspecified logs as %q, want <unspecified>", got)
+		}
+	}
+}
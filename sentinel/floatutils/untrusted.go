@@ -0,0 +1,16 @@
+package floatutils
+
+import "errors"
+
+// ErrReservedNaN is returned when external input is NaN, which is reserved
+// for Unspecified.
+var ErrReservedNaN = errors.New("floatutils: NaN is reserved for Unspecified")
+
+// FromUntrusted converts external input to a float, rejecting NaN, which
+// would otherwise silently read as Unspecified. Infinities pass.
+func FromUntrusted[T Float](f T) (T, error) {
+	if IsUnspecified(f) {
+		return f, ErrReservedNaN
+	}
+	return f, nil
+}
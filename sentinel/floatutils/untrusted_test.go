@@ -0,0 +1,22 @@
+package floatutils
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestFromUntrusted(t *testing.T) {
+	if v, err := FromUntrusted(1.5); err != nil || v != 1.5 {
+		t.Errorf("FromUntrusted(1.5) = %v, %v", v, err)
+	}
+	if v, err := FromUntrusted(Float32Infinite); err != nil || v != Float32Infinite {
+		t.Errorf("FromUntrusted(+Inf) = %v, %v", v, err)
+	}
+	if _, err := FromUntrusted(math.NaN()); !errors.Is(err, ErrReservedNaN) {
+		t.Errorf("FromUntrusted(NaN) err = %v, want ErrReservedNaN", err)
+	}
+	if _, err := FromUntrusted(Float32Unspecified); !errors.Is(err, ErrReservedNaN) {
+		t.Errorf("FromUntrusted(float32 NaN) err = %v, want ErrReservedNaN", err)
+	}
+}
@@ -0,0 +1,154 @@
+package geom
+
+import (
+	"fmt"
+	"math"
+	"unsafe"
+
+	"github.com/zodimo/go-sentinel-helper/sentinel/floatutils"
+)
+
+// Complex is a complex number type. Its real and imaginary parts follow
+// the Offset rules: a NaN part is Unspecified on its own. Addition keeps
+// the parts apart, but multiplication mixes them, so an Unspecified part
+// spreads to both parts of a product.
+type Complex interface {
+	~complex64 | ~complex128
+}
+
+// 1. Sentinel - both parts NaN
+var (
+	Complex64Unspecified  = complex64(complex(math.NaN(), math.NaN()))
+	Complex128Unspecified = complex(math.NaN(), math.NaN())
+)
+
+// The builtins real, imag and complex do not accept type parameters, so
+// the generic functions go through complex128, which holds every
+// complex64 exactly.
+
+func parts[T Complex](c T) (re, im float64) {
+	c128 := complex128(c)
+	return real(c128), imag(c128)
+}
+
+func fromParts[T Complex](re, im float64) T {
+	return T(complex(re, im))
+}
+
+// 2. IsSpecified - at least one part is specified (package-level function)
+func IsSpecifiedComplex[T Complex](c T) bool {
+	re, im := parts(c)
+	return floatutils.IsSpecified(re) || floatutils.IsSpecified(im)
+}
+
+// IsUnspecifiedComplex - convenience predicate
+func IsUnspecifiedComplex[T Complex](c T) bool {
+	return !IsSpecifiedComplex(c)
+}
+
+// IsFullySpecifiedComplex reports whether both parts are specified.
+func IsFullySpecifiedComplex[T Complex](c T) bool {
+	re, im := parts(c)
+	return floatutils.IsSpecified(re) && floatutils.IsSpecified(im)
+}
+
+// 3. TakeOrElse - part-wise fallback: every Unspecified part of c comes from def
+func TakeOrElseComplex[T Complex](c, def T) T {
+	re, im := parts(c)
+	dre, dim := parts(def)
+	return fromParts[T](floatutils.TakeOrElse(re, dre), floatutils.TakeOrElse(im, dim))
+}
+
+// 4. Merge - part-wise composition merge: every specified part of b wins
+func MergeComplex[T Complex](a, b T) T {
+	return TakeOrElseComplex(b, a)
+}
+
+// 5. String - stringification (package-level function)
+func StringComplex[T Complex](c T) string {
+	if IsUnspecifiedComplex(c) {
+		return fmt.Sprintf("%T{Unspecified}", c)
+	}
+	re, im := parts(c)
+	return fmt.Sprintf("%T{real: %s, imag: %s}", c, formatPart(re), formatPart(im))
+}
+
+func formatPart(f float64) string {
+	if floatutils.IsUnspecified(f) {
+		return "Unspecified"
+	}
+	return fmt.Sprint(f)
+}
+
+// 6. Coalesce - N/A for value types (no nil possible)
+
+// 7. Same - identity; any NaN counts as the same Unspecified part
+func SameComplex[T Complex](a, b T) bool {
+	are, aim := parts(a)
+	bre, bim := parts(b)
+	return floatutils.Same(are, bre) && floatutils.Same(aim, bim)
+}
+
+// 8. SemanticEqual - both parts equal within the floatutils threshold of
+// the part type, so complex64 and types defined on it use
+// Float32EqualityThreshold.
+func SemanticEqualComplex[T Complex](a, b T) bool {
+	are, aim := parts(a)
+	bre, bim := parts(b)
+	if unsafe.Sizeof(a) == unsafe.Sizeof(complex64(0)) {
+		return floatutils.SemanticEqual(float32(are), float32(bre)) &&
+			floatutils.SemanticEqual(float32(aim), float32(bim))
+	}
+	return floatutils.SemanticEqual(are, bre) && floatutils.SemanticEqual(aim, bim)
+}
+
+// 9. Equal - equality check (combines Same and SemanticEqual)
+func EqualComplex[T Complex](a, b T) bool {
+	return SameComplex(a, b) || SemanticEqualComplex(a, b)
+}
+
+// 10. Copy - per-part overrides using float sentinels
+
+type ComplexCopyOptions struct {
+	Real, Imag float64
+}
+type ComplexCopyOption func(*ComplexCopyOptions) ComplexCopyOptions
+
+func CopyWithReal(re float64) ComplexCopyOption {
+	return func(o *ComplexCopyOptions) ComplexCopyOptions {
+		o.Real = re
+		return *o
+	}
+}
+
+func CopyWithImag(im float64) ComplexCopyOption {
+	return func(o *ComplexCopyOptions) ComplexCopyOptions {
+		o.Imag = im
+		return *o
+	}
+}
+
+// CopyComplex returns c with modified parts. Parts left at
+// floatutils.Float64Unspecified are taken from c.
+func CopyComplex[T Complex](c T, opts ...ComplexCopyOption) T {
+	var o ComplexCopyOptions = ComplexCopyOptions{
+		Real: floatutils.Float64Unspecified,
+		Imag: floatutils.Float64Unspecified,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	re, im := parts(c)
+	return fromParts[T](floatutils.TakeOrElse(o.Real, re), floatutils.TakeOrElse(o.Imag, im))
+}
+
+// ComplexFromOffset returns o as X + Yi.
+func ComplexFromOffset(o Offset) complex64 {
+	return complex(o.X(), o.Y())
+}
+
+// OffsetFromComplex returns c as Offset{real(c), imag(c)}.
+func OffsetFromComplex[T Complex](c T) Offset {
+	re, im := parts(c)
+	return NewOffset(float32(re), float32(im))
+}
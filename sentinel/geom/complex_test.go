@@ -0,0 +1,44 @@
+package geom
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type phasor complex64
+
+func TestComplexContract(t *testing.T) {
+	nan64 := math.NaN()
+	partial := complex(1, nan64)
+
+	require.True(t, IsUnspecifiedComplex(Complex64Unspecified))
+	require.True(t, IsUnspecifiedComplex(Complex128Unspecified))
+	require.True(t, IsSpecifiedComplex(partial))
+	require.False(t, IsFullySpecifiedComplex(partial))
+	require.True(t, IsFullySpecifiedComplex(complex64(0)))
+
+	require.True(t, SameComplex(TakeOrElseComplex(partial, 5+6i), 1+6i))
+	require.True(t, SameComplex(MergeComplex(5+6i, partial), 1+6i))
+	require.True(t, SameComplex(MergeComplex(5+6i, Complex128Unspecified), 5+6i))
+
+	require.Equal(t, "complex128{Unspecified}", StringComplex(Complex128Unspecified))
+	require.Equal(t, "complex128{real: 1, imag: Unspecified}", StringComplex(partial))
+	require.Equal(t, "complex64{real: 1.5, imag: -2}", StringComplex(complex64(1.5-2i)))
+
+	require.True(t, SameComplex(partial, complex(1, nan64)))
+	require.False(t, SameComplex(partial, 1+0i))
+	require.True(t, EqualComplex(complex64(1+1i), complex64(1.0000001+1i)))
+	require.False(t, EqualComplex(1+1i, 1.0000001+1i), "complex128 uses the float64 threshold")
+	require.True(t, EqualComplex(phasor(1+1i), phasor(1.0000001+1i)), "defined types keep their part precision")
+
+	require.Equal(t, complex64(9+2i), CopyComplex(complex64(1+2i), CopyWithReal(9)))
+	require.True(t, SameComplex(CopyComplex(Complex128Unspecified, CopyWithImag(3)), complex(nan64, 3)))
+}
+
+func TestComplexOffset(t *testing.T) {
+	require.Equal(t, complex64(3+4i), ComplexFromOffset(NewOffset(3, 4)))
+	require.True(t, OffsetFromComplex(complex(3, math.NaN())).Same(NewOffset(3, nan)))
+	require.True(t, OffsetFromComplex(Complex64Unspecified).IsUnspecified())
+}
@@ -0,0 +1,231 @@
+// Package geom provides 2-D geometry values for layout code in which any
+// component may be Unspecified.
+//
+// Offset and Size pack two float32 components into a uint64 with
+// sentinel/packed; a NaN component is Unspecified on its own, so
+// TakeOrElse and Merge work component by component. Rect is built from
+// two Offsets and complex64/complex128 follow the same rules for their
+// real and imaginary parts. Arithmetic follows floatutils semantics: an
+// Unspecified component propagates into the result.
+package geom
+
+import (
+	"math"
+
+	"github.com/zodimo/go-sentinel-helper/sentinel/floatutils"
+	"github.com/zodimo/go-sentinel-helper/sentinel/packed"
+)
+
+type offsetTag struct{}
+
+var (
+	offsetLayout = packed.NewLayout[offsetTag]()
+	offsetX      = offsetLayout.Float32("x")
+	offsetY      = offsetLayout.Float32("y")
+)
+
+// Offset is a 2-D displacement. Uses Pattern 1-D from sentinel_pattern.md:
+// the struct wraps a packed word, so components can only be reached
+// through X and Y. The word is stored XORed with the Unspecified pattern,
+// so the zero value is OffsetUnspecified.
+type Offset struct {
+	bits uint64
+}
+
+// 1. Sentinel - OffsetUnspecified (both components NaN), the zero value
+var OffsetUnspecified = Offset{}
+
+// OffsetZero is the origin.
+var OffsetZero = NewOffset(0, 0)
+
+// offsetUnspecifiedBits is the packed form of OffsetUnspecified.
+var offsetUnspecifiedBits = offsetLayout.Unspecified().Raw()
+
+func offsetFromWord(w packed.Word[offsetTag]) Offset {
+	return Offset{bits: w.Raw() ^ offsetUnspecifiedBits}
+}
+
+func (o Offset) word() packed.Word[offsetTag] {
+	return packed.WordFromRaw[offsetTag](o.bits ^ offsetUnspecifiedBits)
+}
+
+// NewOffset packs x and y; either may be NaN to leave it Unspecified.
+func NewOffset(x, y float32) Offset {
+	return offsetFromWord(offsetY.Set(offsetX.Set(offsetLayout.Unspecified(), x), y))
+}
+
+// X returns the horizontal component, NaN if Unspecified.
+func (o Offset) X() float32 {
+	return offsetX.Get(o.word())
+}
+
+// Y returns the vertical component, NaN if Unspecified.
+func (o Offset) Y() float32 {
+	return offsetY.Get(o.word())
+}
+
+// 2. IsSpecified - at least one component is specified (method on value receiver)
+func (o Offset) IsSpecified() bool {
+	return offsetLayout.IsSpecified(o.word())
+}
+
+// IsUnspecified - convenience predicate
+func (o Offset) IsUnspecified() bool {
+	return !o.IsSpecified()
+}
+
+// SetUnspecified - stores OffsetUnspecified in the receiver
+func (o *Offset) SetUnspecified() {
+	*o = OffsetUnspecified
+}
+
+// IsFullySpecified reports whether both components are specified.
+func (o Offset) IsFullySpecified() bool {
+	return offsetLayout.IsFullySpecified(o.word())
+}
+
+// IsSpecifiedOffset - package-level predicate
+func IsSpecifiedOffset(o Offset) bool {
+	return o.IsSpecified()
+}
+
+// 3. TakeOrElse - component-wise fallback: every Unspecified component of o comes from def
+func (o Offset) TakeOrElse(def Offset) Offset {
+	return offsetFromWord(offsetLayout.TakeOrElse(o.word(), def.word()))
+}
+
+// TakeOrElseOffset - package-level fallback
+func TakeOrElseOffset(a, b Offset) Offset {
+	return a.TakeOrElse(b)
+}
+
+// 4. Merge - component-wise composition merge: every specified component of other wins
+func (o Offset) Merge(other Offset) Offset {
+	return offsetFromWord(offsetLayout.Merge(o.word(), other.word()))
+}
+
+// MergeOffset - package-level merge function
+func MergeOffset(a, b Offset) Offset {
+	return a.Merge(b)
+}
+
+// 5. String - stringification (method on value receiver)
+func (o Offset) String() string {
+	return "Offset" + offsetLayout.String(o.word())
+}
+
+// StringOffset - package-level string function
+func StringOffset(o Offset) string {
+	return o.String()
+}
+
+// 6. Coalesce - N/A for value types (no nil possible)
+
+// 7. Same - identity; any NaN payload counts as the same Unspecified component
+func (o Offset) Same(other Offset) bool {
+	return offsetLayout.Equal(o.word(), other.word())
+}
+
+// SameOffset - package-level same function
+func SameOffset(a, b Offset) bool {
+	return a.Same(b)
+}
+
+// 8. SemanticEqual - every component equal within floatutils.Float32EqualityThreshold
+func (o Offset) SemanticEqual(other Offset) bool {
+	return floatutils.SemanticEqual(o.X(), other.X()) &&
+		floatutils.SemanticEqual(o.Y(), other.Y())
+}
+
+// SemanticEqualOffset - package-level semantic equal function
+func SemanticEqualOffset(a, b Offset) bool {
+	return a.SemanticEqual(b)
+}
+
+// 9. Equal - equality check (combines Same and SemanticEqual)
+func (o Offset) Equal(other Offset) bool {
+	return o.Same(other) || o.SemanticEqual(other)
+}
+
+// EqualOffset - package-level equal function
+func EqualOffset(a, b Offset) bool {
+	return a.Equal(b)
+}
+
+// 10. Copy - per-component overrides using float sentinels
+
+type OffsetCopyOptions struct {
+	X, Y float32
+}
+type OffsetCopyOption func(*OffsetCopyOptions) OffsetCopyOptions
+
+func CopyWithX(x float32) OffsetCopyOption {
+	return func(o *OffsetCopyOptions) OffsetCopyOptions {
+		o.X = x
+		return *o
+	}
+}
+
+func CopyWithY(y float32) OffsetCopyOption {
+	return func(o *OffsetCopyOptions) OffsetCopyOptions {
+		o.Y = y
+		return *o
+	}
+}
+
+// Copy creates a new offset with modified components. Components left at
+// floatutils.Float32Unspecified are taken from o.
+func (o Offset) Copy(opts ...OffsetCopyOption) Offset {
+	var c OffsetCopyOptions = OffsetCopyOptions{
+		X: floatutils.Float32Unspecified,
+		Y: floatutils.Float32Unspecified,
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return NewOffset(
+		floatutils.TakeOrElse(c.X, o.X()),
+		floatutils.TakeOrElse(c.Y, o.Y()),
+	)
+}
+
+// CopyOffset - package-level copy function
+func CopyOffset(o Offset, opts ...OffsetCopyOption) Offset {
+	return o.Copy(opts...)
+}
+
+// Arithmetic works component by component; an Unspecified component
+// stays Unspecified in the result.
+
+// Plus returns o + other.
+func (o Offset) Plus(other Offset) Offset {
+	return NewOffset(o.X()+other.X(), o.Y()+other.Y())
+}
+
+// Minus returns o - other.
+func (o Offset) Minus(other Offset) Offset {
+	return NewOffset(o.X()-other.X(), o.Y()-other.Y())
+}
+
+// Times scales both components by factor.
+func (o Offset) Times(factor float32) Offset {
+	return NewOffset(o.X()*factor, o.Y()*factor)
+}
+
+// Div divides both components by divisor.
+func (o Offset) Div(divisor float32) Offset {
+	return NewOffset(o.X()/divisor, o.Y()/divisor)
+}
+
+// Negate returns -o.
+func (o Offset) Negate() Offset {
+	return o.Times(-1)
+}
+
+// Distance returns the length of o, NaN unless o is fully specified.
+func (o Offset) Distance() float32 {
+	if !o.IsFullySpecified() {
+		return floatutils.Float32Unspecified // math.Hypot(Inf, NaN) is +Inf
+	}
+	return float32(math.Hypot(float64(o.X()), float64(o.Y())))
+}
@@ -0,0 +1,80 @@
+package geom
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var nan = float32(math.NaN())
+
+func TestNewOffset(t *testing.T) {
+	o := NewOffset(1, -2)
+	require.Equal(t, float32(1), o.X())
+	require.Equal(t, float32(-2), o.Y())
+	require.True(t, o.IsFullySpecified())
+
+	var zero Offset
+	require.True(t, zero.IsUnspecified(), "the zero value is Unspecified")
+	require.True(t, Offset{}.Same(OffsetUnspecified))
+	require.True(t, OffsetZero.IsFullySpecified(), "the origin is a real offset")
+	require.Equal(t, float32(0), OffsetZero.X())
+
+	require.True(t, OffsetUnspecified.IsUnspecified())
+	require.True(t, NewOffset(nan, nan).IsUnspecified())
+
+	partial := NewOffset(3, nan)
+	require.True(t, IsSpecifiedOffset(partial))
+	require.False(t, partial.IsFullySpecified())
+	require.True(t, math.IsNaN(float64(partial.Y())))
+}
+
+func TestOffsetTakeOrElseMerge(t *testing.T) {
+	def := NewOffset(10, 20)
+	require.True(t, NewOffset(1, nan).TakeOrElse(def).Same(NewOffset(1, 20)))
+	require.True(t, TakeOrElseOffset(OffsetUnspecified, def).Same(def))
+	require.True(t, NewOffset(1, 2).TakeOrElse(def).Same(NewOffset(1, 2)))
+
+	require.True(t, MergeOffset(def, NewOffset(nan, 5)).Same(NewOffset(10, 5)))
+	require.True(t, def.Merge(OffsetUnspecified).Same(def))
+}
+
+func TestOffsetString(t *testing.T) {
+	require.Equal(t, "Offset{Unspecified}", StringOffset(OffsetUnspecified))
+	require.Equal(t, "Offset{x: 1.5, y: Unspecified}", NewOffset(1.5, nan).String())
+	require.Equal(t, "Offset{x: 0, y: 2}", NewOffset(0, 2).String())
+}
+
+func TestOffsetEquality(t *testing.T) {
+	otherNaN := math.Float32frombits(0x7FC00001)
+	require.True(t, SameOffset(NewOffset(1, nan), NewOffset(1, otherNaN)), "any NaN payload is Unspecified")
+	require.False(t, SameOffset(NewOffset(1, 2), NewOffset(1.0000001, 2)))
+	require.True(t, SemanticEqualOffset(NewOffset(1, 2), NewOffset(1.0000001, 2)))
+	require.True(t, EqualOffset(NewOffset(1, 2), NewOffset(1.0000001, 2)))
+	require.False(t, EqualOffset(NewOffset(1, nan), NewOffset(1, 0)))
+	require.True(t, EqualOffset(OffsetUnspecified, NewOffset(nan, nan)))
+}
+
+func TestOffsetCopy(t *testing.T) {
+	o := NewOffset(1, 2)
+	require.True(t, o.Copy().Same(o))
+	require.True(t, CopyOffset(o, CopyWithX(5)).Same(NewOffset(5, 2)))
+	require.True(t, o.Copy(CopyWithY(0), CopyWithX(nan)).Same(NewOffset(1, 0)))
+	require.True(t, OffsetUnspecified.Copy(CopyWithY(4)).Same(NewOffset(nan, 4)))
+}
+
+func TestOffsetArithmetic(t *testing.T) {
+	a, b := NewOffset(1, 2), NewOffset(3, nan)
+	require.True(t, a.Plus(NewOffset(3, 4)).Same(NewOffset(4, 6)))
+	require.True(t, a.Minus(NewOffset(3, 4)).Same(NewOffset(-2, -2)))
+	require.True(t, a.Times(2).Same(NewOffset(2, 4)))
+	require.True(t, a.Div(2).Same(NewOffset(0.5, 1)))
+	require.True(t, a.Negate().Same(NewOffset(-1, -2)))
+	require.True(t, a.Plus(b).Same(NewOffset(4, nan)), "Unspecified components propagate")
+	require.True(t, a.Plus(OffsetUnspecified).IsUnspecified())
+
+	require.Equal(t, float32(5), NewOffset(3, 4).Distance())
+	require.True(t, math.IsNaN(float64(b.Distance())))
+	require.True(t, math.IsNaN(float64(NewOffset(float32(math.Inf(1)), nan).Distance())))
+}
@@ -0,0 +1,264 @@
+package geom
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zodimo/go-sentinel-helper/sentinel/floatutils"
+)
+
+// Rect is an axis-aligned rectangle given by its edges. It holds two
+// packed Offsets, top-left and bottom-right, so each of the four edges
+// may be Unspecified on its own.
+type Rect struct {
+	topLeft, bottomRight Offset
+}
+
+// 1. Sentinel - RectUnspecified (all four edges NaN), the zero value since
+// the zero Offset is OffsetUnspecified
+var RectUnspecified = Rect{topLeft: OffsetUnspecified, bottomRight: OffsetUnspecified}
+
+// RectZero is the empty rectangle at the origin.
+var RectZero = NewRect(0, 0, 0, 0)
+
+// NewRect creates a rectangle from its edges; any may be NaN to leave it
+// Unspecified.
+func NewRect(left, top, right, bottom float32) Rect {
+	return Rect{topLeft: NewOffset(left, top), bottomRight: NewOffset(right, bottom)}
+}
+
+// RectFromOffsetSize creates the rectangle of size s whose top-left corner is o.
+func RectFromOffsetSize(o Offset, s Size) Rect {
+	return Rect{topLeft: o, bottomRight: o.Plus(NewOffset(s.Width(), s.Height()))}
+}
+
+// Left returns the left edge, NaN if Unspecified.
+func (r Rect) Left() float32 { return r.topLeft.X() }
+
+// Top returns the top edge, NaN if Unspecified.
+func (r Rect) Top() float32 { return r.topLeft.Y() }
+
+// Right returns the right edge, NaN if Unspecified.
+func (r Rect) Right() float32 { return r.bottomRight.X() }
+
+// Bottom returns the bottom edge, NaN if Unspecified.
+func (r Rect) Bottom() float32 { return r.bottomRight.Y() }
+
+// TopLeft returns the top-left corner.
+func (r Rect) TopLeft() Offset { return r.topLeft }
+
+// BottomRight returns the bottom-right corner.
+func (r Rect) BottomRight() Offset { return r.bottomRight }
+
+// Width returns Right - Left.
+func (r Rect) Width() float32 { return r.Right() - r.Left() }
+
+// Height returns Bottom - Top.
+func (r Rect) Height() float32 { return r.Bottom() - r.Top() }
+
+// Size returns the extent of r.
+func (r Rect) Size() Size { return NewSize(r.Width(), r.Height()) }
+
+// Center returns the middle of r.
+func (r Rect) Center() Offset { return r.topLeft.Plus(r.bottomRight).Div(2) }
+
+// 2. IsSpecified - at least one edge is specified (method on value receiver)
+func (r Rect) IsSpecified() bool {
+	return r.topLeft.IsSpecified() || r.bottomRight.IsSpecified()
+}
+
+// IsUnspecified - convenience predicate
+func (r Rect) IsUnspecified() bool {
+	return !r.IsSpecified()
+}
+
+// SetUnspecified - stores RectUnspecified in the receiver
+func (r *Rect) SetUnspecified() {
+	*r = RectUnspecified
+}
+
+// IsFullySpecified reports whether all four edges are specified.
+func (r Rect) IsFullySpecified() bool {
+	return r.topLeft.IsFullySpecified() && r.bottomRight.IsFullySpecified()
+}
+
+// IsSpecifiedRect - package-level predicate
+func IsSpecifiedRect(r Rect) bool {
+	return r.IsSpecified()
+}
+
+// 3. TakeOrElse - edge-wise fallback: every Unspecified edge of r comes from def
+func (r Rect) TakeOrElse(def Rect) Rect {
+	return Rect{topLeft: r.topLeft.TakeOrElse(def.topLeft), bottomRight: r.bottomRight.TakeOrElse(def.bottomRight)}
+}
+
+// TakeOrElseRect - package-level fallback
+func TakeOrElseRect(a, b Rect) Rect {
+	return a.TakeOrElse(b)
+}
+
+// 4. Merge - edge-wise composition merge: every specified edge of other wins
+func (r Rect) Merge(other Rect) Rect {
+	return Rect{topLeft: r.topLeft.Merge(other.topLeft), bottomRight: r.bottomRight.Merge(other.bottomRight)}
+}
+
+// MergeRect - package-level merge function
+func MergeRect(a, b Rect) Rect {
+	return a.Merge(b)
+}
+
+// 5. String - stringification (method on value receiver)
+func (r Rect) String() string {
+	if r.IsUnspecified() {
+		return "Rect{Unspecified}"
+	}
+	var sb strings.Builder
+	sb.WriteString("Rect{")
+	for i, edge := range [4]struct {
+		name  string
+		value float32
+	}{{"left", r.Left()}, {"top", r.Top()}, {"right", r.Right()}, {"bottom", r.Bottom()}} {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		if floatutils.IsUnspecified(edge.value) {
+			fmt.Fprintf(&sb, "%s: Unspecified", edge.name)
+		} else {
+			fmt.Fprintf(&sb, "%s: %v", edge.name, edge.value)
+		}
+	}
+	sb.WriteByte('}')
+	return sb.String()
+}
+
+// StringRect - package-level string function
+func StringRect(r Rect) string {
+	return r.String()
+}
+
+// 6. Coalesce - N/A for value types (no nil possible)
+
+// 7. Same - identity; any NaN payload counts as the same Unspecified edge
+func (r Rect) Same(other Rect) bool {
+	return r.topLeft.Same(other.topLeft) && r.bottomRight.Same(other.bottomRight)
+}
+
+// SameRect - package-level same function
+func SameRect(a, b Rect) bool {
+	return a.Same(b)
+}
+
+// 8. SemanticEqual - every edge equal within floatutils.Float32EqualityThreshold
+func (r Rect) SemanticEqual(other Rect) bool {
+	return r.topLeft.SemanticEqual(other.topLeft) && r.bottomRight.SemanticEqual(other.bottomRight)
+}
+
+// SemanticEqualRect - package-level semantic equal function
+func SemanticEqualRect(a, b Rect) bool {
+	return a.SemanticEqual(b)
+}
+
+// 9. Equal - equality check (combines Same and SemanticEqual)
+func (r Rect) Equal(other Rect) bool {
+	return r.Same(other) || r.SemanticEqual(other)
+}
+
+// EqualRect - package-level equal function
+func EqualRect(a, b Rect) bool {
+	return a.Equal(b)
+}
+
+// 10. Copy - per-edge overrides using float sentinels
+
+type RectCopyOptions struct {
+	Left, Top, Right, Bottom float32
+}
+type RectCopyOption func(*RectCopyOptions) RectCopyOptions
+
+func CopyWithLeft(left float32) RectCopyOption {
+	return func(o *RectCopyOptions) RectCopyOptions {
+		o.Left = left
+		return *o
+	}
+}
+
+func CopyWithTop(top float32) RectCopyOption {
+	return func(o *RectCopyOptions) RectCopyOptions {
+		o.Top = top
+		return *o
+	}
+}
+
+func CopyWithRight(right float32) RectCopyOption {
+	return func(o *RectCopyOptions) RectCopyOptions {
+		o.Right = right
+		return *o
+	}
+}
+
+func CopyWithBottom(bottom float32) RectCopyOption {
+	return func(o *RectCopyOptions) RectCopyOptions {
+		o.Bottom = bottom
+		return *o
+	}
+}
+
+// Copy creates a new rectangle with modified edges. Edges left at
+// floatutils.Float32Unspecified are taken from r.
+func (r Rect) Copy(opts ...RectCopyOption) Rect {
+	var o RectCopyOptions = RectCopyOptions{
+		Left:   floatutils.Float32Unspecified,
+		Top:    floatutils.Float32Unspecified,
+		Right:  floatutils.Float32Unspecified,
+		Bottom: floatutils.Float32Unspecified,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return NewRect(
+		floatutils.TakeOrElse(o.Left, r.Left()),
+		floatutils.TakeOrElse(o.Top, r.Top()),
+		floatutils.TakeOrElse(o.Right, r.Right()),
+		floatutils.TakeOrElse(o.Bottom, r.Bottom()),
+	)
+}
+
+// CopyRect - package-level copy function
+func CopyRect(r Rect, opts ...RectCopyOption) Rect {
+	return r.Copy(opts...)
+}
+
+// Geometry works edge by edge; an Unspecified edge stays Unspecified in
+// the result, and predicates report false for it.
+
+// Translate returns r moved by o.
+func (r Rect) Translate(o Offset) Rect {
+	return Rect{topLeft: r.topLeft.Plus(o), bottomRight: r.bottomRight.Plus(o)}
+}
+
+// Inflate returns r grown by delta on every side; a negative delta shrinks it.
+func (r Rect) Inflate(delta float32) Rect {
+	return NewRect(r.Left()-delta, r.Top()-delta, r.Right()+delta, r.Bottom()+delta)
+}
+
+// Intersect returns the overlap of r and other. Rectangles that do not
+// overlap give an empty result.
+func (r Rect) Intersect(other Rect) Rect {
+	return NewRect(
+		max(r.Left(), other.Left()),
+		max(r.Top(), other.Top()),
+		min(r.Right(), other.Right()),
+		min(r.Bottom(), other.Bottom()),
+	)
+}
+
+// Contains reports whether o lies within r, including the top and left
+// edges but excluding the bottom and right ones.
+func (r Rect) Contains(o Offset) bool {
+	return o.X() >= r.Left() && o.X() < r.Right() && o.Y() >= r.Top() && o.Y() < r.Bottom()
+}
+
+// IsEmpty reports whether r encloses no area; see Size.IsEmpty.
+func (r Rect) IsEmpty() bool {
+	return r.Size().IsEmpty()
+}
@@ -0,0 +1,71 @@
+package geom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRect(t *testing.T) {
+	r := NewRect(1, 2, 11, 22)
+	require.Equal(t, float32(1), r.Left())
+	require.Equal(t, float32(2), r.Top())
+	require.Equal(t, float32(11), r.Right())
+	require.Equal(t, float32(22), r.Bottom())
+	require.Equal(t, float32(10), r.Width())
+	require.Equal(t, float32(20), r.Height())
+	require.True(t, r.Size().Same(NewSize(10, 20)))
+	require.True(t, r.Center().Same(NewOffset(6, 12)))
+	require.True(t, r.TopLeft().Same(NewOffset(1, 2)))
+	require.True(t, r.BottomRight().Same(NewOffset(11, 22)))
+	require.True(t, RectFromOffsetSize(NewOffset(1, 2), NewSize(10, 20)).Same(r))
+
+	var zero Rect
+	require.True(t, zero.IsUnspecified())
+	require.True(t, zero.Same(RectUnspecified))
+	require.True(t, RectZero.IsFullySpecified())
+	require.True(t, RectUnspecified.IsUnspecified())
+	require.True(t, IsSpecifiedRect(NewRect(nan, nan, nan, 0)))
+	require.False(t, NewRect(nan, 0, 0, 0).IsFullySpecified())
+	require.True(t, r.IsFullySpecified())
+}
+
+func TestRectContract(t *testing.T) {
+	r := NewRect(1, 2, 11, 22)
+	partial := NewRect(nan, 0, nan, 5)
+
+	require.True(t, TakeOrElseRect(partial, r).Same(NewRect(1, 0, 11, 5)))
+	require.True(t, MergeRect(r, partial).Same(NewRect(1, 0, 11, 5)))
+	require.True(t, r.Merge(RectUnspecified).Same(r))
+
+	require.Equal(t, "Rect{Unspecified}", StringRect(RectUnspecified))
+	require.Equal(t, "Rect{left: Unspecified, top: 0, right: Unspecified, bottom: 5}", partial.String())
+
+	require.True(t, SameRect(partial, NewRect(nan, 0, nan, 5)))
+	require.True(t, SemanticEqualRect(r, NewRect(1, 2.0000002, 11, 22)))
+	require.False(t, SameRect(r, NewRect(1, 2.0000002, 11, 22)))
+	require.True(t, EqualRect(r, NewRect(1, 2.0000002, 11, 22)))
+	require.False(t, EqualRect(r, partial))
+
+	require.True(t, CopyRect(r, CopyWithLeft(0), CopyWithBottom(30)).Same(NewRect(0, 2, 11, 30)))
+	require.True(t, r.Copy(CopyWithTop(nan), CopyWithRight(5)).Same(NewRect(1, 2, 5, 22)))
+}
+
+func TestRectGeometry(t *testing.T) {
+	r := NewRect(0, 0, 10, 10)
+	require.True(t, r.Translate(NewOffset(5, -5)).Same(NewRect(5, -5, 15, 5)))
+	require.True(t, r.Inflate(1).Same(NewRect(-1, -1, 11, 11)))
+	require.True(t, r.Intersect(NewRect(5, 5, 20, 20)).Same(NewRect(5, 5, 10, 10)))
+	require.True(t, r.Intersect(NewRect(20, 20, 30, 30)).IsEmpty())
+
+	require.True(t, r.Contains(NewOffset(0, 0)))
+	require.True(t, r.Contains(NewOffset(9.5, 5)))
+	require.False(t, r.Contains(NewOffset(10, 5)))
+	require.False(t, r.Contains(NewOffset(nan, 5)))
+
+	open := NewRect(0, 0, nan, 10)
+	require.True(t, open.Translate(NewOffset(1, 1)).Same(NewRect(1, 1, nan, 11)))
+	require.True(t, open.Intersect(r).Same(NewRect(0, 0, nan, 10)))
+	require.False(t, open.Contains(NewOffset(1, 1)))
+	require.False(t, open.IsEmpty())
+}
@@ -0,0 +1,210 @@
+package geom
+
+import (
+	"github.com/zodimo/go-sentinel-helper/sentinel/floatutils"
+	"github.com/zodimo/go-sentinel-helper/sentinel/packed"
+)
+
+type sizeTag struct{}
+
+var (
+	sizeLayout = packed.NewLayout[sizeTag]()
+	sizeWidth  = sizeLayout.Float32("width")
+	sizeHeight = sizeLayout.Float32("height")
+)
+
+// Size is a 2-D extent. Uses Pattern 1-D from sentinel_pattern.md, like
+// Offset; the distinct type keeps sizes and positions from being mixed.
+// A layout pass can leave one dimension Unspecified, such as a width
+// fixed by a parent while the height is still measured. As with Offset,
+// the word is stored XORed with the Unspecified pattern, so the zero value
+// is SizeUnspecified.
+type Size struct {
+	bits uint64
+}
+
+// 1. Sentinel - SizeUnspecified (both components NaN), the zero value
+var SizeUnspecified = Size{}
+
+// SizeZero is the empty size.
+var SizeZero = NewSize(0, 0)
+
+// sizeUnspecifiedBits is the packed form of SizeUnspecified.
+var sizeUnspecifiedBits = sizeLayout.Unspecified().Raw()
+
+func sizeFromWord(w packed.Word[sizeTag]) Size {
+	return Size{bits: w.Raw() ^ sizeUnspecifiedBits}
+}
+
+func (s Size) word() packed.Word[sizeTag] {
+	return packed.WordFromRaw[sizeTag](s.bits ^ sizeUnspecifiedBits)
+}
+
+// NewSize packs width and height; either may be NaN to leave it Unspecified.
+func NewSize(width, height float32) Size {
+	return sizeFromWord(sizeHeight.Set(sizeWidth.Set(sizeLayout.Unspecified(), width), height))
+}
+
+// Width returns the horizontal extent, NaN if Unspecified.
+func (s Size) Width() float32 {
+	return sizeWidth.Get(s.word())
+}
+
+// Height returns the vertical extent, NaN if Unspecified.
+func (s Size) Height() float32 {
+	return sizeHeight.Get(s.word())
+}
+
+// 2. IsSpecified - at least one component is specified (method on value receiver)
+func (s Size) IsSpecified() bool {
+	return sizeLayout.IsSpecified(s.word())
+}
+
+// IsUnspecified - convenience predicate
+func (s Size) IsUnspecified() bool {
+	return !s.IsSpecified()
+}
+
+// SetUnspecified - stores SizeUnspecified in the receiver
+func (s *Size) SetUnspecified() {
+	*s = SizeUnspecified
+}
+
+// IsFullySpecified reports whether both components are specified.
+func (s Size) IsFullySpecified() bool {
+	return sizeLayout.IsFullySpecified(s.word())
+}
+
+// IsSpecifiedSize - package-level predicate
+func IsSpecifiedSize(s Size) bool {
+	return s.IsSpecified()
+}
+
+// 3. TakeOrElse - component-wise fallback: every Unspecified component of s comes from def
+func (s Size) TakeOrElse(def Size) Size {
+	return sizeFromWord(sizeLayout.TakeOrElse(s.word(), def.word()))
+}
+
+// TakeOrElseSize - package-level fallback
+func TakeOrElseSize(a, b Size) Size {
+	return a.TakeOrElse(b)
+}
+
+// 4. Merge - component-wise composition merge: every specified component of other wins
+func (s Size) Merge(other Size) Size {
+	return sizeFromWord(sizeLayout.Merge(s.word(), other.word()))
+}
+
+// MergeSize - package-level merge function
+func MergeSize(a, b Size) Size {
+	return a.Merge(b)
+}
+
+// 5. String - stringification (method on value receiver)
+func (s Size) String() string {
+	return "Size" + sizeLayout.String(s.word())
+}
+
+// StringSize - package-level string function
+func StringSize(s Size) string {
+	return s.String()
+}
+
+// 6. Coalesce - N/A for value types (no nil possible)
+
+// 7. Same - identity; any NaN payload counts as the same Unspecified component
+func (s Size) Same(other Size) bool {
+	return sizeLayout.Equal(s.word(), other.word())
+}
+
+// SameSize - package-level same function
+func SameSize(a, b Size) bool {
+	return a.Same(b)
+}
+
+// 8. SemanticEqual - every component equal within floatutils.Float32EqualityThreshold
+func (s Size) SemanticEqual(other Size) bool {
+	return floatutils.SemanticEqual(s.Width(), other.Width()) &&
+		floatutils.SemanticEqual(s.Height(), other.Height())
+}
+
+// SemanticEqualSize - package-level semantic equal function
+func SemanticEqualSize(a, b Size) bool {
+	return a.SemanticEqual(b)
+}
+
+// 9. Equal - equality check (combines Same and SemanticEqual)
+func (s Size) Equal(other Size) bool {
+	return s.Same(other) || s.SemanticEqual(other)
+}
+
+// EqualSize - package-level equal function
+func EqualSize(a, b Size) bool {
+	return a.Equal(b)
+}
+
+// 10. Copy - per-component overrides using float sentinels
+
+type SizeCopyOptions struct {
+	Width, Height float32
+}
+type SizeCopyOption func(*SizeCopyOptions) SizeCopyOptions
+
+func CopyWithWidth(width float32) SizeCopyOption {
+	return func(o *SizeCopyOptions) SizeCopyOptions {
+		o.Width = width
+		return *o
+	}
+}
+
+func CopyWithHeight(height float32) SizeCopyOption {
+	return func(o *SizeCopyOptions) SizeCopyOptions {
+		o.Height = height
+		return *o
+	}
+}
+
+// Copy creates a new size with modified components. Components left at
+// floatutils.Float32Unspecified are taken from s.
+func (s Size) Copy(opts ...SizeCopyOption) Size {
+	var c SizeCopyOptions = SizeCopyOptions{
+		Width:  floatutils.Float32Unspecified,
+		Height: floatutils.Float32Unspecified,
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return NewSize(
+		floatutils.TakeOrElse(c.Width, s.Width()),
+		floatutils.TakeOrElse(c.Height, s.Height()),
+	)
+}
+
+// CopySize - package-level copy function
+func CopySize(s Size, opts ...SizeCopyOption) Size {
+	return s.Copy(opts...)
+}
+
+// Arithmetic works component by component; an Unspecified component
+// stays Unspecified in the result.
+
+// Times scales both dimensions by factor.
+func (s Size) Times(factor float32) Size {
+	return NewSize(s.Width()*factor, s.Height()*factor)
+}
+
+// Div divides both dimensions by divisor.
+func (s Size) Div(divisor float32) Size {
+	return NewSize(s.Width()/divisor, s.Height()/divisor)
+}
+
+// Center returns the offset of the middle of s from its top-left corner.
+func (s Size) Center() Offset {
+	return NewOffset(s.Width()/2, s.Height()/2)
+}
+
+// IsEmpty reports whether s encloses no area. An Unspecified dimension
+// is not known to be empty, so it does not make s empty.
+func (s Size) IsEmpty() bool {
+	return s.Width() <= 0 || s.Height() <= 0
+}
@@ -0,0 +1,41 @@
+package geom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSizeContract(t *testing.T) {
+	s := NewSize(100, nan)
+	require.Equal(t, float32(100), s.Width())
+	require.True(t, IsSpecifiedSize(s))
+	require.False(t, s.IsFullySpecified())
+	require.True(t, SizeUnspecified.IsUnspecified())
+	var zero Size
+	require.True(t, zero.IsUnspecified())
+	require.True(t, zero.Same(SizeUnspecified))
+	require.True(t, SizeZero.IsFullySpecified())
+
+	require.True(t, TakeOrElseSize(s, NewSize(1, 50)).Same(NewSize(100, 50)))
+	require.True(t, MergeSize(NewSize(1, 50), s).Same(NewSize(100, 50)))
+	require.Equal(t, "Size{width: 100, height: Unspecified}", StringSize(s))
+	require.Equal(t, "Size{Unspecified}", SizeUnspecified.String())
+	require.True(t, EqualSize(NewSize(1, 2), NewSize(1.0000001, 2)))
+	require.False(t, EqualSize(s, NewSize(100, 0)))
+	require.True(t, CopySize(s, CopyWithHeight(8)).Same(NewSize(100, 8)))
+	require.True(t, s.Copy(CopyWithWidth(3)).Same(NewSize(3, nan)))
+}
+
+func TestSizeArithmetic(t *testing.T) {
+	s := NewSize(10, 4)
+	require.True(t, s.Times(2).Same(NewSize(20, 8)))
+	require.True(t, s.Div(2).Same(NewSize(5, 2)))
+	require.True(t, s.Center().Same(NewOffset(5, 2)))
+	require.True(t, NewSize(nan, 4).Times(2).Same(NewSize(nan, 8)))
+
+	require.False(t, s.IsEmpty())
+	require.True(t, NewSize(0, 4).IsEmpty())
+	require.True(t, NewSize(-1, 4).IsEmpty())
+	require.False(t, NewSize(nan, 4).IsEmpty(), "an Unspecified width is not known to be empty")
+}
@@ -0,0 +1,76 @@
+package intutils
+
+import "github.com/zodimo/go-sentinel-helper/internal/bulk"
+
+// Bulk operations apply the contract to whole columns of IntValue or
+// Int; both share the math.MinInt sentinel. internal/bulk describes the
+// shape of their loops and masks.
+
+// CountSpecified returns the number of specified values.
+func CountSpecified[T ~int](values []T) int {
+	var n uint64
+	for _, v := range values {
+		n += bulk.B2U(v != T(IntValueUnspecified))
+	}
+	return int(n)
+}
+
+// FillUnspecified replaces every Unspecified element of dst with def, in
+// place: the bulk form of TakeOrElseIntValue.
+func FillUnspecified[T ~int](dst []T, def T) {
+	for i, v := range dst {
+		if v == T(IntValueUnspecified) {
+			v = def
+		}
+		dst[i] = v
+	}
+}
+
+// MergeInto merges src into dst element by element, in place: the bulk
+// form of MergeIntValue, so specified elements of src win. Like copy it
+// handles min(len(dst), len(src)) elements and returns that count.
+func MergeInto[T ~int](dst, src []T) int {
+	n := min(len(dst), len(src))
+	dst, src = dst[:n], src[:n]
+	for i, v := range src {
+		d := dst[i]
+		if v != T(IntValueUnspecified) {
+			d = v
+		}
+		dst[i] = d
+	}
+	return n
+}
+
+// SpecifiedMask returns a bitmap of the specified elements of values,
+// one bit per element in (len(values)+63)/64 words. The layout matches
+// column.Bitmap, so the result can be used as one.
+func SpecifiedMask[T ~int](values []T) []uint64 {
+	mask := make([]uint64, bulk.MaskWords(len(values)))
+	for w := range mask {
+		chunk := values[w*64 : min(len(values), w*64+64)]
+		var bits uint64
+		for j, v := range chunk {
+			bits |= bulk.B2U(v != T(IntValueUnspecified)) << j
+		}
+		mask[w] = bits
+	}
+	return mask
+}
+
+// Compact removes the Unspecified elements of values in place, keeping
+// the order of the rest, and returns the shortened slice. Elements
+// between the new and the old length are set to Unspecified.
+func Compact[T ~int](values []T) []T {
+	var k uint64
+	for _, v := range values {
+		// Every element is written; only specified ones advance k, so
+		// the next write overwrites an Unspecified one.
+		values[k] = v
+		k += bulk.B2U(v != T(IntValueUnspecified))
+	}
+	for i := range values[k:] {
+		values[int(k)+i] = T(IntValueUnspecified)
+	}
+	return values[:k]
+}
@@ -0,0 +1,154 @@
+package intutils
+
+import (
+	"slices"
+	"testing"
+)
+
+const u = IntValueUnspecified
+
+func TestBulk(t *testing.T) {
+	values := []IntValue{1, u, 0, u, -1}
+	if got := CountSpecified(values); got != 3 {
+		t.Errorf("CountSpecified = %d, want 3", got)
+	}
+	if got := CountSpecified([]Int{1, IntNone}); got != 1 {
+		t.Errorf("CountSpecified([]Int) = %d, want 1", got)
+	}
+
+	filled := slices.Clone(values)
+	FillUnspecified(filled, 7)
+	if !slices.Equal(filled, []IntValue{1, 7, 0, 7, -1}) {
+		t.Errorf("FillUnspecified = %v", filled)
+	}
+
+	dst := []IntValue{1, 2, u, u}
+	if n := MergeInto(dst, []IntValue{u, 20, 30}); n != 3 {
+		t.Errorf("MergeInto n = %d, want 3", n)
+	}
+	if !slices.Equal(dst, []IntValue{1, 20, 30, u}) {
+		t.Errorf("MergeInto = %v", dst)
+	}
+
+	if mask := SpecifiedMask(values); !slices.Equal(mask, []uint64{0b10101}) {
+		t.Errorf("SpecifiedMask = %b", mask)
+	}
+	long := make([]IntValue, 65)
+	long[64] = u
+	if mask := SpecifiedMask(long); !slices.Equal(mask, []uint64{^uint64(0), 0}) {
+		t.Errorf("SpecifiedMask = %x", mask)
+	}
+
+	compacted := Compact(values)
+	if !slices.Equal(compacted, []IntValue{1, 0, -1}) || !slices.Equal(values[3:], []IntValue{u, u}) {
+		t.Errorf("Compact = %v, backing %v", compacted, values)
+	}
+}
+
+var benchIntValues = func() []IntValue {
+	s := make([]IntValue, 1<<16)
+	x := uint32(1)
+	for i := range s {
+		x ^= x << 13
+		x ^= x >> 17
+		x ^= x << 5
+		if x%7 == 0 {
+			s[i] = u
+		} else {
+			s[i] = IntValue(x)
+		}
+	}
+	return s
+}()
+
+func BenchmarkCountSpecified(b *testing.B) {
+	b.Run("bulk", func(b *testing.B) {
+		for b.Loop() {
+			CountSpecified(benchIntValues)
+		}
+	})
+	b.Run("scalar", func(b *testing.B) {
+		for b.Loop() {
+			n := 0
+			for _, v := range benchIntValues {
+				if IsSpecifiedIntValue(v) {
+					n++
+				}
+			}
+			_ = n
+		}
+	})
+}
+
+func BenchmarkFillUnspecified(b *testing.B) {
+	dst := make([]IntValue, len(benchIntValues))
+	b.Run("bulk", func(b *testing.B) {
+		for b.Loop() {
+			copy(dst, benchIntValues)
+			FillUnspecified(dst, 0)
+		}
+	})
+	b.Run("scalar", func(b *testing.B) {
+		for b.Loop() {
+			copy(dst, benchIntValues)
+			for i, v := range dst {
+				dst[i] = TakeOrElseIntValue(v, 0)
+			}
+		}
+	})
+}
+
+func BenchmarkMergeInto(b *testing.B) {
+	dst := make([]IntValue, len(benchIntValues))
+	b.Run("bulk", func(b *testing.B) {
+		for b.Loop() {
+			MergeInto(dst, benchIntValues)
+		}
+	})
+	b.Run("scalar", func(b *testing.B) {
+		for b.Loop() {
+			for i, v := range benchIntValues {
+				dst[i] = MergeIntValue(dst[i], v)
+			}
+		}
+	})
+}
+
+func BenchmarkSpecifiedMask(b *testing.B) {
+	b.Run("bulk", func(b *testing.B) {
+		for b.Loop() {
+			SpecifiedMask(benchIntValues)
+		}
+	})
+	b.Run("scalar", func(b *testing.B) {
+		for b.Loop() {
+			mask := make([]uint64, (len(benchIntValues)+63)/64)
+			for i, v := range benchIntValues {
+				if IsSpecifiedIntValue(v) {
+					mask[i/64] |= 1 << (i % 64)
+				}
+			}
+		}
+	})
+}
+
+func BenchmarkCompact(b *testing.B) {
+	values := make([]IntValue, len(benchIntValues))
+	b.Run("bulk", func(b *testing.B) {
+		for b.Loop() {
+			copy(values, benchIntValues)
+			Compact(values)
+		}
+	})
+	b.Run("scalar", func(b *testing.B) {
+		for b.Loop() {
+			copy(values, benchIntValues)
+			out := values[:0]
+			for _, v := range values {
+				if IsSpecifiedIntValue(v) {
+					out = append(out, v)
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,210 @@
+package intutils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"strconv"
+
+	"github.com/zodimo/go-sentinel-helper/internal/sentinellog"
+)
+
+// Int is the defined-type counterpart of IntValue. Unlike the alias it
+// carries methods, so it prints, marshals and logs sentinel-aware, and the
+// compiler rejects a raw int where an Int is expected.
+//
+// As with IntValue the sentinel is math.MinInt, so the zero value of Int is
+// a specified 0; use IntNone for "not set".
+type Int int
+
+// ErrReservedInt is returned when decoding math.MinInt, which is reserved
+// for IntNone.
+var ErrReservedInt = errors.New("intutils: math.MinInt is reserved for Unspecified")
+
+// 1. Sentinel - IntNone, named TNone like every defined-type counterpart;
+// see the contract note in ints.go.
+const IntNone Int = Int(IntValueUnspecified)
+
+// IntFrom converts an IntValue to an Int; IntValueUnspecified maps to
+// IntNone.
+func IntFrom(i IntValue) Int {
+	return Int(i)
+}
+
+// IntValue converts i back to the alias type.
+func (i Int) IntValue() IntValue {
+	return IntValue(i)
+}
+
+// IntOrElse returns i as an int, or def if i is Unspecified.
+func (i Int) IntOrElse(def int) int {
+	if i == IntNone {
+		return def
+	}
+	return int(i)
+}
+
+// 2. IsSpecified - predicate (method on value receiver)
+func (i Int) IsSpecified() bool {
+	return i != IntNone
+}
+
+// IsUnspecified - convenience predicate
+func (i Int) IsUnspecified() bool {
+	return i == IntNone
+}
+
+// SetUnspecified - stores IntNone in the receiver
+func (i *Int) SetUnspecified() {
+	*i = IntNone
+}
+
+// 3. TakeOrElse - returns i if specified, otherwise def
+func (i Int) TakeOrElse(def Int) Int {
+	if i != IntNone {
+		return i
+	}
+	return def
+}
+
+// 4. Merge - prefers other if specified
+func (i Int) Merge(other Int) Int {
+	if other != IntNone {
+		return other
+	}
+	return i
+}
+
+// 5. String - implements fmt.Stringer
+func (i Int) String() string {
+	if i == IntNone {
+		return "Int{Unspecified}"
+	}
+	return fmt.Sprintf("Int{%d}", int(i))
+}
+
+// 6. Coalesce - N/A for value types
+
+// 7. Same - identity
+func (i Int) Same(other Int) bool {
+	return i == other
+}
+
+// 8. SemanticEqual - for ints, the same as Same
+func (i Int) SemanticEqual(other Int) bool {
+	return i == other
+}
+
+// 9. Equal - equality check
+func (i Int) Equal(other Int) bool {
+	return i == other
+}
+
+// 10. Copy - identity for immutable value types
+func (i Int) Copy() Int {
+	return i
+}
+
+// Package-level forms of the contract, for use as function values.
+func IsSpecifiedInt(i Int) bool      { return i.IsSpecified() }
+func TakeOrElseInt(a, b Int) Int     { return a.TakeOrElse(b) }
+func MergeInt(a, b Int) Int          { return a.Merge(b) }
+func StringInt(i Int) string         { return i.String() }
+func SameInt(a, b Int) bool          { return a.Same(b) }
+func SemanticEqualInt(a, b Int) bool { return a.SemanticEqual(b) }
+func EqualInt(a, b Int) bool         { return a.Equal(b) }
+func CopyInt(i Int) Int              { return i.Copy() }
+
+// IsZero reports whether i is Unspecified, so that encoders honouring
+// `omitempty`/`omitzero` drop it. A specified 0 is kept.
+func (i Int) IsZero() bool {
+	return i == IntNone
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// Unspecified encodes as the empty string.
+func (i Int) MarshalText() ([]byte, error) {
+	if i == IntNone {
+		return []byte{}, nil
+	}
+	return strconv.AppendInt(nil, int64(i), 10), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// The empty string, "null" and "~" decode to Unspecified.
+func (i *Int) UnmarshalText(text []byte) error {
+	switch s := string(text); s {
+	case "", "null", "~":
+		*i = IntNone
+		return nil
+	default:
+		return i.parse(s)
+	}
+}
+
+// MarshalJSON implements json.Marshaler. Unspecified encodes as null.
+func (i Int) MarshalJSON() ([]byte, error) {
+	if i == IntNone {
+		return []byte("null"), nil
+	}
+	return strconv.AppendInt(nil, int64(i), 10), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. null decodes to Unspecified.
+func (i *Int) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*i = IntNone
+		return nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return err
+	}
+	return i.parse(n.String())
+}
+
+func (i *Int) parse(s string) error {
+	n, err := strconv.ParseInt(s, 10, strconv.IntSize)
+	if err != nil {
+		return err
+	}
+	if n == math.MinInt {
+		return ErrReservedInt
+	}
+	*i = Int(n)
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler without importing a YAML package.
+// Unspecified encodes as null.
+func (i Int) MarshalYAML() (any, error) {
+	if i == IntNone {
+		return nil, nil
+	}
+	return int(i), nil
+}
+
+// UnmarshalYAML implements the yaml.v2/v3 obsolete unmarshaler interface.
+// Null nodes never reach it, and a null or absent key leaves the field
+// as is, so start from IntNone rather than the zero value.
+func (i *Int) UnmarshalYAML(unmarshal func(any) error) error {
+	var n int
+	if err := unmarshal(&n); err != nil {
+		return err
+	}
+	if n == math.MinInt {
+		return ErrReservedInt
+	}
+	*i = Int(n)
+	return nil
+}
+
+// LogValue implements slog.LogValuer.
+func (i Int) LogValue() slog.Value {
+	if i == IntNone {
+		return sentinellog.Value()
+	}
+	return slog.IntValue(int(i))
+}
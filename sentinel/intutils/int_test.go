@@ -0,0 +1,122 @@
+package intutils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestInt_Contract(t *testing.T) {
+	var zero Int
+	if !zero.IsSpecified() {
+		t.Error("the zero Int is a specified 0")
+	}
+	if IntNone.IsSpecified() || !IntNone.IsUnspecified() {
+		t.Error("IntNone should be unspecified")
+	}
+	if got := IntNone.TakeOrElse(3); got != 3 {
+		t.Errorf("TakeOrElse = %v, want 3", got)
+	}
+	if got := MergeInt(1, IntNone); got != 1 {
+		t.Errorf("Merge(1, Unspecified) = %v, want 1", got)
+	}
+	if got := MergeInt(1, 0); got != 0 {
+		t.Errorf("Merge(1, 0) = %v, want 0", got)
+	}
+	if !EqualInt(2, 2) || SameInt(2, 3) || !SemanticEqualInt(IntNone, IntNone) {
+		t.Error("equality mismatch")
+	}
+	if got := IntNone.IntOrElse(-1); got != -1 {
+		t.Errorf("IntOrElse = %d, want -1", got)
+	}
+	if IntFrom(IntValueUnspecified) != IntNone || IntNone.IntValue() != IntValueUnspecified {
+		t.Error("conversions should map sentinel to sentinel")
+	}
+}
+
+func TestInt_String(t *testing.T) {
+	for v, want := range map[Int]string{42: "Int{42}", IntNone: "Int{Unspecified}"} {
+		if got := fmt.Sprint(v); got != want {
+			t.Errorf("Sprint(%d) = %q, want %q", int(v), got, want)
+		}
+	}
+	if got := fmt.Sprintf("%d", Int(7)); got != "7" {
+		t.Errorf("%%d = %q, want 7", got)
+	}
+}
+
+type intDoc struct {
+	A Int `json:"a" yaml:"a"`
+	B Int `json:"b,omitzero" yaml:"b,omitempty"`
+	C Int `json:"c" yaml:"c"`
+}
+
+func TestInt_JSON(t *testing.T) {
+	out, err := json.Marshal(intDoc{A: 0, B: IntNone, C: IntNone})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"a":0,"c":null}`; string(out) != want {
+		t.Errorf("Marshal = %s, want %s", out, want)
+	}
+
+	doc := intDoc{B: 5}
+	if err := json.Unmarshal([]byte(`{"a":-3,"b":null}`), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc.A != -3 || doc.B != IntNone {
+		t.Errorf("Unmarshal = %+v", doc)
+	}
+
+	var i Int
+	err = json.Unmarshal(fmt.Appendf(nil, "%d", math.MinInt), &i)
+	if !errors.Is(err, ErrReservedInt) {
+		t.Errorf("decoding math.MinInt: err = %v, want ErrReservedInt", err)
+	}
+	if json.Unmarshal([]byte(`1.5`), &i) == nil {
+		t.Error("decoding 1.5 should fail")
+	}
+}
+
+func TestInt_Text(t *testing.T) {
+	for _, v := range []Int{0, -12, IntNone} {
+		text, err := v.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var back Int
+		if err := back.UnmarshalText(text); err != nil || back != v {
+			t.Errorf("text round trip of %v = %v, %v", v, back, err)
+		}
+	}
+}
+
+func TestInt_YAML(t *testing.T) {
+	out, err := yaml.Marshal(intDoc{A: 1, B: IntNone, C: IntNone})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "a: 1\nc: null\n"; string(out) != want {
+		t.Errorf("Marshal = %q, want %q", out, want)
+	}
+	doc := intDoc{C: IntNone}
+	if err := yaml.Unmarshal([]byte("a: 2\nb: 3\n"), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc.A != 2 || doc.B != 3 || doc.C != IntNone {
+		t.Errorf("Unmarshal = %+v", doc)
+	}
+}
+
+func TestInt_LogValue(t *testing.T) {
+	if got := IntNone.LogValue().Resolve().String(); got != "<unspecified>" {
+		t.Errorf("LogValue = %q, want <unspecified>", got)
+	}
+	if got := Int(4).LogValue().Int64(); got != 4 {
+		t.Errorf("LogValue = %d, want 4", got)
+	}
+}
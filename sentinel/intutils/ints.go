@@ -20,6 +20,11 @@ type IntValue = int
 //   func EqualT(a, b *T) bool      // Package-level predicate (never method on *T)
 //   func CopyT(a, b *T) *T      // Package-level predicate (never method on *T)
 // END_CONTRACT
+//
+// Defined-type counterparts of an alias, such as Int for IntValue and
+// stringutils.Str for StringValue, name their sentinel TNone instead of
+// TUnspecified: IntUnspecified and StringUnspecified already exist as
+// deprecated names for the alias sentinels.
 
 // 1. Sentinel - IntValueUnspecified
 // IntValue is the type for sentinel int pattern.
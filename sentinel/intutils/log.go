@@ -0,0 +1,21 @@
+package intutils
+
+import (
+	"log/slog"
+
+	"github.com/zodimo/go-sentinel-helper/internal/sentinellog"
+)
+
+// LogValueIntValue returns i as a slog.Value. IntValueUnspecified logs as
+// "<unspecified>" instead of math.MinInt.
+func LogValueIntValue(i IntValue) slog.Value {
+	if i == IntValueUnspecified {
+		return sentinellog.Value()
+	}
+	return slog.IntValue(i)
+}
+
+// IntValueAttr returns a slog.Attr for i; see LogValueIntValue.
+func IntValueAttr(key string, i IntValue) slog.Attr {
+	return slog.Attr{Key: key, Value: LogValueIntValue(i)}
+}
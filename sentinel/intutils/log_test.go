@@ -0,0 +1,15 @@
+package intutils
+
+import (
+	"log/slog"
+	"testing"
+)
+
+func TestIntValueAttr(t *testing.T) {
+	if got := IntValueAttr("n", 42).Value.Resolve(); got.Kind() != slog.KindInt64 || got.Int64() != 42 {
+		t.Errorf("IntValueAttr(42) = %v, want 42", got)
+	}
+	if got := IntValueAttr("n", IntValueUnspecified).Value.Resolve().String(); got != "<unspecified>" {
+		t.Errorf("IntValueAttr(Unspecified) = %q, want <unspecified>", got)
+	}
+}
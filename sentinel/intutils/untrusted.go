@@ -0,0 +1,55 @@
+package intutils
+
+import (
+	"math"
+	"strconv"
+)
+
+// FromUntrusted converts external input to an IntValue, rejecting
+// math.MinInt, which would otherwise silently read as IntValueUnspecified.
+func FromUntrusted(n int) (IntValue, error) {
+	if n == IntValueUnspecified {
+		return IntValueUnspecified, ErrReservedInt
+	}
+	return n, nil
+}
+
+// FromUntrustedInt64 is FromUntrusted for int64 input, such as a decoded
+// protobuf or database column. Values outside the int range fail with
+// strconv.ErrRange.
+func FromUntrustedInt64(n int64) (IntValue, error) {
+	if n < math.MinInt || n > math.MaxInt {
+		return IntValueUnspecified, strconv.ErrRange
+	}
+	return FromUntrusted(int(n))
+}
+
+// ParseUntrusted parses a base-10 integer like strconv.Atoi, then applies
+// FromUntrusted.
+func ParseUntrusted(s string) (IntValue, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return IntValueUnspecified, err
+	}
+	return FromUntrusted(n)
+}
+
+// SanitizeIntValue saturates instead of rejecting: math.MinInt becomes
+// math.MinInt+1, the smallest specified IntValue.
+func SanitizeIntValue(n int) IntValue {
+	if n == IntValueUnspecified {
+		return IntValueUnspecified + 1
+	}
+	return n
+}
+
+// IntFromUntrusted is FromUntrusted for Int.
+func IntFromUntrusted(n int) (Int, error) {
+	v, err := FromUntrusted(n)
+	return Int(v), err
+}
+
+// SanitizeInt is SanitizeIntValue for Int.
+func SanitizeInt(n int) Int {
+	return Int(SanitizeIntValue(n))
+}
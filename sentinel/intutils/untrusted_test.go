@@ -0,0 +1,47 @@
+package intutils
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"testing"
+)
+
+func TestFromUntrusted(t *testing.T) {
+	if v, err := FromUntrusted(0); err != nil || v != 0 {
+		t.Errorf("FromUntrusted(0) = %v, %v", v, err)
+	}
+	if _, err := FromUntrusted(math.MinInt); !errors.Is(err, ErrReservedInt) {
+		t.Errorf("FromUntrusted(MinInt) err = %v, want ErrReservedInt", err)
+	}
+	if _, err := IntFromUntrusted(math.MinInt); !errors.Is(err, ErrReservedInt) {
+		t.Errorf("IntFromUntrusted(MinInt) err = %v, want ErrReservedInt", err)
+	}
+	if v, err := FromUntrustedInt64(-7); err != nil || v != -7 {
+		t.Errorf("FromUntrustedInt64(-7) = %v, %v", v, err)
+	}
+	if _, err := FromUntrustedInt64(math.MinInt64); err == nil {
+		t.Error("FromUntrustedInt64(MinInt64) should fail")
+	}
+}
+
+func TestParseUntrusted(t *testing.T) {
+	if v, err := ParseUntrusted("42"); err != nil || v != 42 {
+		t.Errorf("ParseUntrusted(42) = %v, %v", v, err)
+	}
+	if _, err := ParseUntrusted(strconv.Itoa(math.MinInt)); !errors.Is(err, ErrReservedInt) {
+		t.Errorf("ParseUntrusted(MinInt) err = %v, want ErrReservedInt", err)
+	}
+	if _, err := ParseUntrusted("x"); err == nil {
+		t.Error("ParseUntrusted(x) should fail")
+	}
+}
+
+func TestSanitizeIntValue(t *testing.T) {
+	if got := SanitizeIntValue(math.MinInt); !IsSpecifiedIntValue(got) || got != math.MinInt+1 {
+		t.Errorf("SanitizeIntValue(MinInt) = %d, want MinInt+1", got)
+	}
+	if got := SanitizeInt(5); got != 5 {
+		t.Errorf("SanitizeInt(5) = %d, want 5", got)
+	}
+}
@@ -0,0 +1,171 @@
+// Package maputils merges maps key by key across config layers.
+//
+// A nil map is Unspecified ("inherit the whole map") and is distinct from
+// an empty map ("explicitly no entries"). Within a map, a key whose value
+// is Unspecified inherits the lower layer's value through the per-value
+// merge function, and a key holding a tombstone deletes it.
+package maputils
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/zodimo/go-sentinel-helper/sentinel/stringutils"
+)
+
+// StringTombstone marks a key for deletion in a map[K]string layer.
+// Like stringutils.StringValueUnspecified it starts with a NUL byte, so it
+// cannot collide with printable input.
+const StringTombstone = "\x00deleted"
+
+// IsStringTombstone reports whether s is StringTombstone.
+func IsStringTombstone(s string) bool {
+	return s == StringTombstone
+}
+
+// 1. Sentinel - the nil map. It reads as empty and panics on write, so the
+// sentinel can never be mutated into a populated map.
+func Unspecified[K comparable, V any]() map[K]V {
+	return nil
+}
+
+// 2. IsSpecified - a non-nil map, even an empty one, is specified
+func IsSpecifiedMap[K comparable, V any](m map[K]V) bool {
+	return m != nil
+}
+
+// IsUnspecifiedMap - convenience predicate
+func IsUnspecifiedMap[K comparable, V any](m map[K]V) bool {
+	return m == nil
+}
+
+// 3. TakeOrElse - 2-param fallback
+func TakeOrElseMap[K comparable, V any](m, def map[K]V) map[K]V {
+	if IsSpecifiedMap(m) {
+		return m
+	}
+	return def
+}
+
+// 4. Merge - key-wise composition merge.
+// Keys present on one side only are kept; keys present on both sides are
+// combined with merge, e.g. intutils.MergeIntValue, so an Unspecified
+// incoming value inherits. If either map is Unspecified a copy of the
+// other is returned; the result never aliases a or b.
+func MergeMap[K comparable, V any](a, b map[K]V, merge func(a, b V) V) map[K]V {
+	return MergeMapWithTombstone(a, b, merge, nil)
+}
+
+// MergeMapWithTombstone is MergeMap where a value of b for which
+// isTombstone reports true deletes its key from the result.
+// Tombstones are consumed by the merge and never appear in its result.
+func MergeMapWithTombstone[K comparable, V any](a, b map[K]V, merge func(a, b V) V, isTombstone func(V) bool) map[K]V {
+	if IsUnspecifiedMap(b) {
+		return maps.Clone(a)
+	}
+	if IsUnspecifiedMap(a) && isTombstone == nil {
+		return maps.Clone(b)
+	}
+	out := make(map[K]V, len(a)+len(b))
+	maps.Copy(out, a)
+	for k, v := range b {
+		if isTombstone != nil && isTombstone(v) {
+			delete(out, k)
+			continue
+		}
+		if cur, ok := out[k]; ok {
+			out[k] = merge(cur, v)
+		} else {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// MergeMapFunc binds merge so the result can be passed wherever a
+// two-argument merge is expected, e.g. as the merge of a nested map.
+func MergeMapFunc[K comparable, V any](merge func(a, b V) V) func(a, b map[K]V) map[K]V {
+	return func(a, b map[K]V) map[K]V {
+		return MergeMap(a, b, merge)
+	}
+}
+
+// MergeStringMap merges label/annotation style maps: values use
+// stringutils.MergeString semantics and StringTombstone deletes a key.
+func MergeStringMap[K comparable](a, b map[K]string) map[K]string {
+	return MergeMapWithTombstone(a, b, stringutils.MergeString, IsStringTombstone)
+}
+
+// SortedKeys returns the keys of m in ascending order.
+func SortedKeys[K cmp.Ordered, V any](m map[K]V) []K {
+	return slices.Sorted(maps.Keys(m))
+}
+
+// 5. String - stringification with keys in ascending order, so the output
+// is deterministic; str formats a single value.
+func StringMap[K cmp.Ordered, V any](m map[K]V, str func(V) string) string {
+	if IsUnspecifiedMap(m) {
+		return "Map{Unspecified}"
+	}
+	var sb strings.Builder
+	sb.WriteString("Map{")
+	for i, k := range SortedKeys(m) {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "%v: %s", k, str(m[k]))
+	}
+	sb.WriteByte('}')
+	return sb.String()
+}
+
+// 6. Coalesce - nil coalescing
+func CoalesceMap[K comparable, V any](m, def map[K]V) map[K]V {
+	if m == nil {
+		return def
+	}
+	return m
+}
+
+// 7. Same - identity: both Unspecified or the same map header.
+func SameMap[K comparable, V any](a, b map[K]V) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return reflect.ValueOf(a).UnsafePointer() == reflect.ValueOf(b).UnsafePointer()
+}
+
+// 8. SemanticEqual - same key set and values equal under eq,
+// e.g. floatutils.SemanticEqual[float64].
+func SemanticEqualMap[K comparable, V any](a, b map[K]V, eq func(a, b V) bool) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return maps.EqualFunc(a, b, eq)
+}
+
+// 9. Equal - equality check (combines Same and SemanticEqual)
+func EqualMap[K comparable, V any](a, b map[K]V, eq func(a, b V) bool) bool {
+	return SameMap(a, b) || SemanticEqualMap(a, b, eq)
+}
+
+// 10. Copy - deep copy using each value's own Copy function, e.g.
+// protobufwrapper.CopyStringValue. A nil copyVal copies values by value.
+// Unspecified copies to Unspecified.
+func CopyMap[K comparable, V any](m map[K]V, copyVal func(V) V) map[K]V {
+	if IsUnspecifiedMap(m) {
+		return nil
+	}
+	out := make(map[K]V, len(m))
+	for k, v := range m {
+		if copyVal != nil {
+			v = copyVal(v)
+		}
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,150 @@
+package maputils
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zodimo/go-sentinel-helper/sentinel/floatutils"
+	"github.com/zodimo/go-sentinel-helper/sentinel/intutils"
+	"github.com/zodimo/go-sentinel-helper/sentinel/protobufwrapper"
+	"github.com/zodimo/go-sentinel-helper/sentinel/stringutils"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestUnspecifiedMap(t *testing.T) {
+	require.Nil(t, Unspecified[string, int]())
+	require.True(t, IsUnspecifiedMap(Unspecified[string, int]()))
+	require.False(t, IsSpecifiedMap[string, int](nil))
+	require.True(t, IsSpecifiedMap(map[string]int{}))
+	require.True(t, IsSpecifiedMap(map[string]int{"a": 1}))
+}
+
+func TestTakeOrElseMap(t *testing.T) {
+	def := map[string]int{"d": 1}
+	require.Equal(t, def, TakeOrElseMap(nil, def))
+	require.Equal(t, map[string]int{}, TakeOrElseMap(map[string]int{}, def))
+}
+
+func TestMergeMap(t *testing.T) {
+	base := map[string]int{"a": 1, "b": 2, "c": 3}
+	layer := map[string]int{"b": 20, "c": intutils.IntValueUnspecified, "d": 4}
+
+	got := MergeMap(base, layer, intutils.MergeIntValue)
+	require.Equal(t, map[string]int{"a": 1, "b": 20, "c": 3, "d": 4}, got)
+	require.Equal(t, map[string]int{"a": 1, "b": 2, "c": 3}, base, "inputs are not mutated")
+
+	require.Equal(t, base, MergeMap(base, nil, intutils.MergeIntValue))
+	require.Equal(t, layer, MergeMap(nil, layer, intutils.MergeIntValue))
+	require.Nil(t, MergeMap[string, int](nil, nil, intutils.MergeIntValue))
+
+	// An empty layer is specified but changes nothing.
+	require.Equal(t, base, MergeMap(base, map[string]int{}, intutils.MergeIntValue))
+}
+
+func TestMergeMapDoesNotAlias(t *testing.T) {
+	base := map[string]int{"a": 1}
+	got := MergeMap(base, nil, intutils.MergeIntValue)
+	got["a"] = 9
+	require.Equal(t, 1, base["a"])
+
+	layer := map[string]int{"b": 2}
+	got = MergeMap(nil, layer, intutils.MergeIntValue)
+	got["b"] = 9
+	require.Equal(t, 2, layer["b"])
+
+	labels := map[string]string{"env": "prod"}
+	MergeStringMap(labels, nil)["env"] = "dev"
+	require.Equal(t, "prod", labels["env"])
+}
+
+func TestMergeMapNested(t *testing.T) {
+	merge := MergeMapFunc[string](intutils.MergeIntValue)
+	a := map[string]map[string]int{"x": {"a": 1}, "y": {"b": 2}}
+	b := map[string]map[string]int{"x": {"a": 10, "c": 3}, "y": nil}
+
+	got := MergeMap(a, b, merge)
+	require.Equal(t, map[string]map[string]int{"x": {"a": 10, "c": 3}, "y": {"b": 2}}, got)
+}
+
+func TestMergeMapWithTombstone(t *testing.T) {
+	base := map[string]string{"team": "core", "env": "prod", "tier": "1"}
+	layer := map[string]string{
+		"env":  "staging",
+		"tier": StringTombstone,
+		"team": stringutils.StringValueUnspecified,
+		"gone": StringTombstone,
+	}
+
+	got := MergeStringMap(base, layer)
+	require.Equal(t, map[string]string{"team": "core", "env": "staging"}, got)
+
+	// Tombstones against an Unspecified base are consumed, not kept.
+	onlyLayer := MergeStringMap(nil, layer)
+	require.NotContains(t, onlyLayer, "tier")
+	require.Equal(t, "staging", onlyLayer["env"])
+
+	require.True(t, IsStringTombstone(StringTombstone))
+	require.False(t, IsStringTombstone("deleted"))
+}
+
+func TestStringMap(t *testing.T) {
+	require.Equal(t, "Map{Unspecified}", StringMap[string, int](nil, strconv.Itoa))
+	require.Equal(t, "Map{}", StringMap(map[string]int{}, strconv.Itoa))
+
+	m := map[string]int{"c": 3, "a": 1, "b": intutils.IntValueUnspecified}
+	want := "Map{a: IntValue{1}, b: IntValue{Unspecified}, c: IntValue{3}}"
+	for range 10 {
+		require.Equal(t, want, StringMap(m, intutils.StringIntValue))
+	}
+	require.Equal(t, []string{"a", "b", "c"}, SortedKeys(m))
+}
+
+func TestCoalesceMap(t *testing.T) {
+	def := map[string]int{"d": 1}
+	require.Equal(t, def, CoalesceMap(nil, def))
+	require.Equal(t, map[string]int{}, CoalesceMap(map[string]int{}, def))
+}
+
+func TestMapEquality(t *testing.T) {
+	eq := floatutils.SemanticEqual[float64]
+	a := map[string]float64{"x": 1}
+	tests := []struct {
+		name           string
+		a, b           map[string]float64
+		same, semantic bool
+	}{
+		{"both unspecified", nil, nil, true, true},
+		{"unspecified and empty", nil, map[string]float64{}, false, false},
+		{"identical", a, a, true, true},
+		{"within epsilon", a, map[string]float64{"x": 1 + 1e-12}, false, true},
+		{"different keys", a, map[string]float64{"y": 1}, false, false},
+		{"different values", a, map[string]float64{"x": 2}, false, false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.same, SameMap(tc.a, tc.b))
+			require.Equal(t, tc.semantic, SemanticEqualMap(tc.a, tc.b, eq))
+			require.Equal(t, tc.same || tc.semantic, EqualMap(tc.a, tc.b, eq))
+		})
+	}
+}
+
+func TestCopyMap(t *testing.T) {
+	src := map[string]*wrapperspb.StringValue{
+		"a": wrapperspb.String("x"),
+		"b": protobufwrapper.StringValueUnspecified,
+	}
+	dst := CopyMap(src, protobufwrapper.CopyStringValue)
+	require.NotSame(t, src["a"], dst["a"])
+	require.Equal(t, "x", dst["a"].GetValue())
+	require.Same(t, protobufwrapper.StringValueUnspecified, dst["b"])
+
+	ints := map[string]int{"a": 1}
+	shallow := CopyMap(ints, nil)
+	shallow["a"] = 2
+	require.Equal(t, 1, ints["a"])
+
+	require.Nil(t, CopyMap[string, int](nil, nil))
+	require.NotNil(t, CopyMap(map[string]int{}, nil))
+}
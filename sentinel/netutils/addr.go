@@ -0,0 +1,92 @@
+// Package netutils provides sentinel helpers for network addresses, where
+// "not configured" differs from a configured 0.0.0.0 or ::.
+//
+// netip.Addr, AddrPort and Prefix follow Pattern 1-D: their zero values
+// are invalid, and that invalid value is Unspecified. Do not confuse this
+// with netip.Addr.IsUnspecified, which reports the 0.0.0.0 / :: address
+// and is a specified value here. *url.URL follows Pattern 1-C.
+package netutils
+
+import (
+	"fmt"
+	"net/netip"
+)
+
+// 1. Sentinel - AddrUnspecified, the zero (invalid) netip.Addr
+var AddrUnspecified = netip.Addr{}
+
+// 2. IsSpecified - predicate (package-level function)
+func IsSpecifiedAddr(a netip.Addr) bool {
+	return a.IsValid()
+}
+
+// IsUnspecifiedAddr - convenience predicate
+func IsUnspecifiedAddr(a netip.Addr) bool {
+	return !a.IsValid()
+}
+
+// 3. TakeOrElse - 2-param fallback (package-level function)
+func TakeOrElseAddr(a, b netip.Addr) netip.Addr {
+	if a.IsValid() {
+		return a
+	}
+	return b
+}
+
+// 4. Merge - composition merge (package-level function)
+// Prefers incoming specified values over current values
+func MergeAddr(a, b netip.Addr) netip.Addr {
+	if b.IsValid() {
+		return b
+	}
+	return a
+}
+
+// 5. String - stringification (package-level function)
+func StringAddr(a netip.Addr) string {
+	if !a.IsValid() {
+		return "Addr{Unspecified}"
+	}
+	return fmt.Sprintf("Addr{%s}", a)
+}
+
+// 6. Coalesce - N/A for value types (no nil possible)
+
+// 7. Same - identity, including IPv4-mapped form and zone
+func SameAddr(a, b netip.Addr) bool {
+	return a == b
+}
+
+// 8. SemanticEqual - equal after unmapping, so ::ffff:10.0.0.1 equals
+// 10.0.0.1
+func SemanticEqualAddr(a, b netip.Addr) bool {
+	return a.Unmap() == b.Unmap()
+}
+
+// 9. Equal - equality check (package-level function)
+func EqualAddr(a, b netip.Addr) bool {
+	return SameAddr(a, b) || SemanticEqualAddr(a, b)
+}
+
+// 10. Copy - identity for immutable value types (package-level function)
+func CopyAddr(a netip.Addr) netip.Addr {
+	return a
+}
+
+// FormatAddr formats a; Unspecified formats as the empty string rather
+// than netip's "invalid IP".
+func FormatAddr(a netip.Addr) string {
+	if !a.IsValid() {
+		return ""
+	}
+	return a.String()
+}
+
+// ParseAddr is the inverse of FormatAddr: the empty string parses as
+// AddrUnspecified, anything else as netip.ParseAddr does.
+func ParseAddr(s string) (netip.Addr, error) {
+	if s == "" {
+		return AddrUnspecified, nil
+	}
+	return netip.ParseAddr(s)
+}
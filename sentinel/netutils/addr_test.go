@@ -0,0 +1,84 @@
+package netutils
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddr(t *testing.T) {
+	any4 := netip.MustParseAddr("0.0.0.0")
+	require.True(t, IsSpecifiedAddr(any4), "0.0.0.0 is a configured address")
+	require.True(t, IsUnspecifiedAddr(AddrUnspecified))
+	require.Equal(t, any4, TakeOrElseAddr(any4, netip.IPv6Loopback()))
+	require.Equal(t, any4, TakeOrElseAddr(AddrUnspecified, any4))
+	require.Equal(t, any4, MergeAddr(netip.IPv6Loopback(), any4))
+	require.Equal(t, any4, MergeAddr(any4, AddrUnspecified))
+	require.Equal(t, "Addr{0.0.0.0}", StringAddr(any4))
+	require.Equal(t, "Addr{Unspecified}", StringAddr(AddrUnspecified))
+	require.Equal(t, any4, CopyAddr(any4))
+
+	v4, mapped := netip.MustParseAddr("10.0.0.1"), netip.MustParseAddr("::ffff:10.0.0.1")
+	require.False(t, SameAddr(v4, mapped))
+	require.True(t, SemanticEqualAddr(v4, mapped))
+	require.True(t, EqualAddr(v4, mapped))
+	require.False(t, EqualAddr(AddrUnspecified, any4))
+}
+
+func TestAddrRoundTrip(t *testing.T) {
+	for _, a := range []netip.Addr{AddrUnspecified, netip.IPv6Unspecified(), netip.MustParseAddr("fe80::1%eth0")} {
+		back, err := ParseAddr(FormatAddr(a))
+		require.NoError(t, err)
+		require.Equal(t, a, back)
+	}
+	_, err := ParseAddr("nope")
+	require.Error(t, err)
+}
+
+func TestAddrPort(t *testing.T) {
+	ap := netip.MustParseAddrPort("0.0.0.0:0")
+	require.True(t, IsSpecifiedAddrPort(ap))
+	require.True(t, IsUnspecifiedAddrPort(AddrPortUnspecified))
+	require.Equal(t, ap, TakeOrElseAddrPort(AddrPortUnspecified, ap))
+	require.Equal(t, ap, MergeAddrPort(netip.MustParseAddrPort("1.2.3.4:80"), ap))
+	require.Equal(t, ap, MergeAddrPort(ap, AddrPortUnspecified))
+	require.Equal(t, "AddrPort{0.0.0.0:0}", StringAddrPort(ap))
+	require.Equal(t, "AddrPort{Unspecified}", StringAddrPort(AddrPortUnspecified))
+	require.Equal(t, ap, CopyAddrPort(ap))
+
+	v4, mapped := netip.MustParseAddrPort("10.0.0.1:80"), netip.MustParseAddrPort("[::ffff:10.0.0.1]:80")
+	require.False(t, SameAddrPort(v4, mapped))
+	require.True(t, EqualAddrPort(v4, mapped))
+	require.False(t, EqualAddrPort(v4, netip.MustParseAddrPort("10.0.0.1:81")))
+
+	for _, a := range []netip.AddrPort{AddrPortUnspecified, v4, mapped} {
+		back, err := ParseAddrPort(FormatAddrPort(a))
+		require.NoError(t, err)
+		require.Equal(t, a, back)
+	}
+}
+
+func TestPrefix(t *testing.T) {
+	all := netip.MustParsePrefix("0.0.0.0/0")
+	require.True(t, IsSpecifiedPrefix(all))
+	require.True(t, IsUnspecifiedPrefix(PrefixUnspecified))
+	require.Equal(t, all, TakeOrElsePrefix(PrefixUnspecified, all))
+	require.Equal(t, all, MergePrefix(netip.MustParsePrefix("10.0.0.0/8"), all))
+	require.Equal(t, all, MergePrefix(all, PrefixUnspecified))
+	require.Equal(t, "Prefix{0.0.0.0/0}", StringPrefix(all))
+	require.Equal(t, "Prefix{Unspecified}", StringPrefix(PrefixUnspecified))
+	require.Equal(t, all, CopyPrefix(all))
+
+	host, network := netip.MustParsePrefix("10.1.2.3/8"), netip.MustParsePrefix("10.0.0.0/8")
+	require.False(t, SamePrefix(host, network))
+	require.True(t, EqualPrefix(host, network))
+	require.False(t, EqualPrefix(network, netip.MustParsePrefix("10.0.0.0/16")))
+	require.False(t, EqualPrefix(PrefixUnspecified, all))
+
+	for _, p := range []netip.Prefix{PrefixUnspecified, host} {
+		back, err := ParsePrefix(FormatPrefix(p))
+		require.NoError(t, err)
+		require.Equal(t, p, back)
+	}
+}
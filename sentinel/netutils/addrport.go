@@ -0,0 +1,83 @@
+package netutils
+
+import (
+	"fmt"
+	"net/netip"
+)
+
+// 1. Sentinel - AddrPortUnspecified, the zero (invalid) netip.AddrPort
+var AddrPortUnspecified = netip.AddrPort{}
+
+// 2. IsSpecified - predicate (package-level function)
+func IsSpecifiedAddrPort(ap netip.AddrPort) bool {
+	return ap.IsValid()
+}
+
+// IsUnspecifiedAddrPort - convenience predicate
+func IsUnspecifiedAddrPort(ap netip.AddrPort) bool {
+	return !ap.IsValid()
+}
+
+// 3. TakeOrElse - 2-param fallback (package-level function)
+func TakeOrElseAddrPort(a, b netip.AddrPort) netip.AddrPort {
+	if a.IsValid() {
+		return a
+	}
+	return b
+}
+
+// 4. Merge - composition merge (package-level function)
+// Prefers incoming specified values over current values
+func MergeAddrPort(a, b netip.AddrPort) netip.AddrPort {
+	if b.IsValid() {
+		return b
+	}
+	return a
+}
+
+// 5. String - stringification (package-level function)
+func StringAddrPort(ap netip.AddrPort) string {
+	if !ap.IsValid() {
+		return "AddrPort{Unspecified}"
+	}
+	return fmt.Sprintf("AddrPort{%s}", ap)
+}
+
+// 6. Coalesce - N/A for value types (no nil possible)
+
+// 7. Same - identity (package-level function)
+func SameAddrPort(a, b netip.AddrPort) bool {
+	return a == b
+}
+
+// 8. SemanticEqual - same port and SemanticEqualAddr addresses
+func SemanticEqualAddrPort(a, b netip.AddrPort) bool {
+	return a.Port() == b.Port() && SemanticEqualAddr(a.Addr(), b.Addr())
+}
+
+// 9. Equal - equality check (package-level function)
+func EqualAddrPort(a, b netip.AddrPort) bool {
+	return SameAddrPort(a, b) || SemanticEqualAddrPort(a, b)
+}
+
+// 10. Copy - identity for immutable value types (package-level function)
+func CopyAddrPort(ap netip.AddrPort) netip.AddrPort {
+	return ap
+}
+
+// FormatAddrPort formats ap; Unspecified formats as the empty string.
+func FormatAddrPort(ap netip.AddrPort) string {
+	if !ap.IsValid() {
+		return ""
+	}
+	return ap.String()
+}
+
+// ParseAddrPort is the inverse of FormatAddrPort: the empty string parses
+// as AddrPortUnspecified, anything else as netip.ParseAddrPort does.
+func ParseAddrPort(s string) (netip.AddrPort, error) {
+	if s == "" {
+		return AddrPortUnspecified, nil
+	}
+	return netip.ParseAddrPort(s)
+}
@@ -0,0 +1,84 @@
+package netutils
+
+import (
+	"fmt"
+	"net/netip"
+)
+
+// 1. Sentinel - PrefixUnspecified, the zero (invalid) netip.Prefix
+var PrefixUnspecified = netip.Prefix{}
+
+// 2. IsSpecified - predicate (package-level function)
+func IsSpecifiedPrefix(p netip.Prefix) bool {
+	return p.IsValid()
+}
+
+// IsUnspecifiedPrefix - convenience predicate
+func IsUnspecifiedPrefix(p netip.Prefix) bool {
+	return !p.IsValid()
+}
+
+// 3. TakeOrElse - 2-param fallback (package-level function)
+func TakeOrElsePrefix(a, b netip.Prefix) netip.Prefix {
+	if a.IsValid() {
+		return a
+	}
+	return b
+}
+
+// 4. Merge - composition merge (package-level function)
+// Prefers incoming specified values over current values
+func MergePrefix(a, b netip.Prefix) netip.Prefix {
+	if b.IsValid() {
+		return b
+	}
+	return a
+}
+
+// 5. String - stringification (package-level function)
+func StringPrefix(p netip.Prefix) string {
+	if !p.IsValid() {
+		return "Prefix{Unspecified}"
+	}
+	return fmt.Sprintf("Prefix{%s}", p)
+}
+
+// 6. Coalesce - N/A for value types (no nil possible)
+
+// 7. Same - identity, including host bits (package-level function)
+func SamePrefix(a, b netip.Prefix) bool {
+	return a == b
+}
+
+// 8. SemanticEqual - same network: host bits are ignored, so
+// 10.1.2.3/8 equals 10.0.0.0/8
+func SemanticEqualPrefix(a, b netip.Prefix) bool {
+	return a.Masked() == b.Masked()
+}
+
+// 9. Equal - equality check (package-level function)
+func EqualPrefix(a, b netip.Prefix) bool {
+	return SamePrefix(a, b) || SemanticEqualPrefix(a, b)
+}
+
+// 10. Copy - identity for immutable value types (package-level function)
+func CopyPrefix(p netip.Prefix) netip.Prefix {
+	return p
+}
+
+// FormatPrefix formats p; Unspecified formats as the empty string.
+func FormatPrefix(p netip.Prefix) string {
+	if !p.IsValid() {
+		return ""
+	}
+	return p.String()
+}
+
+// ParsePrefix is the inverse of FormatPrefix: the empty string parses as
+// PrefixUnspecified, anything else as netip.ParsePrefix does.
+func ParsePrefix(s string) (netip.Prefix, error) {
+	if s == "" {
+		return PrefixUnspecified, nil
+	}
+	return netip.ParsePrefix(s)
+}
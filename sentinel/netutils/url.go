@@ -0,0 +1,188 @@
+package netutils
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// URL
+
+// 1. Sentinel
+var URLUnspecified = &url.URL{}
+
+// 2. IsSpecified
+func IsSpecifiedURL(u *url.URL) bool {
+	return u != nil && u != URLUnspecified
+}
+
+// 3. TakeOrElse
+func TakeOrElseURL(u, def *url.URL) *url.URL {
+	if u == nil || u == URLUnspecified {
+		return def
+	}
+	return u
+}
+
+// URLMergeOptions selects how MergeURL combines two specified URLs.
+type URLMergeOptions struct {
+	// Components merges field by field: each non-empty component of the
+	// incoming URL (scheme, user, host, path, query, fragment) replaces
+	// the current one, and empty ones keep it.
+	Components bool
+	// QueryValues merges the query key by key instead of replacing it
+	// whole; it implies Components.
+	QueryValues bool
+}
+type URLMergeOption func(*URLMergeOptions) URLMergeOptions
+
+func MergeWithComponents() URLMergeOption {
+	return func(o *URLMergeOptions) URLMergeOptions {
+		o.Components = true
+		return *o
+	}
+}
+
+func MergeWithQueryValues() URLMergeOption {
+	return func(o *URLMergeOptions) URLMergeOptions {
+		o.Components = true
+		o.QueryValues = true
+		return *o
+	}
+}
+
+// 4. Merge
+// By default an incoming specified URL replaces the current one; see
+// URLMergeOptions for component-wise merging. The result never aliases
+// its inputs.
+func MergeURL(a, b *url.URL, opts ...URLMergeOption) *url.URL {
+	a = CoalesceURL(a, URLUnspecified)
+	b = CoalesceURL(b, URLUnspecified)
+
+	if a == URLUnspecified {
+		return CopyURL(b)
+	}
+	if b == URLUnspecified {
+		return CopyURL(a)
+	}
+
+	var o URLMergeOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if !o.Components {
+		return CopyURL(b)
+	}
+
+	out := CopyURL(a)
+	if b.Scheme != "" {
+		out.Scheme = b.Scheme
+	}
+	if b.Opaque != "" {
+		out.Opaque = b.Opaque
+	}
+	if b.User != nil {
+		user := *b.User
+		out.User = &user
+	}
+	if b.Host != "" {
+		out.Host = b.Host
+	}
+	if b.Path != "" {
+		out.Path, out.RawPath = b.Path, b.RawPath
+	}
+	switch {
+	case b.RawQuery == "":
+	case o.QueryValues:
+		query := out.Query()
+		for key, values := range b.Query() {
+			query[key] = values
+		}
+		out.RawQuery = query.Encode()
+	default:
+		out.RawQuery = b.RawQuery
+	}
+	if b.Fragment != "" {
+		out.Fragment, out.RawFragment = b.Fragment, b.RawFragment
+	}
+	return out
+}
+
+// 5. String
+// Passwords are redacted.
+func StringURL(u *url.URL) string {
+	if !IsSpecifiedURL(u) {
+		return "URL{Unspecified}"
+	}
+	return fmt.Sprintf("URL{%s}", u.Redacted())
+}
+
+// 6. Coalesce
+func CoalesceURL(ptr, def *url.URL) *url.URL {
+	if ptr == nil {
+		return def
+	}
+	return ptr
+}
+
+// 7. Same
+func SameURL(a, b *url.URL) bool {
+	return CoalesceURL(a, URLUnspecified) == CoalesceURL(b, URLUnspecified)
+}
+
+// 8. SemanticEqual
+// URLs are equal when they encode the same, ignoring the case of scheme
+// and host, which RFC 3986 defines as case-insensitive.
+func SemanticEqualURL(a, b *url.URL) bool {
+	a = CoalesceURL(a, URLUnspecified)
+	b = CoalesceURL(b, URLUnspecified)
+	if a == URLUnspecified || b == URLUnspecified {
+		return a == b
+	}
+	return canonicalURL(a) == canonicalURL(b)
+}
+
+func canonicalURL(u *url.URL) string {
+	c := *u
+	c.Scheme = strings.ToLower(c.Scheme)
+	c.Host = strings.ToLower(c.Host)
+	return c.String()
+}
+
+// 9. Equal
+func EqualURL(a, b *url.URL) bool {
+	if !SameURL(a, b) {
+		return SemanticEqualURL(a, b)
+	}
+	return true
+}
+
+// 10. Copy
+func CopyURL(u *url.URL) *url.URL {
+	if !IsSpecifiedURL(u) {
+		return URLUnspecified
+	}
+	c := *u
+	if u.User != nil {
+		user := *u.User
+		c.User = &user
+	}
+	return &c
+}
+
+// FormatURL formats u; Unspecified formats as the empty string.
+func FormatURL(u *url.URL) string {
+	if !IsSpecifiedURL(u) {
+		return ""
+	}
+	return u.String()
+}
+
+// ParseURL is the inverse of FormatURL: the empty string parses as
+// URLUnspecified, anything else as url.Parse does.
+func ParseURL(s string) (*url.URL, error) {
+	if s == "" {
+		return URLUnspecified, nil
+	}
+	return url.Parse(s)
+}
@@ -0,0 +1,160 @@
+package packed
+
+import (
+	"fmt"
+	"math"
+)
+
+type fieldHandle[T any] struct {
+	mask     uint64
+	shift    uint
+	width    uint
+	sentinel uint64
+}
+
+func (h fieldHandle[T]) get(w Word[T]) uint64 {
+	return (w.raw & h.mask) >> h.shift
+}
+
+func (h fieldHandle[T]) set(w Word[T], bits uint64) Word[T] {
+	return Word[T]{raw: w.raw&^h.mask | bits<<h.shift&h.mask}
+}
+
+func (h fieldHandle[T]) value(bits uint64) FieldValue[T] {
+	return FieldValue[T]{mask: h.mask, bits: bits << h.shift & h.mask}
+}
+
+// IsSpecified reports whether the field does not hold its Unspecified pattern.
+func (h fieldHandle[T]) IsSpecified(w Word[T]) bool {
+	return w.raw&h.mask != h.sentinel
+}
+
+// Clear returns w with the field reset to its Unspecified pattern.
+func (h fieldHandle[T]) Clear(w Word[T]) Word[T] {
+	return Word[T]{raw: w.raw&^h.mask | h.sentinel}
+}
+
+// FieldValue is a field assignment for Layout.Copy, created by a field's With method.
+type FieldValue[T any] struct {
+	mask uint64
+	bits uint64
+}
+
+// Float32Field accesses a float32 component; NaN is Unspecified.
+type Float32Field[T any] struct {
+	fieldHandle[T]
+}
+
+// Get returns the component.
+func (f Float32Field[T]) Get(w Word[T]) float32 {
+	return math.Float32frombits(uint32(f.get(w)))
+}
+
+// Set returns w with the component replaced.
+func (f Float32Field[T]) Set(w Word[T], v float32) Word[T] {
+	return f.set(w, uint64(math.Float32bits(v)))
+}
+
+// IsSpecified reports whether the component is not NaN.
+func (f Float32Field[T]) IsSpecified(w Word[T]) bool {
+	return !math.IsNaN(float64(f.Get(w)))
+}
+
+// With returns a Copy option setting the component to v.
+func (f Float32Field[T]) With(v float32) FieldValue[T] {
+	return f.value(uint64(math.Float32bits(v)))
+}
+
+// IntField accesses a signed component; the most negative value is Unspecified.
+type IntField[T any] struct {
+	fieldHandle[T]
+}
+
+// Min and Max bound the specified values of the component.
+func (f IntField[T]) Min() int64 { return -(int64(1) << (f.width - 1)) + 1 }
+func (f IntField[T]) Max() int64 { return int64(1)<<(f.width-1) - 1 }
+
+// Get returns the component, sign-extended.
+func (f IntField[T]) Get(w Word[T]) int64 {
+	return signExtend(f.get(w), f.width)
+}
+
+// Set returns w with the component replaced. It panics if v is outside [Min, Max].
+func (f IntField[T]) Set(w Word[T], v int64) Word[T] {
+	f.check(v)
+	return f.set(w, uint64(v))
+}
+
+// With returns a Copy option setting the component to v.
+func (f IntField[T]) With(v int64) FieldValue[T] {
+	f.check(v)
+	return f.value(uint64(v))
+}
+
+func (f IntField[T]) check(v int64) {
+	if v < f.Min() || v > f.Max() {
+		panic(fmt.Sprintf("packed: %d does not fit a %d-bit int field", v, f.width))
+	}
+}
+
+// UintField accesses an unsigned component; the all-ones value is Unspecified.
+type UintField[T any] struct {
+	fieldHandle[T]
+}
+
+// Max bounds the specified values of the component.
+func (f UintField[T]) Max() uint64 { return uint64(1)<<f.width - 2 }
+
+// Get returns the component.
+func (f UintField[T]) Get(w Word[T]) uint64 {
+	return f.get(w)
+}
+
+// Set returns w with the component replaced. It panics if v exceeds Max.
+func (f UintField[T]) Set(w Word[T], v uint64) Word[T] {
+	f.check(v)
+	return f.set(w, v)
+}
+
+// With returns a Copy option setting the component to v.
+func (f UintField[T]) With(v uint64) FieldValue[T] {
+	f.check(v)
+	return f.value(v)
+}
+
+func (f UintField[T]) check(v uint64) {
+	if v > f.Max() {
+		panic(fmt.Sprintf("packed: %d does not fit a %d-bit uint field", v, f.width))
+	}
+}
+
+// EnumField accesses an iota-style enum component; 0 is Unspecified.
+type EnumField[T any] struct {
+	fieldHandle[T]
+}
+
+// Max bounds the specified values of the component.
+func (f EnumField[T]) Max() uint64 { return uint64(1)<<f.width - 1 }
+
+// Get returns the component.
+func (f EnumField[T]) Get(w Word[T]) uint64 {
+	return f.get(w)
+}
+
+// Set returns w with the component replaced. It panics if v exceeds Max.
+func (f EnumField[T]) Set(w Word[T], v uint64) Word[T] {
+	f.check(v)
+	return f.set(w, v)
+}
+
+// With returns a Copy option setting the component to v.
+func (f EnumField[T]) With(v uint64) FieldValue[T] {
+	f.check(v)
+	return f.value(v)
+}
+
+func (f EnumField[T]) check(v uint64) {
+	if v > f.Max() {
+		panic(fmt.Sprintf("packed: %d does not fit a %d-bit enum field", v, f.width))
+	}
+}
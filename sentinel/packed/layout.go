@@ -0,0 +1,279 @@
+package packed
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/zodimo/go-sentinel-helper/sentinel/floatutils"
+)
+
+// WordBits is the number of bits available to a Layout.
+const WordBits = 64
+
+// Kind is the representation of a packed field.
+type Kind uint8
+
+const (
+	// KindFloat32 stores a float32 in 32 bits; NaN is Unspecified.
+	KindFloat32 Kind = iota + 1
+	// KindInt stores a two's-complement integer; the most negative value is Unspecified.
+	KindInt
+	// KindUint stores an unsigned integer; the all-ones value is Unspecified.
+	KindUint
+	// KindEnum stores an iota-style enum; 0 is Unspecified.
+	KindEnum
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindFloat32:
+		return "float32"
+	case KindInt:
+		return "int"
+	case KindUint:
+		return "uint"
+	case KindEnum:
+		return "enum"
+	default:
+		return fmt.Sprintf("Kind(%d)", uint8(k))
+	}
+}
+
+// Word is a packed 64-bit value laid out by a Layout[T].
+// T is an owner tag type: fields of one layout cannot be used on another's words.
+type Word[T any] struct {
+	raw uint64
+}
+
+// Raw returns the packed bits.
+func (w Word[T]) Raw() uint64 {
+	return w.raw
+}
+
+// WordFromRaw reinterprets bits previously obtained from Raw.
+func WordFromRaw[T any](raw uint64) Word[T] {
+	return Word[T]{raw: raw}
+}
+
+type field struct {
+	name     string
+	kind     Kind
+	shift    uint
+	width    uint
+	mask     uint64 // field bits, in place
+	sentinel uint64 // Unspecified bit pattern, in place
+}
+
+func (f *field) get(raw uint64) uint64 {
+	return (raw & f.mask) >> f.shift
+}
+
+func (f *field) isSpecified(raw uint64) bool {
+	if f.kind == KindFloat32 {
+		return !math.IsNaN(float64(math.Float32frombits(uint32(f.get(raw)))))
+	}
+	return raw&f.mask != f.sentinel
+}
+
+// Layout assigns fields to consecutive bit ranges of a uint64, starting at bit 0.
+// Declare a layout once, at package level, and keep the returned field handles:
+//
+//	type textUnitTag struct{}
+//	var (
+//		textUnitLayout = packed.NewLayout[textUnitTag]()
+//		textUnitType   = textUnitLayout.Enum("type", 2)
+//		textUnitValue  = textUnitLayout.Float32("value")
+//	)
+//
+// Field declarations panic when the layout would exceed WordBits, since a
+// layout is fixed at program start like a regexp.MustCompile pattern.
+type Layout[T any] struct {
+	fields      []field
+	used        uint
+	unspecified uint64
+}
+
+// NewLayout returns an empty layout for owner type T.
+func NewLayout[T any]() *Layout[T] {
+	return &Layout[T]{}
+}
+
+func (l *Layout[T]) add(name string, kind Kind, width uint) *field {
+	if width == 0 || width >= WordBits {
+		panic(fmt.Sprintf("packed: field %q: invalid width %d", name, width))
+	}
+	if l.used+width > WordBits {
+		panic(fmt.Sprintf("packed: field %q: layout needs %d bits, only %d available", name, l.used+width, WordBits))
+	}
+
+	f := field{name: name, kind: kind, shift: l.used, width: width}
+	f.mask = (uint64(1)<<width - 1) << f.shift
+	switch kind {
+	case KindFloat32:
+		f.sentinel = uint64(math.Float32bits(floatutils.Float32Unspecified)) << f.shift
+	case KindInt:
+		f.sentinel = uint64(1) << (f.shift + width - 1)
+	case KindUint:
+		f.sentinel = f.mask
+	case KindEnum:
+		f.sentinel = 0
+	}
+
+	l.fields = append(l.fields, f)
+	l.used += width
+	l.unspecified |= f.sentinel
+	return &l.fields[len(l.fields)-1]
+}
+
+// handle declares a field and returns the placement its typed handle needs.
+func (l *Layout[T]) handle(name string, kind Kind, width uint) fieldHandle[T] {
+	f := l.add(name, kind, width)
+	return fieldHandle[T]{mask: f.mask, shift: f.shift, width: f.width, sentinel: f.sentinel}
+}
+
+// Float32 declares a 32-bit float field.
+func (l *Layout[T]) Float32(name string) Float32Field[T] {
+	return Float32Field[T]{l.handle(name, KindFloat32, 32)}
+}
+
+// Int declares a signed field of width bits.
+func (l *Layout[T]) Int(name string, width uint) IntField[T] {
+	return IntField[T]{l.handle(name, KindInt, width)}
+}
+
+// Uint declares an unsigned field of width bits.
+func (l *Layout[T]) Uint(name string, width uint) UintField[T] {
+	return UintField[T]{l.handle(name, KindUint, width)}
+}
+
+// Enum declares an enum field of width bits whose 0 value is Unspecified.
+func (l *Layout[T]) Enum(name string, width uint) EnumField[T] {
+	return EnumField[T]{l.handle(name, KindEnum, width)}
+}
+
+// Bits returns the number of bits used so far.
+func (l *Layout[T]) Bits() uint {
+	return l.used
+}
+
+// 1. Sentinel - every field at its Unspecified bit pattern
+func (l *Layout[T]) Unspecified() Word[T] {
+	return Word[T]{raw: l.unspecified}
+}
+
+// 2. IsSpecified - the word differs from the Unspecified pattern in at least one field
+func (l *Layout[T]) IsSpecified(w Word[T]) bool {
+	for i := range l.fields {
+		if l.fields[i].isSpecified(w.raw) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsFullySpecified reports whether every field is specified.
+func (l *Layout[T]) IsFullySpecified(w Word[T]) bool {
+	for i := range l.fields {
+		if !l.fields[i].isSpecified(w.raw) {
+			return false
+		}
+	}
+	return true
+}
+
+// 3. TakeOrElse - component-wise fallback: every Unspecified field of w comes from def
+func (l *Layout[T]) TakeOrElse(w, def Word[T]) Word[T] {
+	return l.Merge(def, w)
+}
+
+// 4. Merge - component-wise composition merge: every specified field of b wins
+func (l *Layout[T]) Merge(a, b Word[T]) Word[T] {
+	raw := a.raw
+	for i := range l.fields {
+		f := &l.fields[i]
+		if f.isSpecified(b.raw) {
+			raw = raw&^f.mask | b.raw&f.mask
+		}
+	}
+	return Word[T]{raw: raw}
+}
+
+// 5. String - lists every field by name
+func (l *Layout[T]) String(w Word[T]) string {
+	if !l.IsSpecified(w) {
+		return "{Unspecified}"
+	}
+	var sb strings.Builder
+	sb.WriteByte('{')
+	for i := range l.fields {
+		f := &l.fields[i]
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(f.name)
+		sb.WriteString(": ")
+		if !f.isSpecified(w.raw) {
+			sb.WriteString("Unspecified")
+			continue
+		}
+		bits := f.get(w.raw)
+		switch f.kind {
+		case KindFloat32:
+			fmt.Fprint(&sb, math.Float32frombits(uint32(bits)))
+		case KindInt:
+			fmt.Fprint(&sb, signExtend(bits, f.width))
+		default:
+			fmt.Fprint(&sb, bits)
+		}
+	}
+	sb.WriteByte('}')
+	return sb.String()
+}
+
+// 7-9. Equality - words are compared bit for bit, except that any NaN
+// payload in a float field counts as the same Unspecified value.
+func (l *Layout[T]) Equal(a, b Word[T]) bool {
+	if a.raw == b.raw {
+		return true
+	}
+	for i := range l.fields {
+		f := &l.fields[i]
+		sa, sb := f.isSpecified(a.raw), f.isSpecified(b.raw)
+		if sa != sb || sa && a.raw&f.mask != b.raw&f.mask {
+			return false
+		}
+	}
+	return true
+}
+
+// 10. Copy - returns w with the given fields replaced. Options left
+// Unspecified keep the current component; float components go through
+// floatutils.TakeOrElse.
+func (l *Layout[T]) Copy(w Word[T], values ...FieldValue[T]) Word[T] {
+	overlay := l.unspecified
+	for _, v := range values {
+		overlay = overlay&^v.mask | v.bits
+	}
+
+	raw := w.raw
+	for i := range l.fields {
+		f := &l.fields[i]
+		if f.kind == KindFloat32 {
+			opt := math.Float32frombits(uint32(f.get(overlay)))
+			cur := math.Float32frombits(uint32(f.get(raw)))
+			bits := uint64(math.Float32bits(floatutils.TakeOrElse(opt, cur)))
+			raw = raw&^f.mask | bits<<f.shift
+			continue
+		}
+		if f.isSpecified(overlay) {
+			raw = raw&^f.mask | overlay&f.mask
+		}
+	}
+	return Word[T]{raw: raw}
+}
+
+func signExtend(bits uint64, width uint) int64 {
+	shift := WordBits - width
+	return int64(bits<<shift) >> shift
+}
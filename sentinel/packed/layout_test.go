@@ -0,0 +1,189 @@
+package packed
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zodimo/go-sentinel-helper/sentinel/floatutils"
+)
+
+type swatchTag struct{}
+
+var (
+	swatchLayout = NewLayout[swatchTag]()
+	swatchKind   = swatchLayout.Enum("kind", 2)
+	swatchLevel  = swatchLayout.Int("level", 8)
+	swatchCount  = swatchLayout.Uint("count", 12)
+	swatchValue  = swatchLayout.Float32("value")
+)
+
+func newSwatch(kind uint64, level int64, count uint64, value float32) Word[swatchTag] {
+	w := swatchLayout.Unspecified()
+	w = swatchKind.Set(w, kind)
+	w = swatchLevel.Set(w, level)
+	w = swatchCount.Set(w, count)
+	return swatchValue.Set(w, value)
+}
+
+func TestLayout_Unspecified(t *testing.T) {
+	u := swatchLayout.Unspecified()
+	require.Equal(t, uint(54), swatchLayout.Bits())
+	require.False(t, swatchLayout.IsSpecified(u))
+	require.False(t, swatchLayout.IsFullySpecified(u))
+	require.False(t, swatchKind.IsSpecified(u))
+	require.False(t, swatchLevel.IsSpecified(u))
+	require.False(t, swatchCount.IsSpecified(u))
+	require.False(t, swatchValue.IsSpecified(u))
+	require.True(t, floatutils.IsUnspecified(swatchValue.Get(u)))
+	require.Equal(t, "{Unspecified}", swatchLayout.String(u))
+}
+
+func TestLayout_GetSet(t *testing.T) {
+	w := newSwatch(3, -127, 4094, -2.5)
+	require.True(t, swatchLayout.IsFullySpecified(w))
+	require.Equal(t, uint64(3), swatchKind.Get(w))
+	require.Equal(t, int64(-127), swatchLevel.Get(w))
+	require.Equal(t, uint64(4094), swatchCount.Get(w))
+	require.Equal(t, float32(-2.5), swatchValue.Get(w))
+
+	w = swatchLevel.Set(w, 0)
+	require.Equal(t, int64(0), swatchLevel.Get(w))
+	require.Equal(t, uint64(3), swatchKind.Get(w), "neighbouring fields are untouched")
+	require.Equal(t, uint64(4094), swatchCount.Get(w))
+
+	w = swatchCount.Clear(w)
+	require.False(t, swatchCount.IsSpecified(w))
+	require.True(t, swatchLayout.IsSpecified(w))
+	require.False(t, swatchLayout.IsFullySpecified(w))
+
+	require.Equal(t, w, WordFromRaw[swatchTag](w.Raw()))
+}
+
+func TestLayout_Ranges(t *testing.T) {
+	require.Equal(t, int64(-127), swatchLevel.Min())
+	require.Equal(t, int64(127), swatchLevel.Max())
+	require.Equal(t, uint64(4094), swatchCount.Max())
+	require.Equal(t, uint64(3), swatchKind.Max())
+
+	w := swatchLayout.Unspecified()
+	require.Panics(t, func() { swatchLevel.Set(w, -128) })
+	require.Panics(t, func() { swatchLevel.Set(w, 128) })
+	require.Panics(t, func() { swatchCount.Set(w, 4095) })
+	require.Panics(t, func() { swatchKind.With(4) })
+}
+
+func TestLayout_DeclarationPanics(t *testing.T) {
+	type tag struct{}
+	require.Panics(t, func() { NewLayout[tag]().Uint("zero", 0) })
+	require.Panics(t, func() { NewLayout[tag]().Uint("whole", 64) })
+	require.Panics(t, func() {
+		l := NewLayout[tag]()
+		l.Float32("a")
+		l.Float32("b")
+		l.Enum("c", 1)
+	})
+}
+
+func TestLayout_Merge(t *testing.T) {
+	base := newSwatch(1, 10, 100, 1.5)
+	patch := swatchLayout.Unspecified()
+	patch = swatchLevel.Set(patch, -3)
+	patch = swatchValue.Set(patch, 0)
+
+	merged := swatchLayout.Merge(base, patch)
+	require.Equal(t, uint64(1), swatchKind.Get(merged))
+	require.Equal(t, int64(-3), swatchLevel.Get(merged))
+	require.Equal(t, uint64(100), swatchCount.Get(merged))
+	require.Equal(t, float32(0), swatchValue.Get(merged))
+
+	require.Equal(t, base, swatchLayout.Merge(base, swatchLayout.Unspecified()))
+	require.Equal(t, base, swatchLayout.Merge(swatchLayout.Unspecified(), base))
+
+	taken := swatchLayout.TakeOrElse(patch, base)
+	require.True(t, swatchLayout.Equal(merged, taken))
+}
+
+func TestLayout_MergeTreatsAnyNaNAsUnspecified(t *testing.T) {
+	base := newSwatch(1, 1, 1, 1)
+	otherNaN := math.Float32frombits(0x7FA00001)
+	patch := swatchValue.Set(swatchLayout.Unspecified(), otherNaN)
+
+	require.False(t, swatchValue.IsSpecified(patch))
+	require.Equal(t, base, swatchLayout.Merge(base, patch))
+	require.True(t, swatchLayout.Equal(swatchLayout.Unspecified(), patch))
+}
+
+func TestLayout_Copy(t *testing.T) {
+	base := newSwatch(2, 5, 50, 0.25)
+
+	require.Equal(t, base, swatchLayout.Copy(base))
+
+	c := swatchLayout.Copy(base, swatchValue.With(9), swatchCount.With(0))
+	require.Equal(t, uint64(2), swatchKind.Get(c))
+	require.Equal(t, int64(5), swatchLevel.Get(c))
+	require.Equal(t, uint64(0), swatchCount.Get(c))
+	require.Equal(t, float32(9), swatchValue.Get(c))
+
+	// An Unspecified option keeps the current component.
+	c = swatchLayout.Copy(base, swatchValue.With(floatutils.Float32Unspecified))
+	require.Equal(t, float32(0.25), swatchValue.Get(c))
+
+	// The last option for a field wins.
+	c = swatchLayout.Copy(base, swatchLevel.With(1), swatchLevel.With(2))
+	require.Equal(t, int64(2), swatchLevel.Get(c))
+}
+
+func TestLayout_Equal(t *testing.T) {
+	a := newSwatch(1, 2, 3, 4)
+	require.True(t, swatchLayout.Equal(a, newSwatch(1, 2, 3, 4)))
+	require.False(t, swatchLayout.Equal(a, newSwatch(1, 2, 3, 5)))
+	require.False(t, swatchLayout.Equal(a, swatchValue.Clear(a)))
+}
+
+func TestLayout_String(t *testing.T) {
+	w := swatchLevel.Set(swatchLayout.Unspecified(), -4)
+	w = swatchValue.Set(w, 1.5)
+	require.Equal(t, "{kind: Unspecified, level: -4, count: Unspecified, value: 1.5}", swatchLayout.String(w))
+	require.Equal(t, "enum", KindEnum.String())
+}
+
+func TestLayout_ZeroAllocations(t *testing.T) {
+	base := newSwatch(1, 10, 100, 1.5)
+	patch := swatchLevel.Set(swatchLayout.Unspecified(), -3)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		w := swatchLayout.Merge(base, patch)
+		w = swatchLayout.Copy(w, swatchValue.With(2), swatchKind.With(3))
+		_ = swatchLevel.Get(w) + int64(swatchCount.Get(w))
+		_ = swatchLayout.IsFullySpecified(w)
+		_ = swatchLayout.Equal(w, base)
+	})
+	require.Zero(t, allocs)
+}
+
+func BenchmarkLayout_GetSet(b *testing.B) {
+	w := newSwatch(1, 10, 100, 1.5)
+	b.ReportAllocs()
+	for b.Loop() {
+		w = swatchValue.Set(w, swatchValue.Get(w)+1)
+		w = swatchLevel.Set(w, swatchLevel.Get(w)&63)
+	}
+}
+
+func BenchmarkLayout_Merge(b *testing.B) {
+	base := newSwatch(1, 10, 100, 1.5)
+	patch := swatchLevel.Set(swatchLayout.Unspecified(), -3)
+	b.ReportAllocs()
+	for b.Loop() {
+		base = swatchLayout.Merge(base, patch)
+	}
+}
+
+func BenchmarkLayout_Copy(b *testing.B) {
+	base := newSwatch(1, 10, 100, 1.5)
+	b.ReportAllocs()
+	for b.Loop() {
+		base = swatchLayout.Copy(base, swatchValue.With(2), swatchKind.With(3))
+	}
+}
@@ -0,0 +1,159 @@
+package patchutils
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/zodimo/go-sentinel-helper/internal/sentinelreflect"
+	"github.com/zodimo/go-sentinel-helper/sentinel/boolutils"
+)
+
+// field is an exported struct field as encoding/json sees it. Unexported
+// fields, embedded or not, are skipped as diffutils skips them.
+type field struct {
+	name   string // JSON member name
+	index  []int  // path through embedded structs, for FieldByIndex
+	nested bool   // walked member by member rather than encoded whole
+}
+
+var fieldCache sync.Map // reflect.Type -> []field
+
+// fieldsOf lists the JSON members of struct type t. Like encoding/json it
+// honours `json:"name"` and `json:"-"`, and inlines untagged embedded
+// structs.
+func fieldsOf(t reflect.Type) []field {
+	if cached, ok := fieldCache.Load(t); ok {
+		return cached.([]field)
+	}
+	fields := appendFields(nil, t, nil)
+	fieldCache.Store(t, fields)
+	return fields
+}
+
+func appendFields(fields []field, t reflect.Type, index []int) []field {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		idx := append(append([]int(nil), index...), i)
+		nested := sentinelreflect.IsNested(sf.Type)
+		if sf.Anonymous && name == "" && nested {
+			fields = appendFields(fields, sf.Type, idx)
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		fields = append(fields, field{name: name, index: idx, nested: nested})
+	}
+	return fields
+}
+
+// pointer appends name to the RFC 6901 JSON Pointer prefix.
+func pointer(prefix, name string) string {
+	name = strings.ReplaceAll(name, "~", "~0")
+	name = strings.ReplaceAll(name, "/", "~1")
+	return prefix + "/" + name
+}
+
+func isNull(raw json.RawMessage) bool {
+	return strings.TrimSpace(string(raw)) == "null"
+}
+
+// encodeLeaf encodes a specified value the way a REST client would send
+// it: BooleanValue as a JSON bool and wrappers as their bare Value.
+func encodeLeaf(v reflect.Value) (json.RawMessage, error) {
+	t := v.Type()
+	switch {
+	case t == sentinelreflect.BooleanValueType:
+		return json.Marshal(v.Interface().(boolutils.BooleanValue).Bool())
+	case sentinelreflect.IsWrapper(t):
+		return json.Marshal(sentinelreflect.WrapperField(v).Interface())
+	}
+	return json.Marshal(v.Interface())
+}
+
+// decodeLeaf is the inverse of encodeLeaf. Input that decodes to a
+// sentinel, such as math.MinInt for an int, fails rather than reading as
+// "keep"; so does the zero value of a type without one, such as false
+// for a plain bool, which a patch cannot tell apart from an absent member.
+func decodeLeaf(raw json.RawMessage, v reflect.Value) error {
+	t := v.Type()
+	switch {
+	case t == sentinelreflect.BooleanValueType:
+		var b bool
+		if err := json.Unmarshal(raw, &b); err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(boolutils.BooleanValueFrom(b)))
+		return nil
+	case sentinelreflect.IsWrapper(t):
+		ptr, value := sentinelreflect.NewWrapper(t)
+		if err := json.Unmarshal(raw, value.Addr().Interface()); err != nil {
+			return err
+		}
+		v.Set(ptr)
+		return nil
+	}
+	if err := json.Unmarshal(raw, v.Addr().Interface()); err != nil {
+		return err
+	}
+	if sentinelreflect.IsUnspecifiedOrZero(v) {
+		return ErrSentinelCollision
+	}
+	return nil
+}
+
+// clearStruct sets every field of sv to Unspecified.
+func clearStruct(sv reflect.Value) {
+	for _, f := range fieldsOf(sv.Type()) {
+		fv := sv.FieldByIndex(f.index)
+		if f.nested {
+			clearStruct(fv)
+		} else {
+			sentinelreflect.SetUnspecifiedOrZero(fv)
+		}
+	}
+}
+
+// encodeObject returns the members of sv that are specified, or listed in
+// nulls, which encode as null. Nested objects without members are dropped
+// unless keepEmpty is set.
+func encodeObject(sv reflect.Value, prefix string, nulls map[string]bool, keepEmpty bool) (map[string]any, error) {
+	members := map[string]any{}
+	for _, f := range fieldsOf(sv.Type()) {
+		ptr := pointer(prefix, f.name)
+		if nulls[ptr] {
+			members[f.name] = nil
+			continue
+		}
+		fv := sv.FieldByIndex(f.index)
+		if f.nested {
+			sub, err := encodeObject(fv, ptr, nulls, keepEmpty)
+			if err != nil {
+				return nil, err
+			}
+			if len(sub) > 0 || keepEmpty {
+				members[f.name] = sub
+			}
+			continue
+		}
+		if sentinelreflect.IsUnspecifiedOrZero(fv) {
+			continue
+		}
+		raw, err := encodeLeaf(fv)
+		if err != nil {
+			return nil, err
+		}
+		members[f.name] = raw
+	}
+	return members, nil
+}
@@ -0,0 +1,68 @@
+package patchutils
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/zodimo/go-sentinel-helper/internal/sentinelreflect"
+	"github.com/zodimo/go-sentinel-helper/sentinel/diffutils"
+)
+
+// JSON Patch operation names used by CreateJSONPatch.
+const (
+	OpAdd     = "add"
+	OpRemove  = "remove"
+	OpReplace = "replace"
+)
+
+// Operation is a single RFC 6902 JSON Patch operation.
+type Operation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// CreateJSONPatch returns the operations that turn Marshal(base) into
+// Marshal(updated), in field order: "add" where a field becomes specified,
+// "remove" where it becomes Unspecified and "replace" where diffutils.Diff
+// reports a change.
+func CreateJSONPatch[T any](base, updated *T) ([]Operation, error) {
+	base, updated = orUnspecified(base), orUnspecified(updated)
+	patch := diffutils.Diff(base, updated)
+	var ops []Operation
+	err := appendOps(&ops, reflect.ValueOf(base).Elem(), reflect.ValueOf(updated).Elem(), reflect.ValueOf(patch).Elem(), "")
+	return ops, err
+}
+
+func appendOps(ops *[]Operation, base, updated, patch reflect.Value, prefix string) error {
+	for _, f := range fieldsOf(base.Type()) {
+		ptr := pointer(prefix, f.name)
+		bf, uf, pf := base.FieldByIndex(f.index), updated.FieldByIndex(f.index), patch.FieldByIndex(f.index)
+		if f.nested {
+			if err := appendOps(ops, bf, uf, pf, ptr); err != nil {
+				return err
+			}
+			continue
+		}
+		bSet := !sentinelreflect.IsUnspecifiedOrZero(bf)
+		uSet := !sentinelreflect.IsUnspecifiedOrZero(uf)
+		var op string
+		switch {
+		case !bSet && uSet:
+			op = OpAdd
+		case bSet && !uSet:
+			*ops = append(*ops, Operation{Op: OpRemove, Path: ptr})
+			continue
+		case bSet && uSet && !sentinelreflect.IsUnspecifiedOrZero(pf):
+			op = OpReplace
+		default:
+			continue
+		}
+		raw, err := encodeLeaf(uf)
+		if err != nil {
+			return err
+		}
+		*ops = append(*ops, Operation{Op: op, Path: ptr, Value: raw})
+	}
+	return nil
+}
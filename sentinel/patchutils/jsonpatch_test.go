@@ -0,0 +1,97 @@
+package patchutils
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zodimo/go-sentinel-helper/sentinel/intutils"
+	"github.com/zodimo/go-sentinel-helper/sentinel/stringutils"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// applyOps is a minimal RFC 6902 applier for objects, enough to check that
+// CreateJSONPatch agrees with Marshal.
+func applyOps(t *testing.T, doc []byte, ops []Operation) []byte {
+	var root map[string]any
+	require.NoError(t, json.Unmarshal(doc, &root))
+	for _, op := range ops {
+		tokens := strings.Split(strings.TrimPrefix(op.Path, "/"), "/")
+		parent := root
+		for _, token := range tokens[:len(tokens)-1] {
+			parent = parent[unescape(token)].(map[string]any)
+		}
+		name := unescape(tokens[len(tokens)-1])
+		switch op.Op {
+		case OpAdd, OpReplace:
+			_, exists := parent[name]
+			require.Equal(t, op.Op == OpReplace, exists, op.Path)
+			var v any
+			require.NoError(t, json.Unmarshal(op.Value, &v))
+			parent[name] = v
+		case OpRemove:
+			require.Contains(t, parent, name)
+			delete(parent, name)
+		}
+	}
+	out, err := json.Marshal(root)
+	require.NoError(t, err)
+	return out
+}
+
+func unescape(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+}
+
+func TestCreateJSONPatch(t *testing.T) {
+	base, updated := profileBase(), profileBase()
+	updated.Name = "grace"
+	updated.Age = intutils.IntValueUnspecified
+	updated.Quota = wrapperspb.Int64(0)
+	updated.Path = stringutils.StringValueUnspecified
+	updated.Theme.Opacity = 0.5000000001
+
+	ops, err := CreateJSONPatch(base, updated)
+	require.NoError(t, err)
+	require.Equal(t, []Operation{
+		{Op: OpReplace, Path: "/name", Value: json.RawMessage(`"grace"`)},
+		{Op: OpRemove, Path: "/age"},
+		{Op: OpReplace, Path: "/quota", Value: json.RawMessage(`0`)},
+		{Op: OpRemove, Path: "/a~1b~0c"},
+	}, ops, "opacity is within tolerance")
+
+	out, err := json.Marshal(ops)
+	require.NoError(t, err)
+	require.JSONEq(t, `[
+		{"op":"replace","path":"/name","value":"grace"},
+		{"op":"remove","path":"/age"},
+		{"op":"replace","path":"/quota","value":0},
+		{"op":"remove","path":"/a~1b~0c"}
+	]`, string(out))
+}
+
+func TestCreateJSONPatchMatchesMarshal(t *testing.T) {
+	full := profileBase()
+	for name, pair := range map[string][2]*profile{
+		"unchanged":  {full, profileBase()},
+		"add all":    {nil, full},
+		"remove all": {full, nil},
+		"nested": {full, func() *profile {
+			p := profileBase()
+			p.Theme.Accent = stringutils.StringValueUnspecified
+			p.Theme.Opacity = 1
+			return p
+		}()},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ops, err := CreateJSONPatch(pair[0], pair[1])
+			require.NoError(t, err)
+			before, err := Marshal(pair[0])
+			require.NoError(t, err)
+			after, err := Marshal(pair[1])
+			require.NoError(t, err)
+			require.JSONEq(t, string(after), string(applyOps(t, before, ops)))
+		})
+	}
+}
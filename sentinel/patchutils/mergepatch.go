@@ -0,0 +1,192 @@
+// Package patchutils bridges HTTP PATCH bodies and sentinel structs.
+//
+// RFC 7396 JSON Merge Patch maps onto the sentinel convention directly:
+// an absent member is Unspecified ("keep"), a value is specified ("set")
+// and null resets the member to Unspecified ("delete"). Because Merge…
+// functions read Unspecified as "keep", resets travel next to the patch
+// value rather than inside it. RFC 6902 JSON Patch operations are derived
+// from the same field walk, so both bridges agree with diffutils.
+//
+// Members are named like encoding/json names them. BooleanValue encodes
+// as a JSON bool and wrapperspb fields as their bare value.
+// Fields without a sentinel, such as a plain bool, read their zero value
+// as Unspecified, so a patch can set them to anything but zero.
+package patchutils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/zodimo/go-sentinel-helper/internal/sentinelreflect"
+	"github.com/zodimo/go-sentinel-helper/sentinel/diffutils"
+)
+
+var (
+	// ErrNotObject is returned when a merge patch, or one of its members
+	// targeting a nested struct, is not a JSON object.
+	ErrNotObject = errors.New("patchutils: merge patch must be a JSON object")
+	// ErrUnknownMember is returned for a member that names no field.
+	ErrUnknownMember = errors.New("patchutils: unknown member")
+	// ErrSentinelCollision is returned for a member whose value equals the
+	// field's Unspecified sentinel, or the zero value of a field without
+	// one; send null to reset a field instead.
+	ErrSentinelCollision = errors.New("patchutils: value collides with the Unspecified sentinel")
+)
+
+// MergePatch is an RFC 7396 merge patch for T.
+type MergePatch[T any] struct {
+	// Value holds the members present in the document; every other field
+	// is Unspecified.
+	Value *T
+	// Resets lists the JSON Pointers (RFC 6901) of members set to null,
+	// in ascending order. A pointer to a nested struct resets all of it.
+	Resets []string
+}
+
+// ParseMergePatch decodes an RFC 7396 document into a MergePatch.
+func ParseMergePatch[T any](doc []byte) (*MergePatch[T], error) {
+	p := &MergePatch[T]{Value: diffutils.Unspecified[T]()}
+	if err := decodeObject(doc, reflect.ValueOf(p.Value).Elem(), "", &p.Resets); err != nil {
+		return nil, err
+	}
+	slices.Sort(p.Resets)
+	return p, nil
+}
+
+func decodeObject(doc []byte, sv reflect.Value, prefix string, resets *[]string) error {
+	var members map[string]json.RawMessage
+	if isNull(doc) || json.Unmarshal(doc, &members) != nil {
+		return fmt.Errorf("%w at %q", ErrNotObject, prefix)
+	}
+	fields := fieldsOf(sv.Type())
+	for name, raw := range members {
+		ptr := pointer(prefix, name)
+		i := slices.IndexFunc(fields, func(f field) bool { return f.name == name })
+		if i < 0 {
+			return fmt.Errorf("%w %q", ErrUnknownMember, ptr)
+		}
+		f, fv := fields[i], sv.FieldByIndex(fields[i].index)
+		switch {
+		case isNull(raw):
+			*resets = append(*resets, ptr)
+		case f.nested:
+			if err := decodeObject(raw, fv, ptr, resets); err != nil {
+				return err
+			}
+		default:
+			if err := decodeLeaf(raw, fv); err != nil {
+				return fmt.Errorf("patchutils: member %q: %w", ptr, err)
+			}
+		}
+	}
+	return nil
+}
+
+// CreateMergePatch returns the merge patch that turns base into updated.
+// Unlike diffutils.Diff alone it can clear fields: a field specified in
+// base and Unspecified in updated becomes a reset.
+func CreateMergePatch[T any](base, updated *T) *MergePatch[T] {
+	p := &MergePatch[T]{Value: diffutils.Diff(base, updated)}
+	if base == nil || updated == nil {
+		base, updated = orUnspecified(base), orUnspecified(updated)
+	}
+	collectResets(reflect.ValueOf(base).Elem(), reflect.ValueOf(updated).Elem(), "", &p.Resets)
+	slices.Sort(p.Resets)
+	return p
+}
+
+func orUnspecified[T any](v *T) *T {
+	if v == nil {
+		return diffutils.Unspecified[T]()
+	}
+	return v
+}
+
+func collectResets(base, updated reflect.Value, prefix string, resets *[]string) {
+	for _, f := range fieldsOf(base.Type()) {
+		ptr := pointer(prefix, f.name)
+		bf, uf := base.FieldByIndex(f.index), updated.FieldByIndex(f.index)
+		if f.nested {
+			collectResets(bf, uf, ptr, resets)
+			continue
+		}
+		if !sentinelreflect.IsUnspecifiedOrZero(bf) && sentinelreflect.IsUnspecifiedOrZero(uf) {
+			*resets = append(*resets, ptr)
+		}
+	}
+}
+
+// Apply returns base with the patch applied: diffutils.Merge of Value,
+// then every reset field set to Unspecified. base is not modified.
+func (p *MergePatch[T]) Apply(base *T) *T {
+	out := diffutils.Merge(base, p.Value)
+	for _, ptr := range p.Resets {
+		resetPointer(reflect.ValueOf(out).Elem(), ptr)
+	}
+	return out
+}
+
+// resetPointer sets the field at ptr to Unspecified; pointers that name
+// no field are ignored.
+func resetPointer(sv reflect.Value, ptr string) {
+	tokens := strings.Split(strings.TrimPrefix(ptr, "/"), "/")
+	for n, token := range tokens {
+		name := strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		i := slices.IndexFunc(fieldsOf(sv.Type()), func(f field) bool { return f.name == name })
+		if i < 0 {
+			return
+		}
+		f := fieldsOf(sv.Type())[i]
+		fv := sv.FieldByIndex(f.index)
+		switch {
+		case f.nested && n == len(tokens)-1:
+			clearStruct(fv)
+		case f.nested:
+			sv = fv
+			continue
+		case n == len(tokens)-1:
+			sentinelreflect.SetUnspecifiedOrZero(fv)
+		}
+		return
+	}
+}
+
+// MarshalJSON encodes p as an RFC 7396 document: specified fields as
+// values, resets as null, everything else absent.
+func (p *MergePatch[T]) MarshalJSON() ([]byte, error) {
+	nulls := make(map[string]bool, len(p.Resets))
+	for _, ptr := range p.Resets {
+		nulls[ptr] = true
+	}
+	members, err := encodeObject(reflect.ValueOf(orUnspecified(p.Value)).Elem(), "", nulls, false)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(members)
+}
+
+// UnmarshalJSON implements json.Unmarshaler via ParseMergePatch, so a
+// MergePatch can be decoded straight from a request body.
+func (p *MergePatch[T]) UnmarshalJSON(doc []byte) error {
+	parsed, err := ParseMergePatch[T](doc)
+	if err != nil {
+		return err
+	}
+	*p = *parsed
+	return nil
+}
+
+// Marshal encodes v as the JSON document that JSON Patch paths refer to:
+// Unspecified members are absent and nested objects are always present,
+// so "add" operations on nested members have a parent to land in.
+func Marshal[T any](v *T) ([]byte, error) {
+	members, err := encodeObject(reflect.ValueOf(orUnspecified(v)).Elem(), "", nil, true)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(members)
+}
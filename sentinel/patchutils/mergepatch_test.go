@@ -0,0 +1,187 @@
+package patchutils
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zodimo/go-sentinel-helper/sentinel/boolutils"
+	"github.com/zodimo/go-sentinel-helper/sentinel/diffutils"
+	"github.com/zodimo/go-sentinel-helper/sentinel/intutils"
+	"github.com/zodimo/go-sentinel-helper/sentinel/protobufwrapper"
+	"github.com/zodimo/go-sentinel-helper/sentinel/stringutils"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type theme struct {
+	Accent  string  `json:"accent"`
+	Opacity float64 `json:"opacity"`
+}
+
+type Audit struct {
+	Note string `json:"note"`
+}
+
+type profile struct {
+	Audit
+	Name    string                 `json:"name"`
+	Age     int                    `json:"age"`
+	Public  boolutils.BooleanValue `json:"public"`
+	Quota   *wrapperspb.Int64Value `json:"quota"`
+	Enabled bool                   `json:"enabled"`
+	Retries int64                  `json:"retries"`
+	Path    string                 `json:"a/b~c"`
+	Theme   theme                  `json:"theme"`
+	Ignored string                 `json:"-"`
+}
+
+func profileBase() *profile {
+	return &profile{
+		Audit:  Audit{Note: "n"},
+		Name:   "ada",
+		Age:    36,
+		Public: boolutils.BooleanValueTrue(),
+		Quota:  wrapperspb.Int64(10),
+		Path:   "p",
+		Theme:  theme{Accent: "teal", Opacity: 0.5},
+	}
+}
+
+func TestParseMergePatch(t *testing.T) {
+	p, err := ParseMergePatch[profile]([]byte(`{
+		"name": "grace",
+		"age": null,
+		"public": false,
+		"quota": 0,
+		"a/b~c": null,
+		"theme": {"opacity": 1}
+	}`))
+	require.NoError(t, err)
+	require.Equal(t, "grace", p.Value.Name)
+	require.Equal(t, intutils.IntValueUnspecified, p.Value.Age)
+	require.Equal(t, boolutils.BooleanValueFalse(), p.Value.Public)
+	require.True(t, protobufwrapper.IsSpecifiedInt64Value(p.Value.Quota))
+	require.Equal(t, int64(0), p.Value.Quota.GetValue())
+	require.Equal(t, stringutils.StringValueUnspecified, p.Value.Theme.Accent)
+	require.Equal(t, 1.0, p.Value.Theme.Opacity)
+	require.Equal(t, []string{"/age", "/a~1b~0c"}, p.Resets)
+}
+
+func TestParseMergePatchErrors(t *testing.T) {
+	for name, doc := range map[string]string{
+		"array":         `[]`,
+		"null":          `null`,
+		"scalar theme":  `{"theme": 1}`,
+		"unknown":       `{"nope": 1}`,
+		"unknown inner": `{"theme": {"nope": 1}}`,
+		"ignored":       `{"Ignored": "x"}`,
+		"wrong type":    `{"age": "x"}`,
+		"wrong wrapper": `{"quota": "x"}`,
+		"invalid":       `{`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseMergePatch[profile]([]byte(doc))
+			require.Error(t, err)
+		})
+	}
+	_, err := ParseMergePatch[profile]([]byte(`{"nope": 1}`))
+	require.ErrorIs(t, err, ErrUnknownMember)
+	_, err = ParseMergePatch[profile]([]byte(`[]`))
+	require.ErrorIs(t, err, ErrNotObject)
+	_, err = ParseMergePatch[profile]([]byte(`{"name": "\u0000unspecified"}`))
+	require.ErrorIs(t, err, ErrSentinelCollision)
+	_, err = ParseMergePatch[profile]([]byte(`{"age": -9223372036854775808}`))
+	require.ErrorIs(t, err, ErrSentinelCollision)
+	_, err = ParseMergePatch[profile]([]byte(`{"enabled": false}`))
+	require.ErrorIs(t, err, ErrSentinelCollision)
+	_, err = ParseMergePatch[profile]([]byte(`{"retries": 0}`))
+	require.ErrorIs(t, err, ErrSentinelCollision)
+}
+
+func TestApplyZeroConventionFields(t *testing.T) {
+	p, err := ParseMergePatch[profile]([]byte(`{"enabled": true, "retries": 5}`))
+	require.NoError(t, err)
+	out := p.Apply(&profile{Retries: 3})
+	require.True(t, out.Enabled)
+	require.Equal(t, int64(5), out.Retries)
+
+	doc, err := json.Marshal(p)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"enabled":true,"retries":5}`, string(doc))
+}
+
+func TestApply(t *testing.T) {
+	base := profileBase()
+	p, err := ParseMergePatch[profile]([]byte(`{"name": "grace", "age": null, "quota": null, "theme": null}`))
+	require.NoError(t, err)
+
+	out := p.Apply(base)
+	require.Equal(t, "grace", out.Name)
+	require.Equal(t, intutils.IntValueUnspecified, out.Age)
+	require.Same(t, protobufwrapper.Int64ValueUnspecified, out.Quota)
+	require.Equal(t, stringutils.StringValueUnspecified, out.Theme.Accent)
+	require.Equal(t, boolutils.BooleanValueTrue(), out.Public)
+	require.Equal(t, "n", out.Note)
+	require.True(t, diffutils.Equal(profileBase(), base), "base is not modified")
+}
+
+func TestMarshalJSONRoundTrip(t *testing.T) {
+	doc := `{"a/b~c":null,"public":false,"quota":7,"theme":{"accent":"red"}}`
+	var p MergePatch[profile]
+	require.NoError(t, json.Unmarshal([]byte(doc), &p))
+
+	out, err := json.Marshal(&p)
+	require.NoError(t, err)
+	require.JSONEq(t, doc, string(out))
+
+	empty, err := json.Marshal(&MergePatch[profile]{})
+	require.NoError(t, err)
+	require.JSONEq(t, `{}`, string(empty))
+}
+
+func TestCreateMergePatch(t *testing.T) {
+	base, updated := profileBase(), profileBase()
+	updated.Name = ""
+	updated.Age = intutils.IntValueUnspecified
+	updated.Quota = nil
+	updated.Theme.Opacity = 1
+
+	p := CreateMergePatch(base, updated)
+	require.Equal(t, []string{"/age", "/quota"}, p.Resets)
+	require.True(t, diffutils.Equal(updated, p.Apply(base)))
+
+	out, err := json.Marshal(p)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"name":"","age":null,"quota":null,"theme":{"opacity":1}}`, string(out))
+
+	// Parsing the document back gives the same patch.
+	parsed, err := ParseMergePatch[profile](out)
+	require.NoError(t, err)
+	require.Equal(t, p.Resets, parsed.Resets)
+	require.True(t, diffutils.Equal(p.Value, parsed.Value))
+}
+
+func TestCreateMergePatchNil(t *testing.T) {
+	p := CreateMergePatch(profileBase(), nil)
+	require.Len(t, p.Resets, 8)
+	want := diffutils.Unspecified[profile]()
+	want.Ignored = "" // not a JSON member, so kept from base
+	require.True(t, diffutils.Equal(want, p.Apply(profileBase())))
+
+	p = CreateMergePatch(nil, profileBase())
+	require.Empty(t, p.Resets)
+	require.True(t, diffutils.Equal(profileBase(), p.Apply(nil)))
+}
+
+func TestMarshal(t *testing.T) {
+	out, err := Marshal(diffutils.Unspecified[profile]())
+	require.NoError(t, err)
+	require.JSONEq(t, `{"theme":{}}`, string(out))
+
+	out, err = Marshal(profileBase())
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"note":"n","name":"ada","age":36,"public":true,"quota":10,"a/b~c":"p",
+		"theme":{"accent":"teal","opacity":0.5}
+	}`, string(out))
+}
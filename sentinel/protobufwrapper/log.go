@@ -0,0 +1,129 @@
+package protobufwrapper
+
+import (
+	"log/slog"
+
+	"github.com/zodimo/go-sentinel-helper/internal/sentinellog"
+	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// slog bridges. Each LogValueX logs the wrapped value, or "<unspecified>"
+// for nil and the XUnspecified singleton, which would otherwise log as an
+// opaque message or as the zero value.
+
+// LogValueBoolValue returns v as a slog.Value.
+func LogValueBoolValue(v *wrapperspb.BoolValue) slog.Value {
+	if !IsSpecifiedBoolValue(v) {
+		return sentinellog.Value()
+	}
+	return slog.BoolValue(v.GetValue())
+}
+
+// BoolValueAttr returns a slog.Attr for v.
+func BoolValueAttr(key string, v *wrapperspb.BoolValue) slog.Attr {
+	return slog.Attr{Key: key, Value: LogValueBoolValue(v)}
+}
+
+// LogValueBytesValue returns v as a slog.Value.
+func LogValueBytesValue(v *wrapperspb.BytesValue) slog.Value {
+	if !IsSpecifiedBytesValue(v) {
+		return sentinellog.Value()
+	}
+	return slog.AnyValue(v.GetValue())
+}
+
+// BytesValueAttr returns a slog.Attr for v.
+func BytesValueAttr(key string, v *wrapperspb.BytesValue) slog.Attr {
+	return slog.Attr{Key: key, Value: LogValueBytesValue(v)}
+}
+
+// LogValueDoubleValue returns v as a slog.Value.
+func LogValueDoubleValue(v *wrapperspb.DoubleValue) slog.Value {
+	if !IsSpecifiedDoubleValue(v) {
+		return sentinellog.Value()
+	}
+	return slog.Float64Value(v.GetValue())
+}
+
+// DoubleValueAttr returns a slog.Attr for v.
+func DoubleValueAttr(key string, v *wrapperspb.DoubleValue) slog.Attr {
+	return slog.Attr{Key: key, Value: LogValueDoubleValue(v)}
+}
+
+// LogValueFloatValue returns v as a slog.Value.
+func LogValueFloatValue(v *wrapperspb.FloatValue) slog.Value {
+	if !IsSpecifiedFloatValue(v) {
+		return sentinellog.Value()
+	}
+	return slog.Float64Value(float64(v.GetValue()))
+}
+
+// FloatValueAttr returns a slog.Attr for v.
+func FloatValueAttr(key string, v *wrapperspb.FloatValue) slog.Attr {
+	return slog.Attr{Key: key, Value: LogValueFloatValue(v)}
+}
+
+// LogValueInt32Value returns v as a slog.Value.
+func LogValueInt32Value(v *wrapperspb.Int32Value) slog.Value {
+	if !IsSpecifiedInt32Value(v) {
+		return sentinellog.Value()
+	}
+	return slog.Int64Value(int64(v.GetValue()))
+}
+
+// Int32ValueAttr returns a slog.Attr for v.
+func Int32ValueAttr(key string, v *wrapperspb.Int32Value) slog.Attr {
+	return slog.Attr{Key: key, Value: LogValueInt32Value(v)}
+}
+
+// LogValueInt64Value returns v as a slog.Value.
+func LogValueInt64Value(v *wrapperspb.Int64Value) slog.Value {
+	if !IsSpecifiedInt64Value(v) {
+		return sentinellog.Value()
+	}
+	return slog.Int64Value(v.GetValue())
+}
+
+// Int64ValueAttr returns a slog.Attr for v.
+func Int64ValueAttr(key string, v *wrapperspb.Int64Value) slog.Attr {
+	return slog.Attr{Key: key, Value: LogValueInt64Value(v)}
+}
+
+// LogValueStringValue returns v as a slog.Value.
+func LogValueStringValue(v *wrapperspb.StringValue) slog.Value {
+	if !IsSpecifiedStringValue(v) {
+		return sentinellog.Value()
+	}
+	return slog.StringValue(v.GetValue())
+}
+
+// StringValueAttr returns a slog.Attr for v.
+func StringValueAttr(key string, v *wrapperspb.StringValue) slog.Attr {
+	return slog.Attr{Key: key, Value: LogValueStringValue(v)}
+}
+
+// LogValueUInt32Value returns v as a slog.Value.
+func LogValueUInt32Value(v *wrapperspb.UInt32Value) slog.Value {
+	if !IsSpecifiedUInt32Value(v) {
+		return sentinellog.Value()
+	}
+	return slog.Uint64Value(uint64(v.GetValue()))
+}
+
+// UInt32ValueAttr returns a slog.Attr for v.
+func UInt32ValueAttr(key string, v *wrapperspb.UInt32Value) slog.Attr {
+	return slog.Attr{Key: key, Value: LogValueUInt32Value(v)}
+}
+
+// LogValueUInt64Value returns v as a slog.Value.
+func LogValueUInt64Value(v *wrapperspb.UInt64Value) slog.Value {
+	if !IsSpecifiedUInt64Value(v) {
+		return sentinellog.Value()
+	}
+	return slog.Uint64Value(v.GetValue())
+}
+
+// UInt64ValueAttr returns a slog.Attr for v.
+func UInt64ValueAttr(key string, v *wrapperspb.UInt64Value) slog.Attr {
+	return slog.Attr{Key: key, Value: LogValueUInt64Value(v)}
+}
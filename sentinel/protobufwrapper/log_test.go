@@ -0,0 +1,31 @@
+package protobufwrapper
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestLogValues(t *testing.T) {
+	for name, tc := range map[string]struct {
+		specified, unspecified slog.Attr
+		want                   any
+	}{
+		"bool":   {BoolValueAttr("k", wrapperspb.Bool(false)), BoolValueAttr("k", BoolValueUnspecified), false},
+		"bytes":  {BytesValueAttr("k", wrapperspb.Bytes([]byte{1})), BytesValueAttr("k", nil), []byte{1}},
+		"double": {DoubleValueAttr("k", wrapperspb.Double(0.5)), DoubleValueAttr("k", DoubleValueUnspecified), 0.5},
+		"float":  {FloatValueAttr("k", wrapperspb.Float(0.5)), FloatValueAttr("k", nil), 0.5},
+		"int32":  {Int32ValueAttr("k", wrapperspb.Int32(0)), Int32ValueAttr("k", Int32ValueUnspecified), int64(0)},
+		"int64":  {Int64ValueAttr("k", wrapperspb.Int64(-1)), Int64ValueAttr("k", nil), int64(-1)},
+		"string": {StringValueAttr("k", wrapperspb.String("")), StringValueAttr("k", StringValueUnspecified), ""},
+		"uint32": {UInt32ValueAttr("k", wrapperspb.UInt32(3)), UInt32ValueAttr("k", nil), uint64(3)},
+		"uint64": {UInt64ValueAttr("k", wrapperspb.UInt64(3)), UInt64ValueAttr("k", UInt64ValueUnspecified), uint64(3)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.want, tc.specified.Value.Resolve().Any())
+			require.Equal(t, "<unspecified>", tc.unspecified.Value.Resolve().String())
+		})
+	}
+}
@@ -3,6 +3,7 @@ package protobufwrapper
 import (
 	"fmt"
 
+	"github.com/zodimo/go-sentinel-helper/sentinel/stringutils"
 	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
 )
 
@@ -70,11 +71,13 @@ func SameStringValue(a, b *wrapperspb.StringValue) bool {
 }
 
 // 8. SemanticEqual
+// Values compare as stringutils.SemanticEqualString does: canonically
+// equivalent strings are equal.
 func SemanticEqualStringValue(a, b *wrapperspb.StringValue) bool {
 	a = CoalesceStringValue(a, StringValueUnspecified)
 	b = CoalesceStringValue(b, StringValueUnspecified)
 
-	return a.Value == b.Value
+	return stringutils.Canonical.Equal(a.Value, b.Value)
 }
 
 // 9. Equal
@@ -107,3 +107,9 @@ func TestCopyStringValue(t *testing.T) {
 	require.Equal(t, original.Value, copied.Value)
 	require.True(t, original != copied)
 }
+
+func TestSemanticEqualStringValueCanonical(t *testing.T) {
+	require.True(t, SemanticEqualStringValue(wrapperspb.String("caf\u00e9"), wrapperspb.String("cafe\u0301")))
+	require.True(t, EqualStringValue(wrapperspb.String("caf\u00e9"), wrapperspb.String("cafe\u0301")))
+	require.False(t, SameStringValue(wrapperspb.String("caf\u00e9"), wrapperspb.String("cafe\u0301")))
+}
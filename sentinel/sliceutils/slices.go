@@ -0,0 +1,187 @@
+// Package sliceutils gives slices a sentinel so that list fields can tell
+// "inherit" (Unspecified) apart from "explicitly empty" and "populated".
+package sliceutils
+
+import (
+	"fmt"
+	"strings"
+	"unsafe"
+)
+
+// unspecifiedBacking is the shared backing array of every Unspecified
+// slice. It is not zero-sized on purpose: zero-sized variables may share
+// the runtime's zerobase address with ordinary empty slices, which would
+// make `make([]T, 0)` indistinguishable from the sentinel.
+var unspecifiedBacking [8]uint64
+
+// 1. Sentinel - a zero-length, zero-capacity slice over unspecifiedBacking.
+// Appending to it always reallocates, so the shared array is never written.
+func Unspecified[T any]() []T {
+	return unsafe.Slice((*T)(unsafe.Pointer(&unspecifiedBacking)), 0)
+}
+
+func isSentinel[T any](s []T) bool {
+	return unsafe.Pointer(unsafe.SliceData(s)) == unsafe.Pointer(&unspecifiedBacking)
+}
+
+// 2. IsSpecified - nil is treated as Unspecified, a non-nil empty slice is
+// specified.
+func IsSpecifiedSlice[T any](s []T) bool {
+	return s != nil && !isSentinel(s)
+}
+
+// IsUnspecifiedSlice - convenience predicate
+func IsUnspecifiedSlice[T any](s []T) bool {
+	return !IsSpecifiedSlice(s)
+}
+
+// 3. TakeOrElse - 2-param fallback
+func TakeOrElseSlice[T any](s, def []T) []T {
+	if IsSpecifiedSlice(s) {
+		return s
+	}
+	return def
+}
+
+// 4. Merge - composition merge. Every strategy returns a copy of the other
+// operand when one side is Unspecified and a fresh slice when both are
+// specified, so the result never aliases a specified input. Elements are
+// copied by value; see CopySlice for deep copies.
+
+// MergeSlice replaces a with b when b is specified.
+func MergeSlice[T any](a, b []T) []T {
+	a = CoalesceSlice(a, Unspecified[T]())
+	b = CoalesceSlice(b, Unspecified[T]())
+
+	if isSentinel(a) {
+		return CopySlice(b, nil)
+	}
+	if isSentinel(b) {
+		return CopySlice(a, nil)
+	}
+	return append(make([]T, 0, len(b)), b...)
+}
+
+// MergeSliceAppend returns the elements of a followed by those of b.
+func MergeSliceAppend[T any](a, b []T) []T {
+	a = CoalesceSlice(a, Unspecified[T]())
+	b = CoalesceSlice(b, Unspecified[T]())
+
+	if isSentinel(a) {
+		return CopySlice(b, nil)
+	}
+	if isSentinel(b) {
+		return CopySlice(a, nil)
+	}
+	out := make([]T, 0, len(a)+len(b))
+	return append(append(out, a...), b...)
+}
+
+// MergeSlicePrepend returns the elements of b followed by those of a.
+func MergeSlicePrepend[T any](a, b []T) []T {
+	return MergeSliceAppend(b, a)
+}
+
+// MergeSliceByIndex merges element i of a with element i of b using merge,
+// e.g. intutils.MergeIntValue. The result has the length of the longer
+// slice; indices past the end of the shorter one are taken unchanged.
+func MergeSliceByIndex[T any](a, b []T, merge func(a, b T) T) []T {
+	a = CoalesceSlice(a, Unspecified[T]())
+	b = CoalesceSlice(b, Unspecified[T]())
+
+	if isSentinel(a) {
+		return CopySlice(b, nil)
+	}
+	if isSentinel(b) {
+		return CopySlice(a, nil)
+	}
+	out := make([]T, max(len(a), len(b)))
+	for i := range out {
+		switch {
+		case i >= len(a):
+			out[i] = b[i]
+		case i >= len(b):
+			out[i] = a[i]
+		default:
+			out[i] = merge(a[i], b[i])
+		}
+	}
+	return out
+}
+
+// MergeSliceByIndexFunc adapts MergeSliceByIndex to the two-argument merge
+// signature shared by the other strategies.
+func MergeSliceByIndexFunc[T any](merge func(a, b T) T) func(a, b []T) []T {
+	return func(a, b []T) []T {
+		return MergeSliceByIndex(a, b, merge)
+	}
+}
+
+// 5. String - stringification; str formats a single element
+func StringSlice[T any](s []T, str func(T) string) string {
+	if IsUnspecifiedSlice(s) {
+		return "Slice{Unspecified}"
+	}
+	parts := make([]string, len(s))
+	for i, v := range s {
+		parts[i] = str(v)
+	}
+	return fmt.Sprintf("Slice{[%s]}", strings.Join(parts, ", "))
+}
+
+// 6. Coalesce - nil coalescing
+func CoalesceSlice[T any](s, def []T) []T {
+	if s == nil {
+		return def
+	}
+	return s
+}
+
+// 7. Same - identity: the same backing array and length.
+// nil and the sentinel are the same Unspecified slice.
+func SameSlice[T any](a, b []T) bool {
+	if IsUnspecifiedSlice(a) || IsUnspecifiedSlice(b) {
+		return IsUnspecifiedSlice(a) && IsUnspecifiedSlice(b)
+	}
+	return unsafe.SliceData(a) == unsafe.SliceData(b) && len(a) == len(b)
+}
+
+// 8. SemanticEqual - same length and pairwise equal elements under eq,
+// e.g. floatutils.SemanticEqual[float32].
+func SemanticEqualSlice[T any](a, b []T, eq func(a, b T) bool) bool {
+	if IsUnspecifiedSlice(a) || IsUnspecifiedSlice(b) {
+		return IsUnspecifiedSlice(a) && IsUnspecifiedSlice(b)
+	}
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !eq(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// 9. Equal - equality check (combines Same and SemanticEqual)
+func EqualSlice[T any](a, b []T, eq func(a, b T) bool) bool {
+	return SameSlice(a, b) || SemanticEqualSlice(a, b, eq)
+}
+
+// 10. Copy - deep copy using each element's own Copy function, e.g.
+// protobufwrapper.CopyInt32Value. A nil copyElem copies elements by value.
+// Unspecified copies to the sentinel.
+func CopySlice[T any](s []T, copyElem func(T) T) []T {
+	if IsUnspecifiedSlice(s) {
+		return Unspecified[T]()
+	}
+	out := make([]T, len(s))
+	if copyElem == nil {
+		copy(out, s)
+		return out
+	}
+	for i, v := range s {
+		out[i] = copyElem(v)
+	}
+	return out
+}
@@ -0,0 +1,157 @@
+package sliceutils
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zodimo/go-sentinel-helper/sentinel/floatutils"
+	"github.com/zodimo/go-sentinel-helper/sentinel/intutils"
+	"github.com/zodimo/go-sentinel-helper/sentinel/protobufwrapper"
+	"github.com/zodimo/go-sentinel-helper/sentinel/stringutils"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func eqInt(a, b int) bool { return a == b }
+
+func TestUnspecified(t *testing.T) {
+	u := Unspecified[string]()
+	require.NotNil(t, u)
+	require.Len(t, u, 0)
+	require.Equal(t, 0, cap(u))
+	require.True(t, IsUnspecifiedSlice(u))
+	require.True(t, IsUnspecifiedSlice(Unspecified[struct{ a, b, c int64 }]()))
+
+	// Appending never writes into the shared backing array.
+	grown := append(u, "x")
+	require.True(t, IsSpecifiedSlice(grown))
+	require.True(t, IsUnspecifiedSlice(Unspecified[string]()))
+}
+
+func TestIsSpecifiedSlice(t *testing.T) {
+	tests := []struct {
+		name string
+		s    []int
+		want bool
+	}{
+		{"sentinel", Unspecified[int](), false},
+		{"nil", nil, false},
+		{"empty literal", []int{}, true},
+		{"empty make", make([]int, 0), true},
+		{"empty reslice", []int{1, 2}[:0], true},
+		{"populated", []int{1}, true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, IsSpecifiedSlice(tc.s))
+			require.Equal(t, !tc.want, IsUnspecifiedSlice(tc.s))
+		})
+	}
+}
+
+func TestTakeOrElseSlice(t *testing.T) {
+	def := []int{9}
+	require.Equal(t, []int{1}, TakeOrElseSlice([]int{1}, def))
+	require.Equal(t, []int{}, TakeOrElseSlice([]int{}, def))
+	require.Equal(t, def, TakeOrElseSlice(nil, def))
+	require.Equal(t, def, TakeOrElseSlice(Unspecified[int](), def))
+}
+
+func TestMergeStrategies(t *testing.T) {
+	a := []int{1, 2, 3}
+	b := []int{10, intutils.IntValueUnspecified}
+	u := Unspecified[int]()
+
+	tests := []struct {
+		name  string
+		merge func(a, b []int) []int
+		want  []int
+	}{
+		{"replace", MergeSlice[int], []int{10, intutils.IntValueUnspecified}},
+		{"append", MergeSliceAppend[int], []int{1, 2, 3, 10, intutils.IntValueUnspecified}},
+		{"prepend", MergeSlicePrepend[int], []int{10, intutils.IntValueUnspecified, 1, 2, 3}},
+		{"by index", MergeSliceByIndexFunc(intutils.MergeIntValue), []int{10, 2, 3}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, tc.merge(a, b))
+			require.Equal(t, a, tc.merge(a, u))
+			require.Equal(t, a, tc.merge(a, nil))
+			require.Equal(t, b, tc.merge(u, b))
+			require.True(t, IsUnspecifiedSlice(tc.merge(u, nil)))
+
+			// An explicit empty list is specified.
+			require.NotNil(t, tc.merge(nil, []int{}))
+
+			got := tc.merge(a, b)
+			got[0] = -1
+			require.Equal(t, []int{1, 2, 3}, a)
+			require.Equal(t, 10, b[0])
+
+			// One side Unspecified still returns a copy.
+			tc.merge(a, u)[0] = -1
+			tc.merge(u, b)[0] = -1
+			require.Equal(t, 1, a[0])
+			require.Equal(t, 10, b[0])
+		})
+	}
+
+	require.Equal(t, []int{}, MergeSlice([]int{1}, []int{}))
+	require.Equal(t, []int{10, 2}, MergeSliceByIndex([]int{1, 2}, []int{10}, intutils.MergeIntValue))
+}
+
+func TestStringSlice(t *testing.T) {
+	require.Equal(t, "Slice{Unspecified}", StringSlice(Unspecified[int](), strconv.Itoa))
+	require.Equal(t, "Slice{Unspecified}", StringSlice(nil, strconv.Itoa))
+	require.Equal(t, "Slice{[]}", StringSlice([]int{}, strconv.Itoa))
+	require.Equal(t, `Slice{[StringValue{"a"}, StringValue{Unspecified}]}`,
+		StringSlice([]string{"a", stringutils.StringValueUnspecified}, stringutils.StringString))
+}
+
+func TestCoalesceSlice(t *testing.T) {
+	require.Equal(t, []int{1}, CoalesceSlice(nil, []int{1}))
+	require.Equal(t, []int{}, CoalesceSlice([]int{}, []int{1}))
+}
+
+func TestSliceEquality(t *testing.T) {
+	a := []int{1, 2}
+	tests := []struct {
+		name           string
+		a, b           []int
+		same, semantic bool
+	}{
+		{"nil and sentinel", nil, Unspecified[int](), true, true},
+		{"unspecified and empty", nil, []int{}, false, false},
+		{"identical", a, a, true, true},
+		{"prefix", a, a[:1], false, false},
+		{"equal copies", a, []int{1, 2}, false, true},
+		{"different", a, []int{2, 1}, false, false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.same, SameSlice(tc.a, tc.b))
+			require.Equal(t, tc.semantic, SemanticEqualSlice(tc.a, tc.b, eqInt))
+			require.Equal(t, tc.same || tc.semantic, EqualSlice(tc.a, tc.b, eqInt))
+		})
+	}
+
+	require.True(t, SemanticEqualSlice([]float32{1}, []float32{1 + 1e-7}, floatutils.SemanticEqual[float32]))
+}
+
+func TestCopySlice(t *testing.T) {
+	src := []*wrapperspb.Int32Value{wrapperspb.Int32(1), protobufwrapper.Int32ValueUnspecified}
+	dst := CopySlice(src, protobufwrapper.CopyInt32Value)
+	require.Len(t, dst, 2)
+	require.NotSame(t, src[0], dst[0])
+	require.Equal(t, int32(1), dst[0].GetValue())
+	require.Same(t, protobufwrapper.Int32ValueUnspecified, dst[1])
+
+	ints := []int{1, 2}
+	shallow := CopySlice(ints, nil)
+	shallow[0] = 5
+	require.Equal(t, []int{1, 2}, ints)
+
+	require.True(t, IsUnspecifiedSlice(CopySlice[int](nil, nil)))
+	require.True(t, isSentinel(CopySlice(Unspecified[int](), nil)))
+	require.Equal(t, []int{}, CopySlice([]int{}, nil))
+}
@@ -0,0 +1,172 @@
+// Package slogutils makes sentinel values readable in log/slog output.
+//
+// The per-package constructors (intutils.IntValueAttr,
+// protobufwrapper.Int32ValueAttr, …) and the LogValue methods on
+// BooleanValue and Enum already log Unspecified as "<unspecified>".
+// Handler covers attributes built without them, such as
+// slog.Int("n", intutils.IntValueUnspecified), and can drop Unspecified
+// attributes instead.
+package slogutils
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"reflect"
+
+	"github.com/zodimo/go-sentinel-helper/internal/sentinellog"
+	"github.com/zodimo/go-sentinel-helper/internal/sentinelreflect"
+	"github.com/zodimo/go-sentinel-helper/sentinel/intutils"
+	"github.com/zodimo/go-sentinel-helper/sentinel/protobufwrapper"
+	"github.com/zodimo/go-sentinel-helper/sentinel/stringutils"
+	"github.com/zodimo/go-sentinel-helper/sentinel/timeutils"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// UnspecifiedText is how Handler writes an Unspecified value.
+const UnspecifiedText = sentinellog.Text
+
+// Mode selects what Handler does with an Unspecified attribute.
+type Mode uint8
+
+const (
+	// RewriteUnspecified replaces the value with UnspecifiedText.
+	RewriteUnspecified Mode = iota
+	// DropUnspecified removes the attribute.
+	DropUnspecified
+)
+
+// maxResolve bounds LogValuer chains, as slog itself does.
+const maxResolve = 100
+
+// IsUnspecified reports whether v holds a sentinel:
+//   - the marker logged by the sentinel packages' Attr constructors
+//   - intutils.IntValueUnspecified as an int
+//   - stringutils.StringValueUnspecified
+//   - NaN, the floatutils Unspecified
+//   - timeutils.DurationUnspecified and timeutils.TimeUnspecified
+//   - a nil or Unspecified wrapperspb pointer
+//   - an Unspecified value of one of this module's types (Enum, Decimal, …)
+//
+// LogValuers are resolved first.
+func IsUnspecified(v slog.Value) bool {
+	for range maxResolve {
+		switch v.Kind() {
+		case slog.KindLogValuer:
+			if _, ok := v.LogValuer().(sentinellog.Unspecified); ok {
+				return true
+			}
+			v = v.LogValuer().LogValue()
+		case slog.KindInt64:
+			return v.Int64() == int64(intutils.IntValueUnspecified)
+		case slog.KindString:
+			return v.String() == stringutils.StringValueUnspecified
+		case slog.KindFloat64:
+			return math.IsNaN(v.Float64())
+		case slog.KindDuration:
+			return timeutils.IsUnspecifiedDuration(v.Duration())
+		case slog.KindTime:
+			return timeutils.IsUnspecifiedTime(v.Time())
+		case slog.KindAny:
+			return isUnspecifiedAny(v.Any())
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+func isUnspecifiedAny(a any) bool {
+	switch x := a.(type) {
+	case sentinellog.Unspecified:
+		return true
+	case *wrapperspb.BoolValue:
+		return !protobufwrapper.IsSpecifiedBoolValue(x)
+	case *wrapperspb.BytesValue:
+		return !protobufwrapper.IsSpecifiedBytesValue(x)
+	case *wrapperspb.DoubleValue:
+		return !protobufwrapper.IsSpecifiedDoubleValue(x)
+	case *wrapperspb.FloatValue:
+		return !protobufwrapper.IsSpecifiedFloatValue(x)
+	case *wrapperspb.Int32Value:
+		return !protobufwrapper.IsSpecifiedInt32Value(x)
+	case *wrapperspb.Int64Value:
+		return !protobufwrapper.IsSpecifiedInt64Value(x)
+	case *wrapperspb.StringValue:
+		return !protobufwrapper.IsSpecifiedStringValue(x)
+	case *wrapperspb.UInt32Value:
+		return !protobufwrapper.IsSpecifiedUInt32Value(x)
+	case *wrapperspb.UInt64Value:
+		return !protobufwrapper.IsSpecifiedUInt64Value(x)
+	}
+	// Only this module's value types: foreign IsUnspecified methods, such as
+	// netip.Addr's, mean something else.
+	if a != nil && sentinelreflect.IsValueType(reflect.TypeOf(a)) {
+		return a.(interface{ IsUnspecified() bool }).IsUnspecified()
+	}
+	return false
+}
+
+// Handler wraps another slog.Handler and rewrites or drops Unspecified
+// attributes, including those inside groups and those added via With.
+type Handler struct {
+	next slog.Handler
+	mode Mode
+}
+
+// NewHandler returns a Handler that passes records on to next.
+func NewHandler(next slog.Handler, mode Mode) *Handler {
+	return &Handler{next: next, mode: mode}
+}
+
+// Enabled implements slog.Handler.
+func (h *Handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle implements slog.Handler.
+func (h *Handler) Handle(ctx context.Context, r slog.Record) error {
+	out := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		if a, ok := h.rewrite(a); ok {
+			out.AddAttrs(a)
+		}
+		return true
+	})
+	return h.next.Handle(ctx, out)
+}
+
+// WithAttrs implements slog.Handler.
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &Handler{next: h.next.WithAttrs(h.rewriteAll(attrs)), mode: h.mode}
+}
+
+// WithGroup implements slog.Handler.
+func (h *Handler) WithGroup(name string) slog.Handler {
+	return &Handler{next: h.next.WithGroup(name), mode: h.mode}
+}
+
+// rewrite applies the mode to a; ok is false when a is dropped.
+func (h *Handler) rewrite(a slog.Attr) (_ slog.Attr, ok bool) {
+	if IsUnspecified(a.Value) {
+		if h.mode == DropUnspecified {
+			return slog.Attr{}, false
+		}
+		return slog.String(a.Key, UnspecifiedText), true
+	}
+	a.Value = a.Value.Resolve()
+	if a.Value.Kind() == slog.KindGroup {
+		a.Value = slog.GroupValue(h.rewriteAll(a.Value.Group())...)
+	}
+	return a, true
+}
+
+func (h *Handler) rewriteAll(attrs []slog.Attr) []slog.Attr {
+	out := make([]slog.Attr, 0, len(attrs))
+	for _, a := range attrs {
+		if a, ok := h.rewrite(a); ok {
+			out = append(out, a)
+		}
+	}
+	return out
+}
@@ -0,0 +1,84 @@
+package slogutils
+
+import (
+	"bytes"
+	"log/slog"
+	"math"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zodimo/go-sentinel-helper/sentinel/boolutils"
+	"github.com/zodimo/go-sentinel-helper/sentinel/floatutils"
+	"github.com/zodimo/go-sentinel-helper/sentinel/intutils"
+	"github.com/zodimo/go-sentinel-helper/sentinel/protobufwrapper"
+	"github.com/zodimo/go-sentinel-helper/sentinel/stringutils"
+	"github.com/zodimo/go-sentinel-helper/sentinel/timeutils"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func newLogger(mode Mode) (*slog.Logger, *bytes.Buffer) {
+	var buf bytes.Buffer
+	text := slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey || a.Key == slog.LevelKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+	return slog.New(NewHandler(text, mode)), &buf
+}
+
+func TestIsUnspecified(t *testing.T) {
+	for name, v := range map[string]any{
+		"int":          intutils.IntValueUnspecified,
+		"string":       stringutils.StringValueUnspecified,
+		"float32":      floatutils.Float32Unspecified,
+		"float64":      floatutils.Float64Unspecified,
+		"bool":         boolutils.BooleanValue{},
+		"wrapper":      protobufwrapper.Int32ValueUnspecified,
+		"nil wrapper":  (*wrapperspb.StringValue)(nil),
+		"duration":     timeutils.DurationUnspecified,
+		"time":         timeutils.TimeUnspecified,
+		"attr builder": intutils.IntValueAttr("k", intutils.IntValueUnspecified).Value,
+	} {
+		t.Run(name, func(t *testing.T) {
+			if sv, ok := v.(slog.Value); ok {
+				require.True(t, IsUnspecified(sv))
+				return
+			}
+			require.True(t, IsUnspecified(slog.AnyValue(v)))
+		})
+	}
+	for _, v := range []any{0, "", math.Inf(1), time.Duration(0), time.Time{}, boolutils.BooleanValueFalse(), wrapperspb.Int32(0), nil, []int{}, netip.IPv4Unspecified()} {
+		require.False(t, IsUnspecified(slog.AnyValue(v)), "%#v", v)
+	}
+}
+
+func TestHandlerRewrite(t *testing.T) {
+	logger, buf := newLogger(RewriteUnspecified)
+	logger.With("base", stringutils.StringValueUnspecified).
+		WithGroup("g").
+		Info("m",
+			"n", intutils.IntValueUnspecified,
+			"s", "ok",
+			slog.Group("inner", "f", floatutils.Float64Unspecified, protobufwrapper.Int64ValueAttr("q", wrapperspb.Int64(2))),
+		)
+	require.Equal(t,
+		`msg=m base=<unspecified> g.n=<unspecified> g.s=ok g.inner.f=<unspecified> g.inner.q=2`,
+		strings.TrimSpace(buf.String()))
+}
+
+func TestHandlerDrop(t *testing.T) {
+	logger, buf := newLogger(DropUnspecified)
+	logger.With("base", boolutils.BooleanValue{}, "keep", 1).Info("m",
+		"n", intutils.IntValueUnspecified,
+		"w", (*wrapperspb.BoolValue)(nil),
+		slog.Group("inner", "f", floatutils.Float32Unspecified),
+		intutils.IntValueAttr("i", 3),
+	)
+	require.Equal(t, `msg=m keep=1 i=3`, strings.TrimSpace(buf.String()))
+}
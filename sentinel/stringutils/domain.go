@@ -0,0 +1,112 @@
+package stringutils
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/zodimo/go-sentinel-helper/internal/sentinellog"
+)
+
+// Domain chooses the sentinel for a domain string type. Pick a value that
+// valid input can never take, so collisions are impossible by
+// construction rather than guarded against:
+//
+//	type emailDomain struct{}
+//
+//	func (emailDomain) Sentinel() string { return "" } // "" is never a valid address
+//
+//	type Email = stringutils.DomainString[emailDomain]
+type Domain interface {
+	Sentinel() string
+}
+
+// DomainString is a string whose Unspecified value is D's sentinel.
+type DomainString[D Domain] string
+
+// 1. Sentinel - DomainUnspecified
+func DomainUnspecified[D Domain]() DomainString[D] {
+	var d D
+	return DomainString[D](d.Sentinel())
+}
+
+// DomainFromUntrusted converts external input to a DomainString, rejecting
+// D's sentinel with ErrSentinelCollision.
+func DomainFromUntrusted[D Domain](s string) (DomainString[D], error) {
+	v := DomainString[D](s)
+	if v.IsUnspecified() {
+		return v, ErrSentinelCollision
+	}
+	return v, nil
+}
+
+// 2. IsSpecified - predicate (method on value receiver)
+func (s DomainString[D]) IsSpecified() bool {
+	return s != DomainUnspecified[D]()
+}
+
+// IsUnspecified - convenience predicate
+func (s DomainString[D]) IsUnspecified() bool {
+	return s == DomainUnspecified[D]()
+}
+
+// SetUnspecified - stores DomainUnspecified in the receiver
+func (s *DomainString[D]) SetUnspecified() {
+	*s = DomainUnspecified[D]()
+}
+
+// 3. TakeOrElse - returns s if specified, otherwise def
+func (s DomainString[D]) TakeOrElse(def DomainString[D]) DomainString[D] {
+	if s.IsSpecified() {
+		return s
+	}
+	return def
+}
+
+// 4. Merge - prefers other if specified
+func (s DomainString[D]) Merge(other DomainString[D]) DomainString[D] {
+	if other.IsSpecified() {
+		return other
+	}
+	return s
+}
+
+// 5. String - implements fmt.Stringer
+func (s DomainString[D]) String() string {
+	if s.IsUnspecified() {
+		return "DomainString{Unspecified}"
+	}
+	return fmt.Sprintf("DomainString{%q}", string(s))
+}
+
+// 6. Coalesce - N/A for value types
+
+// 7. Same - identity
+func (s DomainString[D]) Same(other DomainString[D]) bool {
+	return s == other
+}
+
+// 8. SemanticEqual - canonical equivalence, as SemanticEqualString
+func (s DomainString[D]) SemanticEqual(other DomainString[D]) bool {
+	if s.IsUnspecified() || other.IsUnspecified() {
+		return s == other
+	}
+	return Canonical.Equal(StringValue(s), StringValue(other))
+}
+
+// 9. Equal - equality check: Same or SemanticEqual
+func (s DomainString[D]) Equal(other DomainString[D]) bool {
+	return s.Same(other) || s.SemanticEqual(other)
+}
+
+// 10. Copy - identity for immutable value types
+func (s DomainString[D]) Copy() DomainString[D] {
+	return s
+}
+
+// LogValue implements slog.LogValuer.
+func (s DomainString[D]) LogValue() slog.Value {
+	if s.IsUnspecified() {
+		return sentinellog.Value()
+	}
+	return slog.StringValue(string(s))
+}
@@ -0,0 +1,49 @@
+package stringutils
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+type emailDomain struct{}
+
+func (emailDomain) Sentinel() string { return "" }
+
+type Email = DomainString[emailDomain]
+
+func TestDomainString(t *testing.T) {
+	var zero Email
+	if !zero.IsUnspecified() || zero != DomainUnspecified[emailDomain]() {
+		t.Error("with \"\" as sentinel the zero Email is Unspecified")
+	}
+	if !Email("\x00unspecified").IsSpecified() {
+		t.Error("the default sentinel is an ordinary value in this domain")
+	}
+
+	a := Email("a@example.com")
+	if got := zero.TakeOrElse(a); got != a {
+		t.Errorf("TakeOrElse = %v, want %v", got, a)
+	}
+	if got := a.Merge(zero); got != a {
+		t.Errorf("Merge(Unspecified) = %v, want %v", got, a)
+	}
+	if !a.Equal(a.Copy()) || !a.Same(a) || !a.SemanticEqual(a) || a.Equal(zero) {
+		t.Error("equality mismatch")
+	}
+	if got := fmt.Sprint(zero, " ", a); got != `DomainString{Unspecified} DomainString{"a@example.com"}` {
+		t.Errorf("Sprint = %q", got)
+	}
+	if got := zero.LogValue().Resolve().String(); got != "<unspecified>" {
+		t.Errorf("LogValue = %q", got)
+	}
+}
+
+func TestDomainFromUntrusted(t *testing.T) {
+	if _, err := DomainFromUntrusted[emailDomain](""); !errors.Is(err, ErrSentinelCollision) {
+		t.Errorf("err = %v, want ErrSentinelCollision", err)
+	}
+	if v, err := DomainFromUntrusted[emailDomain]("\x00unspecified"); err != nil || !v.IsSpecified() {
+		t.Errorf("DomainFromUntrusted = %v, %v", v, err)
+	}
+}
@@ -0,0 +1,197 @@
+package stringutils
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"unique"
+
+	"github.com/zodimo/go-sentinel-helper/internal/sentinellog"
+)
+
+// InternedString is a canonicalised string: every InternedString holding
+// the same bytes shares one copy, so style trees repeating the same font
+// family or locale thousands of times store it once, and Same is a single
+// pointer comparison. Interning uses unique.Make, which is safe for
+// concurrent use and releases values nothing references any more.
+//
+// Uses Pattern 1-D: the zero value is Unspecified.
+type InternedString struct {
+	h unique.Handle[string]
+}
+
+// 1. Sentinel - InternedStringUnspecified
+var InternedStringUnspecified = InternedString{}
+
+// Intern returns the canonical InternedString for s;
+// StringValueUnspecified maps to InternedStringUnspecified.
+func Intern(s StringValue) InternedString {
+	if s == StringValueUnspecified {
+		return InternedStringUnspecified
+	}
+	return InternedString{h: unique.Make(s)}
+}
+
+// Value returns the interned string, or StringValueUnspecified.
+func (s InternedString) Value() StringValue {
+	if s.IsUnspecified() {
+		return StringValueUnspecified
+	}
+	return s.h.Value()
+}
+
+// StringOrElse returns the interned string, or def if s is Unspecified.
+func (s InternedString) StringOrElse(def string) string {
+	if s.IsUnspecified() {
+		return def
+	}
+	return s.h.Value()
+}
+
+// 2. IsSpecified - predicate (method on value receiver)
+func (s InternedString) IsSpecified() bool {
+	return s != InternedStringUnspecified
+}
+
+// IsUnspecified - convenience predicate
+func (s InternedString) IsUnspecified() bool {
+	return s == InternedStringUnspecified
+}
+
+// SetUnspecified - stores InternedStringUnspecified in the receiver
+func (s *InternedString) SetUnspecified() {
+	*s = InternedStringUnspecified
+}
+
+// 3. TakeOrElse - returns s if specified, otherwise def
+func (s InternedString) TakeOrElse(def InternedString) InternedString {
+	if s.IsSpecified() {
+		return s
+	}
+	return def
+}
+
+// 4. Merge - prefers other if specified
+func (s InternedString) Merge(other InternedString) InternedString {
+	if other.IsSpecified() {
+		return other
+	}
+	return s
+}
+
+// 5. String - implements fmt.Stringer
+func (s InternedString) String() string {
+	if s.IsUnspecified() {
+		return "InternedString{Unspecified}"
+	}
+	return fmt.Sprintf("InternedString{%q}", s.h.Value())
+}
+
+// 6. Coalesce - N/A for value types
+
+// 7. Same - handle identity; equal bytes always share a handle
+func (s InternedString) Same(other InternedString) bool {
+	return s == other
+}
+
+// 8. SemanticEqual - canonical equivalence, as SemanticEqualString
+func (s InternedString) SemanticEqual(other InternedString) bool {
+	return s == other || Canonical.Equal(s.Value(), other.Value())
+}
+
+// 9. Equal - equality check: Same or SemanticEqual
+func (s InternedString) Equal(other InternedString) bool {
+	return s.Same(other) || s.SemanticEqual(other)
+}
+
+// 10. Copy - identity: the handle is immutable and shared by design
+func (s InternedString) Copy() InternedString {
+	return s
+}
+
+// Package-level forms of the contract, for use as function values.
+func IsSpecifiedInternedString(s InternedString) bool             { return s.IsSpecified() }
+func TakeOrElseInternedString(a, b InternedString) InternedString { return a.TakeOrElse(b) }
+func MergeInternedString(a, b InternedString) InternedString      { return a.Merge(b) }
+func StringInternedString(s InternedString) string                { return s.String() }
+func SameInternedString(a, b InternedString) bool                 { return a.Same(b) }
+func SemanticEqualInternedString(a, b InternedString) bool        { return a.SemanticEqual(b) }
+func EqualInternedString(a, b InternedString) bool                { return a.Equal(b) }
+func CopyInternedString(s InternedString) InternedString          { return s.Copy() }
+
+// IsZero reports whether s is Unspecified, so that encoders honouring
+// `omitempty`/`omitzero` drop it.
+func (s InternedString) IsZero() bool {
+	return s.IsUnspecified()
+}
+
+// MarshalText implements encoding.TextMarshaler. Unspecified encodes as
+// the empty string; UnmarshalText always decodes a specified string. Use
+// JSON or YAML to round-trip Unspecified.
+func (s InternedString) MarshalText() ([]byte, error) {
+	if s.IsUnspecified() {
+		return []byte{}, nil
+	}
+	return []byte(s.h.Value()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Input equal to the
+// sentinel fails with ErrSentinelCollision.
+func (s *InternedString) UnmarshalText(text []byte) error {
+	v, err := FromUntrusted(string(text))
+	if err != nil {
+		return err
+	}
+	*s = Intern(v)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. Unspecified encodes as null.
+func (s InternedString) MarshalJSON() ([]byte, error) {
+	if s.IsUnspecified() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(s.h.Value())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. null decodes to Unspecified;
+// a string equal to the sentinel fails with ErrSentinelCollision.
+func (s *InternedString) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*s = InternedStringUnspecified
+		return nil
+	}
+	var v string
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	return s.UnmarshalText([]byte(v))
+}
+
+// MarshalYAML implements yaml.Marshaler without importing a YAML package.
+// Unspecified encodes as null.
+func (s InternedString) MarshalYAML() (any, error) {
+	if s.IsUnspecified() {
+		return nil, nil
+	}
+	return s.h.Value(), nil
+}
+
+// UnmarshalYAML implements the yaml.v2/v3 obsolete unmarshaler interface.
+// Null nodes never reach it, and a null or absent key leaves the field
+// as is.
+func (s *InternedString) UnmarshalYAML(unmarshal func(any) error) error {
+	var v string
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	return s.UnmarshalText([]byte(v))
+}
+
+// LogValue implements slog.LogValuer.
+func (s InternedString) LogValue() slog.Value {
+	if s.IsUnspecified() {
+		return sentinellog.Value()
+	}
+	return slog.StringValue(s.h.Value())
+}
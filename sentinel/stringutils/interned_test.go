@@ -0,0 +1,165 @@
+package stringutils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// build returns a fresh copy of s so tests do not rely on the compiler
+// sharing string constants.
+func build(s string) string {
+	return strings.Clone(s)
+}
+
+func TestInternedString(t *testing.T) {
+	var zero InternedString
+	if !zero.IsUnspecified() || zero != InternedStringUnspecified {
+		t.Error("the zero InternedString should be Unspecified")
+	}
+	if !Intern(StringValueUnspecified).IsUnspecified() || zero.Value() != StringValueUnspecified {
+		t.Error("Intern and Value should map sentinel to sentinel")
+	}
+
+	a, b := Intern(build("Roboto")), Intern(build("Roboto"))
+	if !SameInternedString(a, b) || !a.Equal(b) {
+		t.Error("equal strings should share a handle")
+	}
+	if a.Same(Intern("roboto")) {
+		t.Error("different strings should not share a handle")
+	}
+	if e := Intern(""); !e.IsSpecified() || e.Value() != "" {
+		t.Error("the empty string is a specified value")
+	}
+	if got := zero.TakeOrElse(a); got != a {
+		t.Errorf("TakeOrElse = %v", got)
+	}
+	if got := a.Merge(zero); got != a {
+		t.Errorf("Merge(Unspecified) = %v", got)
+	}
+	if got := zero.StringOrElse("d"); got != "d" {
+		t.Errorf("StringOrElse = %q", got)
+	}
+	if a.Copy() != a {
+		t.Error("Copy should return the same handle")
+	}
+}
+
+func TestInternedString_SemanticEqual(t *testing.T) {
+	c, d := Intern(composed), Intern(decomposed)
+	if c.Same(d) || !SemanticEqualInternedString(c, d) {
+		t.Error("canonically equivalent strings are semantically equal but not the same")
+	}
+	if InternedStringUnspecified.SemanticEqual(Intern("")) {
+		t.Error("Unspecified should only equal itself")
+	}
+}
+
+func TestInternedString_Format(t *testing.T) {
+	got := fmt.Sprint(Intern("en-GB"), " ", InternedStringUnspecified)
+	if want := `InternedString{"en-GB"} InternedString{Unspecified}`; got != want {
+		t.Errorf("Sprint = %q, want %q", got, want)
+	}
+	if got := InternedStringUnspecified.LogValue().Resolve().String(); got != "<unspecified>" {
+		t.Errorf("LogValue = %q", got)
+	}
+}
+
+func TestInternedString_JSON(t *testing.T) {
+	type doc struct {
+		A InternedString `json:"a"`
+		B InternedString `json:"b,omitzero"`
+		C InternedString `json:"c"`
+	}
+	out, err := json.Marshal(doc{A: Intern("x")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"a":"x","c":null}`; string(out) != want {
+		t.Errorf("Marshal = %s, want %s", out, want)
+	}
+
+	var d doc
+	if err := json.Unmarshal([]byte(`{"a":"x","c":null}`), &d); err != nil {
+		t.Fatal(err)
+	}
+	if !d.A.Same(Intern("x")) || d.C.IsSpecified() {
+		t.Errorf("Unmarshal = %+v", d)
+	}
+	if err := json.Unmarshal([]byte(`{"a":"\u0000unspecified"}`), &d); !errors.Is(err, ErrSentinelCollision) {
+		t.Errorf("err = %v, want ErrSentinelCollision", err)
+	}
+}
+
+func TestInternedString_Text(t *testing.T) {
+	var s InternedString
+	if err := s.UnmarshalText([]byte("en-GB")); err != nil || !s.Same(Intern("en-GB")) {
+		t.Errorf("UnmarshalText = %v, %v", s, err)
+	}
+	if out, _ := InternedStringUnspecified.MarshalText(); len(out) != 0 {
+		t.Errorf("MarshalText(Unspecified) = %q", out)
+	}
+	if err := s.UnmarshalText([]byte(StringValueUnspecified)); !errors.Is(err, ErrSentinelCollision) {
+		t.Errorf("err = %v, want ErrSentinelCollision", err)
+	}
+}
+
+// families stands in for a style tree: few distinct values, many copies,
+// each decoded separately and so backed by its own allocation.
+func families(n int) []string {
+	names := []string{"Roboto Condensed Medium", "Noto Sans Display Light", "Source Serif Pro Semibold"}
+	out := make([]string, n)
+	for i := range out {
+		out[i] = build(names[i%len(names)])
+	}
+	return out
+}
+
+func retained(b *testing.B, keep func() any) {
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	v := keep()
+	runtime.GC()
+	runtime.ReadMemStats(&after)
+	runtime.KeepAlive(v)
+	b.ReportMetric(float64(int64(after.HeapAlloc)-int64(before.HeapAlloc)), "retained-B")
+}
+
+func BenchmarkInternedStringMemory(b *testing.B) {
+	const n = 10000
+	b.Run("plain", func(b *testing.B) {
+		for range b.N {
+			retained(b, func() any { return families(n) })
+		}
+	})
+	b.Run("interned", func(b *testing.B) {
+		for range b.N {
+			retained(b, func() any {
+				out := make([]InternedString, n)
+				for i, s := range families(n) {
+					out[i] = Intern(s)
+				}
+				return out
+			})
+		}
+	})
+}
+
+func BenchmarkSame(b *testing.B) {
+	s1, s2 := families(2)[0]+strings.Repeat("x", 256), families(2)[0]+strings.Repeat("x", 256)
+	b.Run("SameString", func(b *testing.B) {
+		for range b.N {
+			_ = SameString(s1, s2)
+		}
+	})
+	i1, i2 := Intern(s1), Intern(s2)
+	b.Run("SameInternedString", func(b *testing.B) {
+		for range b.N {
+			_ = SameInternedString(i1, i2)
+		}
+	})
+}
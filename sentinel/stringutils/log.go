@@ -0,0 +1,21 @@
+package stringutils
+
+import (
+	"log/slog"
+
+	"github.com/zodimo/go-sentinel-helper/internal/sentinellog"
+)
+
+// LogValueStringValue returns s as a slog.Value. StringValueUnspecified
+// logs as "<unspecified>" instead of its raw NUL-prefixed bytes.
+func LogValueStringValue(s StringValue) slog.Value {
+	if s == StringValueUnspecified {
+		return sentinellog.Value()
+	}
+	return slog.StringValue(s)
+}
+
+// StringValueAttr returns a slog.Attr for s; see LogValueStringValue.
+func StringValueAttr(key string, s StringValue) slog.Attr {
+	return slog.Attr{Key: key, Value: LogValueStringValue(s)}
+}
@@ -0,0 +1,14 @@
+package stringutils
+
+import (
+	"testing"
+)
+
+func TestStringValueAttr(t *testing.T) {
+	if got := StringValueAttr("s", "").Value.Resolve().String(); got != "" {
+		t.Errorf("StringValueAttr(\"\") = %q, want empty", got)
+	}
+	if got := StringValueAttr("s", StringValueUnspecified).Value.Resolve().String(); got != "<unspecified>" {
+		t.Errorf("StringValueAttr(Unspecified) = %q, want <unspecified>", got)
+	}
+}
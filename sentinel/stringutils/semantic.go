@@ -0,0 +1,97 @@
+package stringutils
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Fold is one normalisation step of a Comparator.
+type Fold func(string) string
+
+// Folds for NewComparator. They are pure functions and safe for
+// concurrent use.
+var (
+	// FoldNFC composes to Unicode Normalization Form C, so "é" written as
+	// one code point equals "e" followed by a combining acute accent.
+	FoldNFC Fold = norm.NFC.String
+	// FoldNFD decomposes to Unicode Normalization Form D.
+	FoldNFD Fold = norm.NFD.String
+	// FoldCase applies Unicode full case folding ("Straße" → "strasse").
+	FoldCase Fold = foldCase
+	// FoldTrimSpace removes leading and trailing white space.
+	FoldTrimSpace Fold = strings.TrimSpace
+	// FoldCollapseSpace trims and replaces every inner run of white space
+	// with a single ASCII space.
+	FoldCollapseSpace Fold = collapseSpace
+)
+
+// foldCase creates a Caser per call: Casers are stateful and must not be
+// shared between goroutines.
+func foldCase(s string) string {
+	return cases.Fold().String(s)
+}
+
+func collapseSpace(s string) string {
+	return strings.Join(strings.FieldsFunc(s, unicode.IsSpace), " ")
+}
+
+// Comparator is a configurable semantic string equality. Its Equal method
+// has the shape SemanticEqualSlice, SemanticEqualMap and friends expect:
+//
+//	sliceutils.SemanticEqualSlice(a, b, stringutils.Caseless.Equal)
+//
+// Unspecified is never folded: it equals only itself.
+type Comparator struct {
+	folds []Fold
+}
+
+// NewComparator returns a Comparator that applies folds in order to both
+// sides before comparing bytes. Without folds it is byte-exact.
+func NewComparator(folds ...Fold) Comparator {
+	return Comparator{folds: append([]Fold(nil), folds...)}
+}
+
+// Predefined comparators.
+var (
+	// ByteExact compares bytes, like SameString.
+	ByteExact = NewComparator()
+	// Canonical treats canonically equivalent strings as equal. It backs
+	// SemanticEqualString.
+	Canonical = NewComparator(FoldNFC)
+	// Caseless is Unicode canonical caseless matching: NFD, case fold,
+	// then NFC, so that folding cannot break canonical equivalence.
+	Caseless = NewComparator(FoldNFD, FoldCase, FoldNFC)
+	// Loose is Caseless that also ignores differences in white space.
+	Loose = NewComparator(FoldCollapseSpace, FoldNFD, FoldCase, FoldNFC)
+)
+
+// Normalize returns s with every fold applied; Unspecified is returned as
+// is. Equal strings have equal keys, so Normalize can key a map.
+func (c Comparator) Normalize(s StringValue) StringValue {
+	if s == StringValueUnspecified {
+		return s
+	}
+	for _, fold := range c.folds {
+		s = fold(s)
+	}
+	return s
+}
+
+// Equal reports whether a and b are equal after normalisation.
+func (c Comparator) Equal(a, b StringValue) bool {
+	if a == b {
+		return true
+	}
+	if a == StringValueUnspecified || b == StringValueUnspecified {
+		return false
+	}
+	return c.Normalize(a) == c.Normalize(b)
+}
+
+// EqualStr is Equal for Str.
+func (c Comparator) EqualStr(a, b Str) bool {
+	return c.Equal(StringValue(a), StringValue(b))
+}
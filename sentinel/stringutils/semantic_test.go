@@ -0,0 +1,105 @@
+package stringutils
+
+import (
+	"sync"
+	"testing"
+)
+
+const (
+	composed   = "caf\u00e9"  // é as one code point
+	decomposed = "cafe\u0301" // e + combining acute accent
+)
+
+func TestComparators(t *testing.T) {
+	tests := []struct {
+		name string
+		c    Comparator
+		a, b string
+		want bool
+	}{
+		{"exact same", ByteExact, "a", "a", true},
+		{"exact composed", ByteExact, composed, decomposed, false},
+		{"canonical composed", Canonical, composed, decomposed, true},
+		{"canonical case", Canonical, "A", "a", false},
+		{"caseless case", Caseless, "Straße", "STRASSE", true},
+		{"caseless composed", Caseless, "CAFÉ", decomposed, true},
+		{"caseless space", Caseless, "a b", "a  b", false},
+		{"loose space", Loose, " Hello\t\nWORLD ", "hello world", true},
+		{"loose still distinct", Loose, "hello world", "helloworld", false},
+		{"nfd", NewComparator(FoldNFD), composed, decomposed, true},
+		{"trim", NewComparator(FoldTrimSpace), " x\n", "x", true},
+		{"trim keeps inner", NewComparator(FoldTrimSpace), "x  y", "x y", false},
+		{"collapse", NewComparator(FoldCollapseSpace), "x   y", "x y", true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.c.Equal(tc.a, tc.b); got != tc.want {
+				t.Errorf("Equal(%q, %q) = %v, want %v", tc.a, tc.b, got, tc.want)
+			}
+			if got := tc.c.EqualStr(Str(tc.b), Str(tc.a)); got != tc.want {
+				t.Errorf("EqualStr(%q, %q) = %v, want %v", tc.b, tc.a, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestComparatorUnspecified(t *testing.T) {
+	// A fold that would map every string to the same key.
+	c := NewComparator(func(string) string { return "" })
+	if !c.Equal("a", "b") {
+		t.Error("the fold should apply to specified strings")
+	}
+	if c.Equal(StringValueUnspecified, "") || c.Equal("x", StringValueUnspecified) {
+		t.Error("Unspecified should only equal itself")
+	}
+	if !c.Equal(StringValueUnspecified, StringValueUnspecified) {
+		t.Error("Unspecified should equal itself")
+	}
+	if c.Normalize(StringValueUnspecified) != StringValueUnspecified {
+		t.Error("Normalize should keep Unspecified")
+	}
+}
+
+func TestSemanticEqualStringIsCanonical(t *testing.T) {
+	if !SemanticEqualString(composed, decomposed) {
+		t.Error("canonically equivalent strings should be semantically equal")
+	}
+	if SameString(composed, decomposed) {
+		t.Error("SameString should stay byte-exact")
+	}
+	if !Str(composed).SemanticEqual(Str(decomposed)) || Str(composed).Same(Str(decomposed)) {
+		t.Error("Str should follow SemanticEqualString")
+	}
+	if !Email(composed).SemanticEqual(Email(decomposed)) || Email("").SemanticEqual(Email("x")) {
+		t.Error("DomainString should follow SemanticEqualString")
+	}
+}
+
+func TestEqualIsSameOrSemanticEqual(t *testing.T) {
+	if !EqualString(composed, decomposed) || EqualString(composed, StringValueUnspecified) {
+		t.Error("EqualString should be SameString || SemanticEqualString")
+	}
+	if !Str(composed).Equal(Str(decomposed)) || !Email(composed).Equal(Email(decomposed)) {
+		t.Error("Str and DomainString Equal should follow EqualString")
+	}
+	if !Intern(composed).Equal(Intern(decomposed)) {
+		t.Error("InternedString Equal should follow EqualString")
+	}
+}
+
+func TestFoldCaseConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				if !Caseless.Equal("Straße", "STRASSE") {
+					t.Error("Caseless mismatch")
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,188 @@
+package stringutils
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/zodimo/go-sentinel-helper/internal/sentinellog"
+)
+
+// Str is the defined-type counterpart of StringValue. Unlike the alias it
+// carries methods, so it prints, marshals and logs sentinel-aware, and the
+// compiler rejects a raw string where a Str is expected.
+//
+// As with StringValue the sentinel is "\x00unspecified", so the zero value
+// of Str is a specified ""; use StrNone for "not set".
+type Str string
+
+// 1. Sentinel - StrNone, named TNone like intutils.IntNone; see the
+// contract note in intutils/ints.go.
+const StrNone Str = Str(StringValueUnspecified)
+
+// StrFrom converts a StringValue to a Str; StringValueUnspecified maps to
+// StrNone.
+func StrFrom(s StringValue) Str {
+	return Str(s)
+}
+
+// StringValue converts s back to the alias type.
+func (s Str) StringValue() StringValue {
+	return StringValue(s)
+}
+
+// StringOrElse returns s as a string, or def if s is Unspecified.
+func (s Str) StringOrElse(def string) string {
+	if s == StrNone {
+		return def
+	}
+	return string(s)
+}
+
+// 2. IsSpecified - predicate (method on value receiver)
+func (s Str) IsSpecified() bool {
+	return s != StrNone
+}
+
+// IsUnspecified - convenience predicate
+func (s Str) IsUnspecified() bool {
+	return s == StrNone
+}
+
+// SetUnspecified - stores StrNone in the receiver
+func (s *Str) SetUnspecified() {
+	*s = StrNone
+}
+
+// 3. TakeOrElse - returns s if specified, otherwise def
+func (s Str) TakeOrElse(def Str) Str {
+	if s != StrNone {
+		return s
+	}
+	return def
+}
+
+// 4. Merge - prefers other if specified
+func (s Str) Merge(other Str) Str {
+	if other != StrNone {
+		return other
+	}
+	return s
+}
+
+// 5. String - implements fmt.Stringer
+func (s Str) String() string {
+	if s == StrNone {
+		return "Str{Unspecified}"
+	}
+	return fmt.Sprintf("Str{%q}", string(s))
+}
+
+// 6. Coalesce - N/A for value types
+
+// 7. Same - identity
+func (s Str) Same(other Str) bool {
+	return s == other
+}
+
+// 8. SemanticEqual - canonical equivalence, as SemanticEqualString
+func (s Str) SemanticEqual(other Str) bool {
+	return Canonical.EqualStr(s, other)
+}
+
+// 9. Equal - equality check: Same or SemanticEqual
+func (s Str) Equal(other Str) bool {
+	return s.Same(other) || s.SemanticEqual(other)
+}
+
+// 10. Copy - identity for immutable value types
+func (s Str) Copy() Str {
+	return s
+}
+
+// Package-level forms of the contract, for use as function values.
+func IsSpecifiedStr(s Str) bool      { return s.IsSpecified() }
+func TakeOrElseStr(a, b Str) Str     { return a.TakeOrElse(b) }
+func MergeStr(a, b Str) Str          { return a.Merge(b) }
+func StringStr(s Str) string         { return s.String() }
+func SameStr(a, b Str) bool          { return a.Same(b) }
+func SemanticEqualStr(a, b Str) bool { return a.SemanticEqual(b) }
+func EqualStr(a, b Str) bool         { return a.Equal(b) }
+func CopyStr(s Str) Str              { return s.Copy() }
+
+// IsZero reports whether s is Unspecified, so that encoders honouring
+// `omitempty`/`omitzero` drop it. A specified "" is kept.
+func (s Str) IsZero() bool {
+	return s == StrNone
+}
+
+// MarshalText implements encoding.TextMarshaler. Text has no spare value
+// for Unspecified, which encodes as the empty string; UnmarshalText always
+// decodes a specified string. Use JSON or YAML to round-trip Unspecified.
+func (s Str) MarshalText() ([]byte, error) {
+	if s == StrNone {
+		return []byte{}, nil
+	}
+	return []byte(s), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Input equal to the
+// sentinel fails with ErrSentinelCollision.
+func (s *Str) UnmarshalText(text []byte) error {
+	v, err := StrFromUntrusted(string(text))
+	if err != nil {
+		return err
+	}
+	*s = v
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. Unspecified encodes as null.
+func (s Str) MarshalJSON() ([]byte, error) {
+	if s == StrNone {
+		return []byte("null"), nil
+	}
+	return json.Marshal(string(s))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. null decodes to Unspecified;
+// a string equal to the sentinel fails with ErrSentinelCollision.
+func (s *Str) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*s = StrNone
+		return nil
+	}
+	var v string
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	return s.UnmarshalText([]byte(v))
+}
+
+// MarshalYAML implements yaml.Marshaler without importing a YAML package.
+// Unspecified encodes as null.
+func (s Str) MarshalYAML() (any, error) {
+	if s == StrNone {
+		return nil, nil
+	}
+	return string(s), nil
+}
+
+// UnmarshalYAML implements the yaml.v2/v3 obsolete unmarshaler interface.
+// Null nodes never reach it, and a null or absent key leaves the field
+// as is, so start from StrNone rather than the zero value.
+func (s *Str) UnmarshalYAML(unmarshal func(any) error) error {
+	var v string
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	return s.UnmarshalText([]byte(v))
+}
+
+// LogValue implements slog.LogValuer.
+func (s Str) LogValue() slog.Value {
+	if s == StrNone {
+		return sentinellog.Value()
+	}
+	return slog.StringValue(string(s))
+}
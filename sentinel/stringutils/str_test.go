@@ -0,0 +1,103 @@
+package stringutils
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestStr_Contract(t *testing.T) {
+	var zero Str
+	if !zero.IsSpecified() {
+		t.Error("the zero Str is a specified \"\"")
+	}
+	if StrNone.IsSpecified() || !StrNone.IsUnspecified() {
+		t.Error("StrNone should be unspecified")
+	}
+	if got := StrNone.TakeOrElse("d"); got != "d" {
+		t.Errorf("TakeOrElse = %v, want d", got)
+	}
+	if got := MergeStr("a", StrNone); got != "a" {
+		t.Errorf("Merge(a, Unspecified) = %v, want a", got)
+	}
+	if got := MergeStr("a", ""); got != "" {
+		t.Errorf("Merge(a, \"\") = %v, want \"\"", got)
+	}
+	if !EqualStr("x", "x") || SameStr("x", "X") || !SemanticEqualStr(StrNone, StrNone) {
+		t.Error("equality mismatch")
+	}
+	if got := StrNone.StringOrElse("d"); got != "d" {
+		t.Errorf("StringOrElse = %q, want d", got)
+	}
+	if StrFrom(StringValueUnspecified) != StrNone || StrNone.StringValue() != StringValueUnspecified {
+		t.Error("conversions should map sentinel to sentinel")
+	}
+}
+
+func TestStr_String(t *testing.T) {
+	for v, want := range map[Str]string{"hi": `Str{"hi"}`, "": `Str{""}`, StrNone: "Str{Unspecified}"} {
+		if got := fmt.Sprint(v); got != want {
+			t.Errorf("Sprint = %q, want %q", got, want)
+		}
+	}
+}
+
+type strDoc struct {
+	A Str `json:"a" yaml:"a"`
+	B Str `json:"b,omitzero" yaml:"b,omitempty"`
+	C Str `json:"c" yaml:"c"`
+}
+
+func TestStr_JSON(t *testing.T) {
+	out, err := json.Marshal(strDoc{A: "", B: StrNone, C: StrNone})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"a":"","c":null}`; string(out) != want {
+		t.Errorf("Marshal = %s, want %s", out, want)
+	}
+
+	doc := strDoc{B: "keep"}
+	if err := json.Unmarshal([]byte(`{"a":"x","b":null}`), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc.A != "x" || doc.B != StrNone {
+		t.Errorf("Unmarshal = %+v", doc)
+	}
+	if json.Unmarshal([]byte(`1`), &doc.A) == nil {
+		t.Error("decoding a number should fail")
+	}
+}
+
+func TestStr_Text(t *testing.T) {
+	text, _ := StrNone.MarshalText()
+	var s Str = StrNone
+	if err := s.UnmarshalText(text); err != nil || s != "" {
+		t.Errorf("text cannot carry Unspecified: got %v, %v", s, err)
+	}
+}
+
+func TestStr_YAML(t *testing.T) {
+	out, err := yaml.Marshal(strDoc{A: "x", B: StrNone, C: StrNone})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "a: x\nc: null\n"; string(out) != want {
+		t.Errorf("Marshal = %q, want %q", out, want)
+	}
+	doc := strDoc{C: StrNone}
+	if err := yaml.Unmarshal([]byte("a: \"\"\nb: y\n"), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc.A != "" || doc.B != "y" || doc.C != StrNone {
+		t.Errorf("Unmarshal = %+v", doc)
+	}
+}
+
+func TestStr_LogValue(t *testing.T) {
+	if got := StrNone.LogValue().Resolve().String(); got != "<unspecified>" {
+		t.Errorf("LogValue = %q, want <unspecified>", got)
+	}
+}
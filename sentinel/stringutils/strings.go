@@ -56,14 +56,15 @@ func SameString(a, b StringValue) bool {
 }
 
 // 8. SemanticEqual - semantic equality (package-level function)
-// For strings, this is the same as Same
+// Canonically equivalent strings (NFC) are equal; see Comparator for
+// case- and space-insensitive modes. SameString stays byte-exact.
 func SemanticEqualString(a, b StringValue) bool {
-	return a == b
+	return Canonical.Equal(a, b)
 }
 
-// 9. Equal - equality check (package-level function)
+// 9. Equal - equality check (package-level function): Same or SemanticEqual
 func EqualString(a, b StringValue) bool {
-	return a == b
+	return SameString(a, b) || SemanticEqualString(a, b)
 }
 
 // 10. Copy - identity for immutable value types (package-level function)
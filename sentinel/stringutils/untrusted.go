@@ -0,0 +1,53 @@
+package stringutils
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrSentinelCollision is returned when input equals the Unspecified
+// sentinel, which would otherwise silently read as "not set".
+var ErrSentinelCollision = errors.New("stringutils: input collides with the Unspecified sentinel")
+
+// FromUntrusted converts external input (request bodies, files, headers)
+// to a StringValue, rejecting the one value that would read as
+// StringValueUnspecified.
+func FromUntrusted(s string) (StringValue, error) {
+	if s == StringValueUnspecified {
+		return StringValueUnspecified, ErrSentinelCollision
+	}
+	return s, nil
+}
+
+// StrFromUntrusted is FromUntrusted for Str.
+func StrFromUntrusted(s string) (Str, error) {
+	v, err := FromUntrusted(s)
+	return Str(v), err
+}
+
+// SanitizeString escapes external input instead of rejecting it: a string
+// starting with NUL gets one more leading NUL, so no input maps to
+// StringValueUnspecified and distinct inputs stay distinct. Strings
+// without a leading NUL, i.e. virtually all text, are returned unchanged.
+// UnsanitizeString reverses it.
+func SanitizeString(s string) StringValue {
+	if strings.HasPrefix(s, "\x00") {
+		return "\x00" + s
+	}
+	return s
+}
+
+// UnsanitizeString returns the original input of SanitizeString.
+// StringValueUnspecified, which SanitizeString never produces, is returned
+// as is.
+func UnsanitizeString(s StringValue) string {
+	if s != StringValueUnspecified && strings.HasPrefix(s, "\x00\x00") {
+		return s[1:]
+	}
+	return s
+}
+
+// SanitizeStr is SanitizeString for Str.
+func SanitizeStr(s string) Str {
+	return Str(SanitizeString(s))
+}
@@ -0,0 +1,58 @@
+package stringutils
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestFromUntrusted(t *testing.T) {
+	if v, err := FromUntrusted(""); err != nil || v != "" {
+		t.Errorf("FromUntrusted(\"\") = %q, %v", v, err)
+	}
+	if _, err := FromUntrusted("\x00unspecified"); !errors.Is(err, ErrSentinelCollision) {
+		t.Errorf("FromUntrusted(sentinel) err = %v, want ErrSentinelCollision", err)
+	}
+	if _, err := StrFromUntrusted("\x00unspecified"); !errors.Is(err, ErrSentinelCollision) {
+		t.Errorf("StrFromUntrusted(sentinel) err = %v, want ErrSentinelCollision", err)
+	}
+}
+
+func TestSanitizeString(t *testing.T) {
+	inputs := []string{"", "plain", "\x00", "\x00unspecified", "\x00\x00unspecified", "a\x00unspecified"}
+	seen := map[StringValue]string{}
+	for _, in := range inputs {
+		out := SanitizeString(in)
+		if IsUnspecifiedString(out) {
+			t.Errorf("SanitizeString(%q) is Unspecified", in)
+		}
+		if prev, dup := seen[out]; dup {
+			t.Errorf("SanitizeString(%q) == SanitizeString(%q)", in, prev)
+		}
+		seen[out] = in
+		if back := UnsanitizeString(out); back != in {
+			t.Errorf("UnsanitizeString(SanitizeString(%q)) = %q", in, back)
+		}
+	}
+	if SanitizeString("plain") != "plain" {
+		t.Error("text without a leading NUL should be unchanged")
+	}
+	if UnsanitizeString(StringValueUnspecified) != StringValueUnspecified {
+		t.Error("UnsanitizeString should keep the sentinel")
+	}
+	if SanitizeStr("\x00unspecified").IsUnspecified() {
+		t.Error("SanitizeStr should escape the sentinel")
+	}
+}
+
+func TestStr_DecodeRejectsSentinel(t *testing.T) {
+	var s Str
+	if err := json.Unmarshal([]byte(`"\u0000unspecified"`), &s); !errors.Is(err, ErrSentinelCollision) {
+		t.Errorf("JSON err = %v, want ErrSentinelCollision", err)
+	}
+	if err := yaml.Unmarshal([]byte(`"\0unspecified"`), &s); !errors.Is(err, ErrSentinelCollision) {
+		t.Errorf("YAML err = %v, want ErrSentinelCollision", err)
+	}
+}
@@ -0,0 +1,103 @@
+// Package timeutils provides sentinel helpers for time.Duration and
+// time.Time, where the zero value (no timeout, the zero instant) is often a
+// meaningful setting rather than "not configured".
+package timeutils
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// 1. Sentinel - DurationUnspecified
+// The sentinel math.MinInt64 is used, about -292 years.
+// Note: This means that duration cannot be used as a valid value.
+const DurationUnspecified time.Duration = math.MinInt64
+
+// ErrReservedDuration is returned when parsing yields DurationUnspecified.
+var ErrReservedDuration = errors.New("timeutils: duration is reserved for Unspecified")
+
+// 2. IsSpecified - predicate (package-level function)
+func IsSpecifiedDuration(d time.Duration) bool {
+	return d != DurationUnspecified
+}
+
+// IsUnspecifiedDuration - convenience predicate
+func IsUnspecifiedDuration(d time.Duration) bool {
+	return d == DurationUnspecified
+}
+
+// 3. TakeOrElse - 2-param fallback (package-level function)
+func TakeOrElseDuration(a, b time.Duration) time.Duration {
+	if a != DurationUnspecified {
+		return a
+	}
+	return b
+}
+
+// 4. Merge - composition merge (package-level function)
+// Prefers incoming specified values over current values
+func MergeDuration(a, b time.Duration) time.Duration {
+	if b != DurationUnspecified {
+		return b
+	}
+	return a
+}
+
+// 5. String - stringification (package-level function)
+func StringDuration(d time.Duration) string {
+	if d == DurationUnspecified {
+		return "Duration{Unspecified}"
+	}
+	return fmt.Sprintf("Duration{%s}", d)
+}
+
+// 6. Coalesce - N/A for value types (no nil possible)
+
+// 7. Same - identity (package-level function)
+func SameDuration(a, b time.Duration) bool {
+	return a == b
+}
+
+// 8. SemanticEqual - for durations, the same as Same
+func SemanticEqualDuration(a, b time.Duration) bool {
+	return a == b
+}
+
+// 9. Equal - equality check (package-level function)
+func EqualDuration(a, b time.Duration) bool {
+	return a == b
+}
+
+// 10. Copy - identity for immutable value types (package-level function)
+func CopyDuration(d time.Duration) time.Duration {
+	return d
+}
+
+// FormatDuration formats d like time.Duration.String; Unspecified formats
+// as the empty string.
+func FormatDuration(d time.Duration) string {
+	if d == DurationUnspecified {
+		return ""
+	}
+	return d.String()
+}
+
+// ParseDuration is the inverse of FormatDuration: the empty string, "null"
+// and "~" parse as DurationUnspecified, anything else as
+// time.ParseDuration does.
+func ParseDuration(s string) (time.Duration, error) {
+	switch s {
+	case "", "null", "~":
+		return DurationUnspecified, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return DurationUnspecified, err
+	}
+	if d == DurationUnspecified {
+		return DurationUnspecified, ErrReservedDuration
+	}
+	return d, nil
+}
@@ -0,0 +1,41 @@
+package timeutils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDurationContract(t *testing.T) {
+	require.True(t, IsSpecifiedDuration(0), "a zero timeout is a setting")
+	require.True(t, IsUnspecifiedDuration(DurationUnspecified))
+	require.Equal(t, time.Second, TakeOrElseDuration(DurationUnspecified, time.Second))
+	require.Equal(t, time.Duration(0), TakeOrElseDuration(0, time.Second))
+	require.Equal(t, time.Duration(0), MergeDuration(time.Second, 0))
+	require.Equal(t, time.Second, MergeDuration(time.Second, DurationUnspecified))
+	require.Equal(t, "Duration{1.5s}", StringDuration(1500*time.Millisecond))
+	require.Equal(t, "Duration{Unspecified}", StringDuration(DurationUnspecified))
+	require.True(t, SameDuration(DurationUnspecified, DurationUnspecified))
+	require.True(t, SemanticEqualDuration(time.Minute, 60*time.Second))
+	require.False(t, EqualDuration(0, DurationUnspecified))
+	require.Equal(t, time.Hour, CopyDuration(time.Hour))
+}
+
+func TestDurationRoundTrip(t *testing.T) {
+	for _, d := range []time.Duration{0, -time.Second, 90 * time.Minute, DurationUnspecified} {
+		back, err := ParseDuration(FormatDuration(d))
+		require.NoError(t, err)
+		require.Equal(t, d, back)
+	}
+	for _, s := range []string{"null", "~"} {
+		d, err := ParseDuration(s)
+		require.NoError(t, err)
+		require.Equal(t, DurationUnspecified, d)
+	}
+
+	_, err := ParseDuration(DurationUnspecified.String())
+	require.ErrorIs(t, err, ErrReservedDuration)
+	_, err = ParseDuration("soon")
+	require.Error(t, err)
+}
@@ -0,0 +1,26 @@
+package timeutils
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/zodimo/go-sentinel-helper/internal/sentinellog"
+)
+
+// DurationAttr returns a slog.Attr for d; DurationUnspecified logs as
+// "<unspecified>".
+func DurationAttr(key string, d time.Duration) slog.Attr {
+	if d == DurationUnspecified {
+		return slog.Attr{Key: key, Value: sentinellog.Value()}
+	}
+	return slog.Duration(key, d)
+}
+
+// TimeAttr returns a slog.Attr for t; TimeUnspecified logs as
+// "<unspecified>".
+func TimeAttr(key string, t time.Time) slog.Attr {
+	if IsUnspecifiedTime(t) {
+		return slog.Attr{Key: key, Value: sentinellog.Value()}
+	}
+	return slog.Time(key, t)
+}
@@ -0,0 +1,106 @@
+package timeutils
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// 1. Sentinel - TimeUnspecified
+// A reserved instant at the start of year math.MinInt32, UTC. It differs
+// from the zero time.Time, which stays a specified value, and no layout
+// with a four-digit year can produce it. Compare with IsUnspecifiedTime,
+// not ==, since time.Time values carry a location.
+var TimeUnspecified = time.Date(math.MinInt32, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// ErrReservedTime is returned when parsing yields TimeUnspecified.
+var ErrReservedTime = errors.New("timeutils: instant is reserved for Unspecified")
+
+// 2. IsSpecified - predicate (package-level function)
+func IsSpecifiedTime(t time.Time) bool {
+	return !t.Equal(TimeUnspecified)
+}
+
+// IsUnspecifiedTime - convenience predicate
+func IsUnspecifiedTime(t time.Time) bool {
+	return t.Equal(TimeUnspecified)
+}
+
+// 3. TakeOrElse - 2-param fallback (package-level function)
+func TakeOrElseTime(a, b time.Time) time.Time {
+	if IsSpecifiedTime(a) {
+		return a
+	}
+	return b
+}
+
+// 4. Merge - composition merge (package-level function)
+// Prefers incoming specified values over current values
+func MergeTime(a, b time.Time) time.Time {
+	if IsSpecifiedTime(b) {
+		return b
+	}
+	return a
+}
+
+// 5. String - stringification (package-level function)
+func StringTime(t time.Time) string {
+	if IsUnspecifiedTime(t) {
+		return "Time{Unspecified}"
+	}
+	return fmt.Sprintf("Time{%s}", t.Format(time.RFC3339Nano))
+}
+
+// 6. Coalesce - N/A for value types (no nil possible)
+
+// 7. Same - identity: same instant, location and monotonic reading.
+// Any two Unspecified values are the same.
+func SameTime(a, b time.Time) bool {
+	if IsUnspecifiedTime(a) || IsUnspecifiedTime(b) {
+		return IsUnspecifiedTime(a) && IsUnspecifiedTime(b)
+	}
+	return a == b
+}
+
+// 8. SemanticEqual - same instant, via time.Time.Equal: locations are
+// ignored, and monotonic readings are used when both sides have one
+func SemanticEqualTime(a, b time.Time) bool {
+	return a.Equal(b)
+}
+
+// 9. Equal - equality check (package-level function)
+func EqualTime(a, b time.Time) bool {
+	return SameTime(a, b) || SemanticEqualTime(a, b)
+}
+
+// 10. Copy - identity for immutable value types (package-level function)
+func CopyTime(t time.Time) time.Time {
+	return t
+}
+
+// FormatTime formats t with layout; Unspecified formats as the empty
+// string.
+func FormatTime(t time.Time, layout string) string {
+	if IsUnspecifiedTime(t) {
+		return ""
+	}
+	return t.Format(layout)
+}
+
+// ParseTime is the inverse of FormatTime: the empty string, "null" and "~"
+// parse as TimeUnspecified, anything else as time.Parse does.
+func ParseTime(layout, s string) (time.Time, error) {
+	switch s {
+	case "", "null", "~":
+		return TimeUnspecified, nil
+	}
+	t, err := time.Parse(layout, s)
+	if err != nil {
+		return TimeUnspecified, err
+	}
+	if IsUnspecifiedTime(t) {
+		return TimeUnspecified, ErrReservedTime
+	}
+	return t, nil
+}
@@ -0,0 +1,64 @@
+package timeutils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeUnspecified(t *testing.T) {
+	require.False(t, TimeUnspecified.IsZero())
+	require.True(t, IsSpecifiedTime(time.Time{}), "the zero instant is a setting")
+	require.True(t, IsUnspecifiedTime(TimeUnspecified))
+	require.True(t, IsUnspecifiedTime(TimeUnspecified.In(time.FixedZone("X", 3600))),
+		"Unspecified does not depend on the location")
+}
+
+func TestTimeContract(t *testing.T) {
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	require.Equal(t, at, TakeOrElseTime(TimeUnspecified, at))
+	require.Equal(t, time.Time{}, TakeOrElseTime(time.Time{}, at))
+	require.Equal(t, at, MergeTime(time.Time{}, at))
+	require.Equal(t, at, MergeTime(at, TimeUnspecified))
+	require.Equal(t, "Time{2024-05-01T12:00:00Z}", StringTime(at))
+	require.Equal(t, "Time{Unspecified}", StringTime(TimeUnspecified))
+	require.Equal(t, at, CopyTime(at))
+}
+
+func TestTimeEquality(t *testing.T) {
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	elsewhere := at.In(time.FixedZone("CEST", 2*3600))
+	require.False(t, SameTime(at, elsewhere))
+	require.True(t, SemanticEqualTime(at, elsewhere))
+	require.True(t, EqualTime(at, elsewhere))
+
+	now := time.Now()
+	require.False(t, SameTime(now, now.Round(0)), "monotonic reading differs")
+	require.True(t, SemanticEqualTime(now, now.Round(0)))
+
+	require.True(t, SameTime(TimeUnspecified, TimeUnspecified.Local()))
+	require.False(t, EqualTime(TimeUnspecified, time.Time{}))
+}
+
+func TestTimeRoundTrip(t *testing.T) {
+	for _, at := range []time.Time{
+		time.Time{},
+		time.Date(2024, 5, 1, 12, 0, 0, 123, time.UTC),
+		TimeUnspecified,
+	} {
+		back, err := ParseTime(time.RFC3339Nano, FormatTime(at, time.RFC3339Nano))
+		require.NoError(t, err)
+		require.True(t, EqualTime(at, back), "%v", at)
+		require.Equal(t, IsSpecifiedTime(at), IsSpecifiedTime(back))
+	}
+	_, err := ParseTime(time.RFC3339, "yesterday")
+	require.Error(t, err)
+}
+
+func TestAttrs(t *testing.T) {
+	require.Equal(t, "<unspecified>", DurationAttr("d", DurationUnspecified).Value.Resolve().String())
+	require.Equal(t, time.Duration(0), DurationAttr("d", 0).Value.Duration())
+	require.Equal(t, "<unspecified>", TimeAttr("t", TimeUnspecified).Value.Resolve().String())
+	require.True(t, TimeAttr("t", time.Time{}).Value.Time().IsZero())
+}
@@ -0,0 +1,65 @@
+package units
+
+import (
+	"fmt"
+
+	"github.com/zodimo/go-sentinel-helper/sentinel/floatutils"
+)
+
+// Density is the conversion context between Dp, TextUnit and pixels.
+// It is not a sentinel type: a context is always fully specified.
+type Density struct {
+	// Density is the number of pixels per Dp.
+	Density float32
+	// FontScale is the user's font size preference, applied to Sp only.
+	FontScale float32
+}
+
+// NewDensity returns a conversion context.
+func NewDensity(density, fontScale float32) Density {
+	return Density{Density: density, FontScale: fontScale}
+}
+
+// DpToPx converts d to pixels. DpUnspecified converts to NaN.
+func (dn Density) DpToPx(d Dp) float32 {
+	return float32(d) * dn.Density
+}
+
+// PxToDp converts pixels to Dp. NaN converts to DpUnspecified.
+func (dn Density) PxToDp(px float32) Dp {
+	return Dp(px / dn.Density)
+}
+
+// SpToDp converts an Sp TextUnit to Dp by applying the font scale.
+func (dn Density) SpToDp(tu TextUnit) (Dp, error) {
+	if tu.IsUnspecified() {
+		return DpUnspecified, nil
+	}
+	if !tu.IsSp() {
+		return DpUnspecified, fmt.Errorf("%w: want Sp, got %s", ErrMixedUnits, tu.Type())
+	}
+	return Dp(tu.Value() * dn.FontScale), nil
+}
+
+// DpToSp converts d to an Sp TextUnit by removing the font scale.
+func (dn Density) DpToSp(d Dp) TextUnit {
+	return Sp(float32(d) / dn.FontScale)
+}
+
+// TextUnitToPx converts tu to pixels. Em values are relative to fontSize,
+// which must itself be an Sp TextUnit. TextUnitUnspecified converts to NaN.
+func (dn Density) TextUnitToPx(tu, fontSize TextUnit) (float32, error) {
+	switch tu.Type() {
+	case TextUnitTypeSp:
+		dp, err := dn.SpToDp(tu)
+		return dn.DpToPx(dp), err
+	case TextUnitTypeEm:
+		if fontSize.IsEm() {
+			return floatutils.Float32Unspecified, fmt.Errorf("%w: Em font size for an Em value", ErrMixedUnits)
+		}
+		base, err := dn.SpToDp(fontSize)
+		return tu.Value() * dn.DpToPx(base), err
+	default:
+		return floatutils.Float32Unspecified, nil
+	}
+}
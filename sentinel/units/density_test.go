@@ -0,0 +1,57 @@
+package units
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDensityDp(t *testing.T) {
+	dn := NewDensity(2, 1.5)
+	require.Equal(t, float32(32), dn.DpToPx(16))
+	require.Equal(t, Dp(8), dn.PxToDp(16))
+	require.True(t, math.IsNaN(float64(dn.DpToPx(DpUnspecified))))
+	require.True(t, dn.PxToDp(float32(math.NaN())).IsUnspecified())
+}
+
+func TestDensitySp(t *testing.T) {
+	dn := NewDensity(2, 1.5)
+
+	dp, err := dn.SpToDp(Sp(10))
+	require.NoError(t, err)
+	require.Equal(t, Dp(15), dp)
+
+	dp, err = dn.SpToDp(TextUnitUnspecified)
+	require.NoError(t, err)
+	require.True(t, dp.IsUnspecified())
+
+	_, err = dn.SpToDp(Em(1))
+	require.ErrorIs(t, err, ErrMixedUnits)
+
+	require.True(t, dn.DpToSp(15).Equal(Sp(10)))
+	require.True(t, dn.DpToSp(DpUnspecified).IsUnspecified())
+}
+
+func TestDensityTextUnitToPx(t *testing.T) {
+	dn := NewDensity(2, 1.5)
+
+	px, err := dn.TextUnitToPx(Sp(10), TextUnitUnspecified)
+	require.NoError(t, err)
+	require.Equal(t, float32(30), px)
+
+	px, err = dn.TextUnitToPx(Em(2), Sp(10))
+	require.NoError(t, err)
+	require.Equal(t, float32(60), px)
+
+	px, err = dn.TextUnitToPx(Em(2), TextUnitUnspecified)
+	require.NoError(t, err)
+	require.True(t, math.IsNaN(float64(px)))
+
+	px, err = dn.TextUnitToPx(TextUnitUnspecified, Sp(10))
+	require.NoError(t, err)
+	require.True(t, math.IsNaN(float64(px)))
+
+	_, err = dn.TextUnitToPx(Em(2), Em(1))
+	require.ErrorIs(t, err, ErrMixedUnits)
+}
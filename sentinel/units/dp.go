@@ -0,0 +1,134 @@
+package units
+
+import (
+	"fmt"
+
+	"github.com/zodimo/go-sentinel-helper/sentinel/floatutils"
+)
+
+// Dp is a density-independent pixel length.
+// Uses Pattern 1-A from sentinel_pattern.md: the value is stored as a float32.
+type Dp float32
+
+// 1. Sentinel - DpUnspecified
+var DpUnspecified = Dp(floatutils.Float32Unspecified)
+
+// 2. IsSpecified - predicate (method on value receiver)
+func (d Dp) IsSpecified() bool {
+	return floatutils.IsSpecified(d)
+}
+
+// IsUnspecified - convenience predicate
+func (d Dp) IsUnspecified() bool {
+	return floatutils.IsUnspecified(d)
+}
+
+// SetUnspecified - stores DpUnspecified in the receiver
+func (d *Dp) SetUnspecified() {
+	*d = DpUnspecified
+}
+
+// IsSpecifiedDp - package-level predicate
+func IsSpecifiedDp(d Dp) bool {
+	return d.IsSpecified()
+}
+
+// 3. TakeOrElse - 2-param fallback (method on value receiver)
+func (d Dp) TakeOrElse(def Dp) Dp {
+	if d.IsSpecified() {
+		return d
+	}
+	return def
+}
+
+// TakeOrElseDp - package-level fallback
+func TakeOrElseDp(a, b Dp) Dp {
+	return a.TakeOrElse(b)
+}
+
+// 4. Merge - prefers other if specified (method on value receiver)
+func (d Dp) Merge(other Dp) Dp {
+	if other.IsSpecified() {
+		return other
+	}
+	return d
+}
+
+// MergeDp - package-level merge function
+func MergeDp(a, b Dp) Dp {
+	return a.Merge(b)
+}
+
+// 5. String - stringification (method on value receiver)
+func (d Dp) String() string {
+	if d.IsUnspecified() {
+		return "Dp{Unspecified}"
+	}
+	return fmt.Sprintf("Dp{%.1f}", float32(d))
+}
+
+// StringDp - package-level string function
+func StringDp(d Dp) string {
+	return d.String()
+}
+
+// 6. Coalesce - N/A for value types (no nil possible)
+
+// 7. Same - identity; Unspecified is the same as Unspecified
+func SameDp(a, b Dp) bool {
+	return floatutils.Same(a, b)
+}
+
+// 8. SemanticEqual - equality within floatutils.Float32EqualityThreshold
+func SemanticEqualDp(a, b Dp) bool {
+	return floatutils.SemanticEqual(float32(a), float32(b))
+}
+
+// 9. Equal - equality check (method on value receiver)
+func (d Dp) Equal(other Dp) bool {
+	return SameDp(d, other) || SemanticEqualDp(d, other)
+}
+
+// EqualDp - package-level equal function
+func EqualDp(a, b Dp) bool {
+	return a.Equal(b)
+}
+
+// 10. Copy - identity for immutable value types (method on value receiver)
+func (d Dp) Copy() Dp {
+	return d
+}
+
+// CopyDp - package-level copy function
+func CopyDp(d Dp) Dp {
+	return d.Copy()
+}
+
+// Arithmetic follows floatutils semantics: an Unspecified operand yields
+// DpUnspecified. Dp only combines with Dp, so lengths of other units
+// cannot be mixed in by accident.
+
+// Plus returns d + other.
+func (d Dp) Plus(other Dp) Dp {
+	return d + other
+}
+
+// Minus returns d - other.
+func (d Dp) Minus(other Dp) Dp {
+	return d - other
+}
+
+// Times scales d by factor.
+func (d Dp) Times(factor float32) Dp {
+	return d * Dp(factor)
+}
+
+// Div divides d by divisor.
+func (d Dp) Div(divisor float32) Dp {
+	return d / Dp(divisor)
+}
+
+// Negate returns -d.
+func (d Dp) Negate() Dp {
+	return -d
+}
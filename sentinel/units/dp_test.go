@@ -0,0 +1,83 @@
+package units
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsSpecifiedDp(t *testing.T) {
+	require.False(t, IsSpecifiedDp(DpUnspecified))
+	require.True(t, DpUnspecified.IsUnspecified())
+	require.True(t, IsSpecifiedDp(0))
+	require.True(t, Dp(16).IsSpecified())
+	require.False(t, Dp(16).IsUnspecified())
+}
+
+func TestTakeOrElseDp(t *testing.T) {
+	require.Equal(t, Dp(4), TakeOrElseDp(4, 8))
+	require.Equal(t, Dp(0), TakeOrElseDp(0, 8))
+	require.Equal(t, Dp(8), TakeOrElseDp(DpUnspecified, 8))
+	require.Equal(t, Dp(8), DpUnspecified.TakeOrElse(8))
+}
+
+func TestMergeDp(t *testing.T) {
+	require.Equal(t, Dp(2), MergeDp(1, 2))
+	require.Equal(t, Dp(1), MergeDp(1, DpUnspecified))
+	require.Equal(t, Dp(2), MergeDp(DpUnspecified, 2))
+	require.True(t, MergeDp(DpUnspecified, DpUnspecified).IsUnspecified())
+	require.Equal(t, Dp(3), Dp(1).Merge(3))
+	require.Equal(t, Dp(1), Dp(1).Merge(DpUnspecified))
+}
+
+func TestStringDp(t *testing.T) {
+	require.Equal(t, "Dp{Unspecified}", StringDp(DpUnspecified))
+	require.Equal(t, "Dp{16.0}", StringDp(16))
+	require.Equal(t, "Dp{1.5}", Dp(1.5).String())
+}
+
+func TestSameDp(t *testing.T) {
+	require.True(t, SameDp(DpUnspecified, DpUnspecified))
+	require.True(t, SameDp(4, 4))
+	require.False(t, SameDp(0.5, 0.5000005))
+	require.False(t, SameDp(4, DpUnspecified))
+}
+
+func TestSemanticEqualDp(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b Dp
+		want bool
+	}{
+		{"both unspecified", DpUnspecified, DpUnspecified, true},
+		{"one unspecified", DpUnspecified, 0, false},
+		{"equal", 4, 4, true},
+		{"within epsilon", 0.5, 0.5000005, true},
+		{"different", 4, 5, false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, SemanticEqualDp(tc.a, tc.b))
+			require.Equal(t, tc.want, EqualDp(tc.a, tc.b))
+			require.Equal(t, tc.want, tc.a.Equal(tc.b))
+		})
+	}
+}
+
+func TestCopyDp(t *testing.T) {
+	require.Equal(t, Dp(3), CopyDp(3))
+	require.True(t, CopyDp(DpUnspecified).IsUnspecified())
+	require.Equal(t, Dp(3), Dp(3).Copy())
+}
+
+func TestDpArithmetic(t *testing.T) {
+	require.Equal(t, Dp(5), Dp(2).Plus(3))
+	require.Equal(t, Dp(-1), Dp(2).Minus(3))
+	require.Equal(t, Dp(6), Dp(2).Times(3))
+	require.Equal(t, Dp(1), Dp(2).Div(2))
+	require.Equal(t, Dp(-2), Dp(2).Negate())
+
+	require.True(t, DpUnspecified.Plus(3).IsUnspecified())
+	require.True(t, Dp(3).Minus(DpUnspecified).IsUnspecified())
+	require.True(t, DpUnspecified.Times(2).IsUnspecified())
+}
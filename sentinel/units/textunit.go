@@ -0,0 +1,245 @@
+package units
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/zodimo/go-sentinel-helper/sentinel/floatutils"
+	"github.com/zodimo/go-sentinel-helper/sentinel/packed"
+)
+
+// ErrMixedUnits is returned by arithmetic that would combine Sp with Em.
+var ErrMixedUnits = errors.New("units: cannot combine TextUnits of different types")
+
+// TextUnitType is the unit of a TextUnit.
+type TextUnitType uint64
+
+const (
+	TextUnitTypeUnspecified TextUnitType = iota
+	TextUnitTypeSp
+	TextUnitTypeEm
+)
+
+func (t TextUnitType) String() string {
+	switch t {
+	case TextUnitTypeSp:
+		return "Sp"
+	case TextUnitTypeEm:
+		return "Em"
+	default:
+		return "Unspecified"
+	}
+}
+
+type textUnitTag struct{}
+
+var (
+	textUnitLayout = packed.NewLayout[textUnitTag]()
+	textUnitValue  = textUnitLayout.Float32("value")
+	textUnitType   = textUnitLayout.Enum("type", 2)
+)
+
+// TextUnit packs unit type (Sp/Em) and float value into 64 bits.
+// Uses Pattern 1-D from sentinel_pattern.md: the struct wrapper prevents
+// `TextUnit(24)` - use `Sp(24)` or `Em(1.5)`.
+type TextUnit struct {
+	packed packed.Word[textUnitTag]
+}
+
+// 1. Sentinel - TextUnitUnspecified (Unspecified type, NaN value)
+var TextUnitUnspecified = TextUnit{packed: textUnitLayout.Unspecified()}
+
+// newTextUnit packs t and value; a NaN value yields TextUnitUnspecified.
+func newTextUnit(t TextUnitType, value float32) TextUnit {
+	if floatutils.IsUnspecified(value) {
+		return TextUnitUnspecified
+	}
+	w := textUnitType.Set(textUnitLayout.Unspecified(), uint64(t))
+	return TextUnit{packed: textUnitValue.Set(w, value)}
+}
+
+// Constructors (the ONLY way to create valid TextUnits)
+
+// Sp creates a TextUnit in scale-independent pixels.
+func Sp(value float32) TextUnit {
+	return newTextUnit(TextUnitTypeSp, value)
+}
+
+// Em creates a TextUnit relative to the current font size.
+func Em(value float32) TextUnit {
+	return newTextUnit(TextUnitTypeEm, value)
+}
+
+// Type returns the unit type.
+func (tu TextUnit) Type() TextUnitType {
+	return TextUnitType(textUnitType.Get(tu.packed))
+}
+
+// Value returns the magnitude, NaN if Unspecified.
+func (tu TextUnit) Value() float32 {
+	if tu.IsUnspecified() {
+		return floatutils.Float32Unspecified
+	}
+	return textUnitValue.Get(tu.packed)
+}
+
+// IsSp reports whether tu is in Sp.
+func (tu TextUnit) IsSp() bool {
+	return tu.Type() == TextUnitTypeSp
+}
+
+// IsEm reports whether tu is in Em.
+func (tu TextUnit) IsEm() bool {
+	return tu.Type() == TextUnitTypeEm
+}
+
+// 2. IsSpecified - predicate (method on value receiver)
+func (tu TextUnit) IsSpecified() bool {
+	return tu.Type() != TextUnitTypeUnspecified
+}
+
+// IsUnspecified - convenience predicate
+func (tu TextUnit) IsUnspecified() bool {
+	return tu.Type() == TextUnitTypeUnspecified
+}
+
+// SetUnspecified - stores TextUnitUnspecified in the receiver
+func (tu *TextUnit) SetUnspecified() {
+	*tu = TextUnitUnspecified
+}
+
+// IsSpecifiedTextUnit - package-level predicate
+func IsSpecifiedTextUnit(tu TextUnit) bool {
+	return tu.IsSpecified()
+}
+
+// 3. TakeOrElse - 2-param fallback (method on value receiver)
+func (tu TextUnit) TakeOrElse(def TextUnit) TextUnit {
+	if tu.IsSpecified() {
+		return tu
+	}
+	return def
+}
+
+// TakeOrElseTextUnit - package-level fallback
+func TakeOrElseTextUnit(a, b TextUnit) TextUnit {
+	return a.TakeOrElse(b)
+}
+
+// 4. Merge - whole-value replacement, TextUnit is atomic
+func (tu TextUnit) Merge(other TextUnit) TextUnit {
+	if other.IsSpecified() {
+		return other
+	}
+	return tu
+}
+
+// MergeTextUnit - package-level merge function
+func MergeTextUnit(a, b TextUnit) TextUnit {
+	return a.Merge(b)
+}
+
+// 5. String - stringification (method on value receiver)
+func (tu TextUnit) String() string {
+	if tu.IsUnspecified() {
+		return "TextUnit{Unspecified}"
+	}
+	return fmt.Sprintf("TextUnit{%v %s}", tu.Value(), tu.Type())
+}
+
+// StringTextUnit - package-level string function
+func StringTextUnit(tu TextUnit) string {
+	return tu.String()
+}
+
+// 6. Coalesce - N/A for value types (no nil possible)
+
+// 7. Same - identity (for value types, compares the packed bits)
+func (tu TextUnit) Same(other TextUnit) bool {
+	return tu.packed == other.packed
+}
+
+// SameTextUnit - package-level same function
+func SameTextUnit(a, b TextUnit) bool {
+	return a.Same(b)
+}
+
+// 8. SemanticEqual - same type and values within floatutils.Float32EqualityThreshold
+func (tu TextUnit) SemanticEqual(other TextUnit) bool {
+	if tu.Type() != other.Type() {
+		return false
+	}
+	if tu.IsUnspecified() {
+		return true
+	}
+	return floatutils.SemanticEqual(tu.Value(), other.Value())
+}
+
+// SemanticEqualTextUnit - package-level semantic equal function
+func SemanticEqualTextUnit(a, b TextUnit) bool {
+	return a.SemanticEqual(b)
+}
+
+// 9. Equal - equality check (combines Same and SemanticEqual)
+func (tu TextUnit) Equal(other TextUnit) bool {
+	return tu.Same(other) || tu.SemanticEqual(other)
+}
+
+// EqualTextUnit - package-level equal function
+func EqualTextUnit(a, b TextUnit) bool {
+	return a.Equal(b)
+}
+
+// 10. Copy - identity for immutable value types (just returns the value)
+func (tu TextUnit) Copy() TextUnit {
+	return tu
+}
+
+// CopyTextUnit - package-level copy function
+func CopyTextUnit(tu TextUnit) TextUnit {
+	return tu.Copy()
+}
+
+// Arithmetic keeps the unit type. An Unspecified operand yields
+// TextUnitUnspecified; combining Sp with Em returns ErrMixedUnits.
+
+// Times scales tu by factor.
+func (tu TextUnit) Times(factor float32) TextUnit {
+	if tu.IsUnspecified() {
+		return TextUnitUnspecified
+	}
+	return newTextUnit(tu.Type(), tu.Value()*factor)
+}
+
+// Div divides tu by divisor.
+func (tu TextUnit) Div(divisor float32) TextUnit {
+	if tu.IsUnspecified() {
+		return TextUnitUnspecified
+	}
+	return newTextUnit(tu.Type(), tu.Value()/divisor)
+}
+
+// Negate returns tu with its value negated.
+func (tu TextUnit) Negate() TextUnit {
+	return tu.Times(-1)
+}
+
+// Plus returns tu + other, which must share tu's unit type.
+func (tu TextUnit) Plus(other TextUnit) (TextUnit, error) {
+	return tu.combine(other, other.Value())
+}
+
+// Minus returns tu - other, which must share tu's unit type.
+func (tu TextUnit) Minus(other TextUnit) (TextUnit, error) {
+	return tu.combine(other, -other.Value())
+}
+
+func (tu TextUnit) combine(other TextUnit, delta float32) (TextUnit, error) {
+	if tu.IsUnspecified() || other.IsUnspecified() {
+		return TextUnitUnspecified, nil
+	}
+	if tu.Type() != other.Type() {
+		return TextUnitUnspecified, fmt.Errorf("%w: %s and %s", ErrMixedUnits, tu.Type(), other.Type())
+	}
+	return newTextUnit(tu.Type(), tu.Value()+delta), nil
+}
@@ -0,0 +1,128 @@
+package units
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/zodimo/go-sentinel-helper/sentinel/floatutils"
+)
+
+// ErrInvalidTextUnit is returned when text is not a number followed by
+// "sp" or "em".
+var ErrInvalidTextUnit = errors.New("units: invalid TextUnit")
+
+// ParseTextUnit parses the form written by Text, such as "16sp" or
+// "1.5em". The empty string parses as TextUnitUnspecified; "NaNsp" fails,
+// since it would otherwise read as Unspecified.
+func ParseTextUnit(s string) (TextUnit, error) {
+	if s == "" {
+		return TextUnitUnspecified, nil
+	}
+	unit := Sp
+	num, ok := strings.CutSuffix(s, "sp")
+	if !ok {
+		unit = Em
+		if num, ok = strings.CutSuffix(s, "em"); !ok {
+			return TextUnitUnspecified, fmt.Errorf("%w %q: want an sp or em suffix", ErrInvalidTextUnit, s)
+		}
+	}
+	v, err := strconv.ParseFloat(num, 32)
+	if err != nil || floatutils.IsUnspecified(v) {
+		return TextUnitUnspecified, fmt.Errorf("%w %q", ErrInvalidTextUnit, s)
+	}
+	return unit(float32(v)), nil
+}
+
+// Text formats tu as its value followed by "sp" or "em"; Unspecified
+// formats as the empty string. ParseTextUnit(tu.Text()) returns tu.
+func (tu TextUnit) Text() string {
+	if tu.IsUnspecified() {
+		return ""
+	}
+	return strconv.FormatFloat(float64(tu.Value()), 'g', -1, 32) + strings.ToLower(tu.Type().String())
+}
+
+// IsZero reports whether tu is Unspecified, so that encoders honouring
+// `omitempty`/`omitzero` drop it. A specified Sp(0) is kept.
+func (tu TextUnit) IsZero() bool {
+	return tu.IsUnspecified()
+}
+
+// MarshalText implements encoding.TextMarshaler via Text.
+func (tu TextUnit) MarshalText() ([]byte, error) {
+	return []byte(tu.Text()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// The empty string, "null" and "~" decode to Unspecified.
+func (tu *TextUnit) UnmarshalText(text []byte) error {
+	s := string(text)
+	if s == "null" || s == "~" {
+		s = ""
+	}
+	v, err := ParseTextUnit(s)
+	if err != nil {
+		return err
+	}
+	*tu = v
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. TextUnits encode as JSON strings
+// such as "16sp"; Unspecified encodes as null.
+func (tu TextUnit) MarshalJSON() ([]byte, error) {
+	if tu.IsUnspecified() {
+		return []byte("null"), nil
+	}
+	return strconv.AppendQuote(nil, tu.Text()), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. null decodes to Unspecified.
+func (tu *TextUnit) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*tu = TextUnitUnspecified
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return tu.parseSpecified(s)
+}
+
+// MarshalYAML implements yaml.Marshaler without importing a YAML package.
+// Unspecified encodes as null.
+func (tu TextUnit) MarshalYAML() (any, error) {
+	if tu.IsUnspecified() {
+		return nil, nil
+	}
+	return tu.Text(), nil
+}
+
+// UnmarshalYAML implements the yaml.v2/v3 obsolete unmarshaler interface.
+// Null nodes never reach it, and a null or absent key leaves the field
+// as is.
+func (tu *TextUnit) UnmarshalYAML(unmarshal func(any) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	return tu.parseSpecified(s)
+}
+
+// parseSpecified is ParseTextUnit for encodings with their own null, where
+// the empty string is not Unspecified.
+func (tu *TextUnit) parseSpecified(s string) error {
+	if s == "" {
+		return fmt.Errorf("%w %q", ErrInvalidTextUnit, s)
+	}
+	v, err := ParseTextUnit(s)
+	if err != nil {
+		return err
+	}
+	*tu = v
+	return nil
+}
@@ -0,0 +1,155 @@
+package units
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestTextUnitConstructors(t *testing.T) {
+	sp := Sp(16)
+	require.True(t, sp.IsSpecified())
+	require.True(t, sp.IsSp())
+	require.False(t, sp.IsEm())
+	require.Equal(t, TextUnitTypeSp, sp.Type())
+	require.Equal(t, float32(16), sp.Value())
+
+	em := Em(1.5)
+	require.True(t, em.IsEm())
+	require.Equal(t, TextUnitTypeEm, em.Type())
+	require.Equal(t, float32(1.5), em.Value())
+
+	require.True(t, Sp(0).IsSpecified())
+	require.True(t, Sp(float32(math.NaN())).IsUnspecified())
+}
+
+func TestIsSpecifiedTextUnit(t *testing.T) {
+	var zero TextUnit
+	require.False(t, IsSpecifiedTextUnit(TextUnitUnspecified))
+	require.True(t, TextUnitUnspecified.IsUnspecified())
+	require.Equal(t, TextUnitTypeUnspecified, TextUnitUnspecified.Type())
+	require.True(t, math.IsNaN(float64(TextUnitUnspecified.Value())))
+	require.True(t, zero.IsUnspecified())
+	require.True(t, IsSpecifiedTextUnit(Em(0)))
+}
+
+func TestTakeOrElseTextUnit(t *testing.T) {
+	require.True(t, TakeOrElseTextUnit(Sp(1), Em(2)).Equal(Sp(1)))
+	require.True(t, TakeOrElseTextUnit(TextUnitUnspecified, Em(2)).Equal(Em(2)))
+	require.True(t, TextUnitUnspecified.TakeOrElse(TextUnitUnspecified).IsUnspecified())
+}
+
+func TestMergeTextUnit(t *testing.T) {
+	require.True(t, MergeTextUnit(Sp(1), Em(2)).Equal(Em(2)))
+	require.True(t, MergeTextUnit(Sp(1), TextUnitUnspecified).Equal(Sp(1)))
+	require.True(t, MergeTextUnit(TextUnitUnspecified, Sp(3)).Equal(Sp(3)))
+}
+
+func TestStringTextUnit(t *testing.T) {
+	require.Equal(t, "TextUnit{Unspecified}", StringTextUnit(TextUnitUnspecified))
+	require.Equal(t, "TextUnit{24 Sp}", StringTextUnit(Sp(24)))
+	require.Equal(t, "TextUnit{1.5 Em}", Em(1.5).String())
+	require.Equal(t, "Unspecified", TextUnitTypeUnspecified.String())
+}
+
+func TestTextUnitEquality(t *testing.T) {
+	tests := []struct {
+		name                 string
+		a, b                 TextUnit
+		same, semantic, want bool
+	}{
+		{"both unspecified", TextUnitUnspecified, TextUnitUnspecified, true, true, true},
+		{"unspecified vs Sp", TextUnitUnspecified, Sp(1), false, false, false},
+		{"equal Sp", Sp(12), Sp(12), true, true, true},
+		{"Sp within epsilon", Sp(0.5), Sp(0.5000005), false, true, true},
+		{"different values", Sp(12), Sp(13), false, false, false},
+		{"different units", Sp(1), Em(1), false, false, false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.same, SameTextUnit(tc.a, tc.b))
+			require.Equal(t, tc.semantic, SemanticEqualTextUnit(tc.a, tc.b))
+			require.Equal(t, tc.want, EqualTextUnit(tc.a, tc.b))
+		})
+	}
+}
+
+func TestCopyTextUnit(t *testing.T) {
+	require.True(t, CopyTextUnit(Em(2)).Same(Em(2)))
+	require.True(t, TextUnitUnspecified.Copy().IsUnspecified())
+}
+
+func TestTextUnitArithmetic(t *testing.T) {
+	require.True(t, Sp(2).Times(3).Equal(Sp(6)))
+	require.True(t, Em(3).Div(2).Equal(Em(1.5)))
+	require.True(t, Sp(2).Negate().Equal(Sp(-2)))
+	require.True(t, TextUnitUnspecified.Times(3).IsUnspecified())
+	require.True(t, TextUnitUnspecified.Div(3).IsUnspecified())
+
+	sum, err := Sp(2).Plus(Sp(3))
+	require.NoError(t, err)
+	require.True(t, sum.Equal(Sp(5)))
+
+	diff, err := Em(2).Minus(Em(0.5))
+	require.NoError(t, err)
+	require.True(t, diff.Equal(Em(1.5)))
+
+	_, err = Sp(2).Plus(Em(1))
+	require.ErrorIs(t, err, ErrMixedUnits)
+	_, err = Em(2).Minus(Sp(1))
+	require.ErrorIs(t, err, ErrMixedUnits)
+
+	unspec, err := Sp(2).Plus(TextUnitUnspecified)
+	require.NoError(t, err)
+	require.True(t, unspec.IsUnspecified())
+}
+
+func TestTextUnitText(t *testing.T) {
+	for _, tu := range []TextUnit{Sp(16), Em(1.5), Sp(-0.25), TextUnitUnspecified} {
+		var back TextUnit
+		require.NoError(t, back.UnmarshalText([]byte(tu.Text())))
+		require.True(t, back.Same(tu) || back.IsUnspecified() && tu.IsUnspecified(), "%v", tu)
+	}
+	require.Equal(t, "16sp", Sp(16).Text())
+	require.Equal(t, "1.5em", Em(1.5).Text())
+
+	for _, s := range []string{"16", "16px", "sp", "NaNsp"} {
+		_, err := ParseTextUnit(s)
+		require.ErrorIs(t, err, ErrInvalidTextUnit, s)
+	}
+}
+
+func TestTextUnitJSON(t *testing.T) {
+	type style struct {
+		Size   TextUnit `json:"size"`
+		Height TextUnit `json:"height,omitzero"`
+		Track  TextUnit `json:"track"`
+	}
+	out, err := json.Marshal(style{Size: Sp(14), Height: TextUnitUnspecified, Track: TextUnitUnspecified})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"size":"14sp","track":null}`, string(out))
+
+	var back style
+	require.NoError(t, json.Unmarshal([]byte(`{"size":"1.2em","track":null}`), &back))
+	require.True(t, back.Size.Same(Em(1.2)))
+	require.True(t, back.Track.IsUnspecified())
+	require.ErrorIs(t, json.Unmarshal([]byte(`{"size":""}`), &back), ErrInvalidTextUnit)
+}
+
+func TestTextUnitYAML(t *testing.T) {
+	type style struct {
+		Size  TextUnit `yaml:"size"`
+		Track TextUnit `yaml:"track"`
+	}
+	out, err := yaml.Marshal(style{Size: Em(2), Track: TextUnitUnspecified})
+	require.NoError(t, err)
+	require.Equal(t, "size: 2em\ntrack: null\n", string(out))
+
+	back := style{Track: Sp(1)}
+	require.NoError(t, yaml.Unmarshal([]byte("size: 12sp\ntrack: ~\n"), &back))
+	require.True(t, back.Size.Same(Sp(12)))
+	require.True(t, back.Track.Same(Sp(1)), "null leaves the field as is")
+}
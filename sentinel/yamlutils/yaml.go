@@ -0,0 +1,312 @@
+package yamlutils
+
+import (
+	"encoding"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/zodimo/go-sentinel-helper/internal/sentinelreflect"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrInvalidTarget is returned when a decode target is not a non-nil pointer to a struct.
+var ErrInvalidTarget = errors.New("yamlutils: target must be a non-nil pointer to a struct")
+
+// ErrSentinelCollision is returned for a value that decodes to its field's
+// Unspecified sentinel, such as math.MinInt for an int or .nan for a
+// float; null is the way to leave a field Unspecified.
+var ErrSentinelCollision = errors.New("yamlutils: value collides with the Unspecified sentinel")
+
+// ErrInvalidSource is returned when an encode source is not a struct or pointer to one.
+var ErrInvalidSource = errors.New("yamlutils: source must be a struct or a pointer to a struct")
+
+// Marshal encodes the struct v as YAML, omitting every field that holds its
+// type's Unspecified sentinel and every nested struct left with no fields.
+func Marshal(v any) ([]byte, error) {
+	node, err := EncodeNode(v)
+	if err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(node)
+}
+
+// Unmarshal decodes YAML into the struct pointed to by v.
+// Absent keys and null (`~`, `null`) values leave sentinel-typed fields
+// at their Unspecified sentinel.
+func Unmarshal(data []byte, v any) error {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	return DecodeNode(&doc, v)
+}
+
+// EncodeNode encodes the struct v into a YAML mapping node.
+func EncodeNode(v any) (*yaml.Node, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, ErrInvalidSource
+	}
+	node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	if err := encodeStruct(node, rv); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+// DecodeNode decodes a YAML node into the struct pointed to by v.
+func DecodeNode(node *yaml.Node, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return ErrInvalidTarget
+	}
+	return decodeStruct(node, rv.Elem())
+}
+
+var textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+
+// isNested reports whether t is a struct to encode key by key. Structs
+// that sentinelreflect treats as opaque (netip.Addr) or that
+// bring their own YAML or text decoding go to yaml.v3 as a whole.
+func isNested(t reflect.Type) bool {
+	if !sentinelreflect.IsNested(t) {
+		return false
+	}
+	pt := reflect.PointerTo(t)
+	_, marshal := pt.MethodByName("MarshalYAML")
+	_, unmarshal := pt.MethodByName("UnmarshalYAML")
+	return !marshal && !unmarshal && !pt.Implements(textUnmarshalerType)
+}
+
+type fieldInfo struct {
+	name      string
+	omitEmpty bool
+	inline    bool
+}
+
+// parseField mirrors the yaml.v3 key naming: the tag name, or the
+// lowercased field name when the tag omits it.
+func parseField(sf reflect.StructField) (fieldInfo, bool) {
+	if !sf.IsExported() {
+		return fieldInfo{}, false
+	}
+	tag := sf.Tag.Get("yaml")
+	if tag == "-" {
+		return fieldInfo{}, false
+	}
+	name, flags, _ := strings.Cut(tag, ",")
+	info := fieldInfo{name: name}
+	for _, flag := range strings.Split(flags, ",") {
+		switch flag {
+		case "omitempty":
+			info.omitEmpty = true
+		case "inline":
+			info.inline = true
+		}
+	}
+	if info.name == "" {
+		info.name = strings.ToLower(sf.Name)
+	}
+	return info, true
+}
+
+func encodeStruct(node *yaml.Node, sv reflect.Value) error {
+	st := sv.Type()
+	for i := 0; i < st.NumField(); i++ {
+		info, ok := parseField(st.Field(i))
+		if !ok {
+			continue
+		}
+		fv := sv.Field(i)
+
+		var value *yaml.Node
+		switch {
+		case sentinelreflect.IsLeaf(fv.Type()):
+			if sentinelreflect.IsUnspecified(fv) {
+				continue
+			}
+			var err error
+			if value, err = encodeLeaf(fv); err != nil {
+				return err
+			}
+		case isNested(fv.Type()):
+			if info.inline {
+				if err := encodeStruct(node, fv); err != nil {
+					return err
+				}
+				continue
+			}
+			value = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			if err := encodeStruct(value, fv); err != nil {
+				return err
+			}
+			if len(value.Content) == 0 {
+				continue
+			}
+		default:
+			if info.omitEmpty && fv.IsZero() {
+				continue
+			}
+			value = &yaml.Node{}
+			if err := value.Encode(fv.Interface()); err != nil {
+				return err
+			}
+		}
+
+		key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: info.name}
+		node.Content = append(node.Content, key, value)
+	}
+	return nil
+}
+
+func encodeLeaf(fv reflect.Value) (*yaml.Node, error) {
+	// 1-D value types encode through their own MarshalYAML.
+	scalar := fv.Interface()
+	if sentinelreflect.IsWrapper(fv.Type()) {
+		field := sentinelreflect.WrapperField(fv)
+		if field.Kind() == reflect.Slice {
+			return &yaml.Node{
+				Kind:  yaml.ScalarNode,
+				Tag:   "!!binary",
+				Value: base64.StdEncoding.EncodeToString(field.Bytes()),
+			}, nil
+		}
+		scalar = field.Interface()
+	}
+	node := &yaml.Node{}
+	if err := node.Encode(scalar); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+func resolve(node *yaml.Node) *yaml.Node {
+	for node != nil {
+		switch node.Kind {
+		case yaml.DocumentNode:
+			if len(node.Content) == 0 {
+				return nil
+			}
+			node = node.Content[0]
+		case yaml.AliasNode:
+			node = node.Alias
+		default:
+			return node
+		}
+	}
+	return nil
+}
+
+func isNull(node *yaml.Node) bool {
+	return node == nil || (node.Kind == yaml.ScalarNode && node.ShortTag() == "!!null")
+}
+
+// presetStruct puts every sentinel-typed field of sv, recursively, at its
+// Unspecified sentinel so that absent keys decode as Unspecified.
+func presetStruct(sv reflect.Value) {
+	st := sv.Type()
+	for i := 0; i < st.NumField(); i++ {
+		if _, ok := parseField(st.Field(i)); !ok {
+			continue
+		}
+		fv := sv.Field(i)
+		if isNested(fv.Type()) {
+			presetStruct(fv)
+			continue
+		}
+		sentinelreflect.SetUnspecified(fv)
+	}
+}
+
+func decodeStruct(node *yaml.Node, sv reflect.Value) error {
+	presetStruct(sv)
+
+	node = resolve(node)
+	if isNull(node) {
+		return nil
+	}
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("yamlutils: line %d: cannot decode %s into %s", node.Line, node.ShortTag(), sv.Type())
+	}
+
+	values := make(map[string]*yaml.Node, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		values[node.Content[i].Value] = node.Content[i+1]
+	}
+	return decodeFields(values, sv)
+}
+
+func decodeFields(values map[string]*yaml.Node, sv reflect.Value) error {
+	st := sv.Type()
+	for i := 0; i < st.NumField(); i++ {
+		info, ok := parseField(st.Field(i))
+		if !ok {
+			continue
+		}
+		fv := sv.Field(i)
+
+		if info.inline && isNested(fv.Type()) {
+			if err := decodeFields(values, fv); err != nil {
+				return err
+			}
+			continue
+		}
+
+		value, present := values[info.name]
+		if !present {
+			continue
+		}
+		value = resolve(value)
+
+		var err error
+		switch {
+		case sentinelreflect.IsLeaf(fv.Type()):
+			err = decodeLeaf(value, fv)
+		case isNested(fv.Type()):
+			err = decodeStruct(value, fv)
+		default:
+			err = value.Decode(fv.Addr().Interface())
+		}
+		if err != nil {
+			return fmt.Errorf("yamlutils: field %q: %w", info.name, err)
+		}
+	}
+	return nil
+}
+
+func decodeLeaf(node *yaml.Node, fv reflect.Value) error {
+	if isNull(node) {
+		sentinelreflect.SetUnspecified(fv)
+		return nil
+	}
+
+	// 1-D value types decode through their own UnmarshalYAML.
+	if t := fv.Type(); sentinelreflect.IsWrapper(t) {
+		ptr, field := sentinelreflect.NewWrapper(t)
+		if field.Kind() == reflect.Slice {
+			// yaml.v3 resolves !!binary to its decoded string form.
+			var s string
+			if err := node.Decode(&s); err != nil {
+				return err
+			}
+			field.SetBytes([]byte(s))
+		} else if err := node.Decode(field.Addr().Interface()); err != nil {
+			return err
+		}
+		fv.Set(ptr)
+		return nil
+	}
+	if err := node.Decode(fv.Addr().Interface()); err != nil {
+		return err
+	}
+	if err := sentinelreflect.FromUntrusted(fv); err != nil {
+		return fmt.Errorf("%w: %w", ErrSentinelCollision, err)
+	}
+	return nil
+}
@@ -0,0 +1,250 @@
+package yamlutils
+
+import (
+	"fmt"
+	"math"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zodimo/go-sentinel-helper/sentinel/boolutils"
+	"github.com/zodimo/go-sentinel-helper/sentinel/enumutils"
+	"github.com/zodimo/go-sentinel-helper/sentinel/floatutils"
+	"github.com/zodimo/go-sentinel-helper/sentinel/intutils"
+	"github.com/zodimo/go-sentinel-helper/sentinel/protobufwrapper"
+	"github.com/zodimo/go-sentinel-helper/sentinel/stringutils"
+	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type limits struct {
+	MaxConns intutils.IntValue `yaml:"max_conns"`
+}
+
+type common struct {
+	Region stringutils.StringValue `yaml:"region"`
+}
+
+type teamConfig struct {
+	Name     stringutils.StringValue `yaml:"name"`
+	Size     intutils.IntValue       `yaml:"size"`
+	Ratio    float64                 `yaml:"ratio"`
+	Scale    float32                 `yaml:"scale"`
+	Enabled  boolutils.BooleanValue  `yaml:"enabled"`
+	Retries  *wrapperspb.Int32Value  `yaml:"retries"`
+	Label    *wrapperspb.StringValue `yaml:"label"`
+	Blob     *wrapperspb.BytesValue  `yaml:"blob"`
+	Limits   limits                  `yaml:"limits"`
+	Common   common                  `yaml:",inline"`
+	Tags     []string                `yaml:"tags,omitempty"`
+	Internal string                  `yaml:"-"`
+}
+
+func TestUnmarshal_AbsentAndNullAreUnspecified(t *testing.T) {
+	doc := []byte("name: ~\nsize: null\nenabled: ~\nretries: null\nlimits: ~\n")
+
+	var cfg teamConfig
+	require.NoError(t, Unmarshal(doc, &cfg))
+
+	require.True(t, stringutils.IsUnspecifiedString(cfg.Name))
+	require.True(t, intutils.IsUnspecifiedIntValue(cfg.Size))
+	require.True(t, floatutils.IsUnspecified(cfg.Ratio))
+	require.True(t, floatutils.IsUnspecified(cfg.Scale))
+	require.True(t, cfg.Enabled.IsUnspecified())
+	require.Same(t, protobufwrapper.Int32ValueUnspecified, cfg.Retries)
+	require.Same(t, protobufwrapper.StringValueUnspecified, cfg.Label)
+	require.Same(t, protobufwrapper.BytesValueUnspecified, cfg.Blob)
+	require.True(t, intutils.IsUnspecifiedIntValue(cfg.Limits.MaxConns))
+	require.True(t, stringutils.IsUnspecifiedString(cfg.Common.Region))
+}
+
+func TestUnmarshal_NullOverridesExistingValue(t *testing.T) {
+	cfg := teamConfig{Size: 3, Enabled: boolutils.BooleanValueTrue()}
+	require.NoError(t, Unmarshal([]byte("size: ~\nenabled: null\n"), &cfg))
+	require.True(t, intutils.IsUnspecifiedIntValue(cfg.Size))
+	require.True(t, cfg.Enabled.IsUnspecified())
+}
+
+func TestUnmarshal_Values(t *testing.T) {
+	doc := []byte(`
+name: ""
+size: 0
+ratio: 0.5
+scale: 2
+enabled: false
+retries: 0
+label: ""
+blob: !!binary aGk=
+limits:
+  max_conns: 10
+region: eu
+tags: [a, b]
+`)
+	var cfg teamConfig
+	require.NoError(t, Unmarshal(doc, &cfg))
+
+	require.True(t, stringutils.IsSpecifiedString(cfg.Name))
+	require.Equal(t, "", cfg.Name)
+	require.Equal(t, 0, cfg.Size)
+	require.Equal(t, 0.5, cfg.Ratio)
+	require.Equal(t, float32(2), cfg.Scale)
+	require.True(t, cfg.Enabled.IsFalse())
+	require.True(t, protobufwrapper.IsSpecifiedInt32Value(cfg.Retries))
+	require.Equal(t, int32(0), cfg.Retries.Value)
+	require.True(t, protobufwrapper.IsSpecifiedStringValue(cfg.Label))
+	require.Equal(t, []byte("hi"), cfg.Blob.Value)
+	require.Equal(t, 10, cfg.Limits.MaxConns)
+	require.Equal(t, "eu", cfg.Common.Region)
+	require.Equal(t, []string{"a", "b"}, cfg.Tags)
+}
+
+func TestUnmarshal_Errors(t *testing.T) {
+	var cfg teamConfig
+	require.Error(t, Unmarshal([]byte("size: ten\n"), &cfg))
+	require.Error(t, Unmarshal([]byte("retries: [1]\n"), &cfg))
+	require.Error(t, Unmarshal([]byte("- a\n"), &cfg))
+	require.ErrorIs(t, Unmarshal(fmt.Appendf(nil, "size: %d\n", math.MinInt), &cfg), ErrSentinelCollision)
+	require.ErrorIs(t, Unmarshal([]byte("name: \"\\0unspecified\"\n"), &cfg), ErrSentinelCollision)
+	require.ErrorIs(t, Unmarshal([]byte("ratio: .nan\n"), &cfg), ErrSentinelCollision)
+	require.ErrorIs(t, Unmarshal([]byte("scale: .NaN\n"), &cfg), ErrSentinelCollision)
+	require.ErrorIs(t, Unmarshal([]byte("a: 1\n"), cfg), ErrInvalidTarget)
+}
+
+func TestMarshal_OmitsUnspecified(t *testing.T) {
+	cfg := teamConfig{
+		Name:    stringutils.StringValueUnspecified,
+		Size:    intutils.IntValueUnspecified,
+		Ratio:   floatutils.Float64Unspecified,
+		Scale:   floatutils.Float32Unspecified,
+		Enabled: boolutils.BooleanValueUnspecified,
+		Retries: protobufwrapper.Int32ValueUnspecified,
+		Limits:  limits{MaxConns: intutils.IntValueUnspecified},
+		Common:  common{Region: stringutils.StringValueUnspecified},
+	}
+	out, err := Marshal(cfg)
+	require.NoError(t, err)
+	require.Equal(t, "{}\n", string(out))
+}
+
+func TestMarshal_RoundTrip(t *testing.T) {
+	in := teamConfig{
+		Name:    "",
+		Size:    0,
+		Ratio:   floatutils.Float64Unspecified,
+		Scale:   1.5,
+		Enabled: boolutils.BooleanValueFalse(),
+		Retries: wrapperspb.Int32(0),
+		Label:   protobufwrapper.StringValueUnspecified,
+		Blob:    wrapperspb.Bytes([]byte("hi")),
+		Limits:  limits{MaxConns: 4},
+		Common:  common{Region: "us"},
+	}
+	out, err := Marshal(&in)
+	require.NoError(t, err)
+	require.Equal(t, `name: ""
+size: 0
+scale: 1.5
+enabled: false
+retries: 0
+blob: !!binary aGk=
+limits:
+    max_conns: 4
+region: us
+`, string(out))
+
+	var back teamConfig
+	require.NoError(t, Unmarshal(out, &back))
+	require.Equal(t, in.Name, back.Name)
+	require.Equal(t, in.Size, back.Size)
+	require.True(t, floatutils.IsUnspecified(back.Ratio))
+	require.Equal(t, in.Scale, back.Scale)
+	require.True(t, back.Enabled.Equal(in.Enabled))
+	require.True(t, protobufwrapper.EqualInt32Value(in.Retries, back.Retries))
+	require.Same(t, protobufwrapper.StringValueUnspecified, back.Label)
+	require.True(t, protobufwrapper.EqualBytesValue(in.Blob, back.Blob))
+	require.Equal(t, in.Limits, back.Limits)
+	require.Equal(t, in.Common, back.Common)
+}
+
+func TestMarshal_InvalidSource(t *testing.T) {
+	_, err := Marshal(42)
+	require.ErrorIs(t, err, ErrInvalidSource)
+}
+
+type directionDef struct{}
+
+func (directionDef) TypeName() string { return "Direction" }
+func (directionDef) Names() []string  { return []string{"Ltr", "Rtl"} }
+
+func TestEnumFields(t *testing.T) {
+	type layout struct {
+		Dir  enumutils.Enum[directionDef] `yaml:"dir"`
+		Next enumutils.Enum[directionDef] `yaml:"next"`
+	}
+
+	out, err := Marshal(layout{Dir: enumutils.MustParse[directionDef]("Rtl")})
+	require.NoError(t, err)
+	require.Equal(t, "dir: Rtl\n", string(out))
+
+	in := layout{Next: enumutils.MustParse[directionDef]("Ltr")}
+	require.NoError(t, Unmarshal([]byte("dir: Ltr\nnext: ~\n"), &in))
+	require.Equal(t, "Ltr", in.Dir.Name())
+	require.True(t, in.Next.IsUnspecified())
+}
+
+// version has exported fields but encodes itself as a scalar.
+type version struct {
+	Major, Minor int
+}
+
+func (v version) MarshalText() ([]byte, error) {
+	return fmt.Appendf(nil, "%d.%d", v.Major, v.Minor), nil
+}
+
+func (v *version) UnmarshalText(b []byte) error {
+	_, err := fmt.Sscanf(string(b), "%d.%d", &v.Major, &v.Minor)
+	return err
+}
+
+func TestOpaqueStructFields(t *testing.T) {
+	type server struct {
+		Listen  netip.Addr `yaml:"listen"`
+		Started time.Time  `yaml:"started"`
+		Version version    `yaml:"version"`
+	}
+
+	in := server{
+		Listen:  netip.IPv4Unspecified(),
+		Started: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Version: version{Major: 1, Minor: 2},
+	}
+	out, err := Marshal(in)
+	require.NoError(t, err)
+	require.Equal(t, "listen: 0.0.0.0\nstarted: 2024-01-02T03:04:05Z\nversion: \"1.2\"\n", string(out))
+
+	var back server
+	require.NoError(t, Unmarshal(out, &back))
+	require.Equal(t, in.Listen, back.Listen)
+	require.True(t, in.Started.Equal(back.Started))
+	require.Equal(t, in.Version, back.Version)
+
+	require.NoError(t, Unmarshal([]byte("started: !!timestamp 2024-01-02\n"), &back))
+	require.True(t, back.Started.Equal(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestInternedStringFields(t *testing.T) {
+	type font struct {
+		Family stringutils.InternedString `yaml:"family"`
+		Locale stringutils.InternedString `yaml:"locale"`
+	}
+
+	in := font{Family: stringutils.Intern("Roboto")}
+	out, err := Marshal(in)
+	require.NoError(t, err)
+	require.Equal(t, "family: Roboto\n", string(out))
+
+	back := font{Locale: stringutils.Intern("en")}
+	require.NoError(t, Unmarshal(append(out, "locale: ~\n"...), &back))
+	require.True(t, back.Family.Same(in.Family))
+	require.True(t, back.Locale.IsUnspecified())
+}